@@ -12,6 +12,8 @@ import (
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/identity"
 	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/limits"
+	"github.com/sprawl/sprawl/metrics"
 	"github.com/sprawl/sprawl/p2p"
 	"github.com/sprawl/sprawl/pb"
 	"github.com/sprawl/sprawl/service"
@@ -24,6 +26,7 @@ type App struct {
 	P2p              *p2p.P2p
 	Server           *service.Server
 	Logger           interfaces.Logger
+	Metrics          *metrics.Metrics
 	config           interfaces.Config
 	WebsocketService interfaces.WebsocketService
 }
@@ -68,6 +71,12 @@ func (app *App) InitServices(config interfaces.Config, Logger interfaces.Logger)
 	app.Storage.SetDbPath(app.config.GetDatabasePath())
 	app.Storage.Run()
 
+	// Time every storage operation when metrics are served
+	if app.config.GetMetricsEnable() {
+		app.Metrics = metrics.NewMetrics(app.Logger)
+		app.Storage = app.Metrics.InstrumentStorage(app.Storage)
+	}
+
 	privateKey, publicKey, err := identity.GetIdentity(app.Storage)
 
 	if !errors.IsEmpty(err) {
@@ -75,15 +84,43 @@ func (app *App) InitServices(config interfaces.Config, Logger interfaces.Logger)
 	}
 
 	if app.config.GetWebsocketEnable() {
-		app.WebsocketService = &service.WebsocketService{Logger: Logger, Port: app.config.GetWebsocketPort()}
+		app.WebsocketService = &service.WebsocketService{
+			Logger:         Logger,
+			Port:           app.config.GetWebsocketPort(),
+			MaxConnections: app.config.GetWebsocketMaxConnections(),
+			AllowedOrigins: app.config.GetWebsocketAllowedOrigins(),
+			APIKey:         app.config.GetWebsocketAPIKey(),
+			CertFile:       app.config.GetWebsocketCertFile(),
+			KeyFile:        app.config.GetWebsocketKeyFile(),
+		}
 		go app.WebsocketService.Start()
 	}
 
 	// Run the P2P process
-	app.P2p = p2p.NewP2p(config, privateKey, publicKey, p2p.Logger(app.Logger), p2p.Storage(app.Storage))
+	p2pOptions := []p2p.Option{p2p.Logger(app.Logger), p2p.Storage(app.Storage)}
+	if app.Metrics != nil {
+		p2pOptions = append(p2pOptions, p2p.Metrics(app.Metrics))
+	}
+	app.P2p = p2p.NewP2p(config, privateKey, publicKey, p2pOptions...)
 
 	// Construct the server struct
 	app.Server = service.NewServer(Logger, app.Storage, app.P2p, app.WebsocketService)
+	app.Server.Address = app.config.GetRPCAddress()
+	app.Server.CertFile = app.config.GetRPCCertFile()
+	app.Server.KeyFile = app.config.GetRPCKeyFile()
+	app.Server.ClientCAFile = app.config.GetRPCClientCAFile()
+	app.Server.APIToken = app.config.GetRPCAPIToken()
+	app.Server.APIKeys.AdminToken = app.Server.APIToken != ""
+	app.Server.Orders.MaxQuoteTTL = time.Duration(app.config.GetMaxQuoteTTL()) * time.Second
+
+	// Limit how much other peers can send on channels
+	app.Server.RegisterLimiter(limits.NewLimiter(app.config.GetMessagesPerSecond(), app.config.GetMessageBurst(), app.config.GetMaxOpenOrders(), app.Logger))
+
+	if app.Metrics != nil {
+		app.Server.RegisterMetrics(app.Metrics)
+		app.Metrics.RegisterP2p(app.P2p)
+		app.Metrics.RegisterOrderCounter(app.Server.Orders)
+	}
 
 	// Connect the order service as a receiver for p2p
 	app.P2p.AddReceiver(app.Server.Orders)
@@ -122,6 +159,17 @@ func (app *App) Run() {
 		go app.debugPinger()
 	}
 
+	// Serve Prometheus metrics
+	if app.Metrics != nil {
+		defer app.Metrics.Close()
+		go app.Metrics.Run(app.config.GetMetricsAddress(), app.config.GetMetricsPort())
+	}
+
+	// Serve the gRPC API as REST/JSON too
+	if app.config.GetGatewayEnable() {
+		go app.Server.RunGateway(app.config.GetGatewayPort(), app.config.GetRPCPort())
+	}
+
 	// Run the gRPC API
 	app.Server.Run(app.config.GetRPCPort())
 }
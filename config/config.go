@@ -12,6 +12,16 @@ import (
 const dbPathVar string = "database.path"
 const dbInMemoryVar string = "database.inMemory"
 const rpcPortVar string = "rpc.port"
+const rpcAddressVar string = "rpc.address"
+const rpcCertFileVar string = "rpc.certFile"
+const rpcKeyFileVar string = "rpc.keyFile"
+const rpcClientCAFileVar string = "rpc.clientCAFile"
+const rpcAPITokenVar string = "rpc.apiToken"
+const gatewayEnableVar string = "gateway.enable"
+const gatewayPortVar string = "gateway.port"
+const metricsEnableVar string = "metrics.enable"
+const metricsPortVar string = "metrics.port"
+const metricsAddressVar string = "metrics.address"
 const p2pExternalIPVar string = "p2p.externalIP"
 const p2pPortVar string = "p2p.port"
 const p2pDebugVar string = "p2p.debug"
@@ -19,11 +29,22 @@ const p2pRelayVar string = "p2p.enableRelay"
 const p2pAutoRelayVar string = "p2p.enableAutoRelay"
 const p2pNATPortMapVar string = "p2p.enableNATPortMap"
 const ipfsPeerVar string = "p2p.useIPFSPeers"
+const p2pSyncIntervalVar string = "p2p.syncInterval"
+const p2pSyncPeersVar string = "p2p.syncPeers"
+const limitsMessagesPerSecondVar string = "limits.messagesPerSecond"
+const limitsMessageBurstVar string = "limits.messageBurst"
+const limitsMaxOpenOrdersVar string = "limits.maxOpenOrders"
+const limitsMaxQuoteTTLVar string = "limits.maxQuoteTTL"
 const errorsEnableStackTraceVar string = "errors.enableStackTrace"
 const logLevelVar string = "log.level"
 const logFormatVar string = "log.format"
 const websocketEnableVar string = "websocket.enable"
 const websocketPortVar string = "websocket.port"
+const websocketMaxConnectionsVar string = "websocket.maxConnections"
+const websocketAllowedOriginsVar string = "websocket.allowedOrigins"
+const websocketAPIKeyVar string = "websocket.apiKey"
+const websocketCertFileVar string = "websocket.certFile"
+const websocketKeyFileVar string = "websocket.keyFile"
 
 // Config has an initialized version of spf13/viper
 type Config struct {
@@ -81,13 +102,34 @@ func (c *Config) ReadConfig(configPath string) {
 	}
 
 	c.AddString(dbPathVar)
+	c.AddString(rpcAddressVar)
+	c.AddString(rpcCertFileVar)
+	c.AddString(rpcKeyFileVar)
+	c.AddString(rpcClientCAFileVar)
+	c.AddString(rpcAPITokenVar)
+	c.AddString(metricsAddressVar)
 	c.AddString(p2pExternalIPVar)
 	c.AddString(logLevelVar)
 	c.AddString(logFormatVar)
+	c.AddString(websocketAllowedOriginsVar)
+	c.AddString(websocketAPIKeyVar)
+	c.AddString(websocketCertFileVar)
+	c.AddString(websocketKeyFileVar)
 	c.AddUint(p2pPortVar)
 	c.AddUint(rpcPortVar)
+	c.AddUint(gatewayPortVar)
+	c.AddUint(metricsPortVar)
 	c.AddUint(websocketPortVar)
+	c.AddUint(websocketMaxConnectionsVar)
+	c.AddUint(p2pSyncIntervalVar)
+	c.AddUint(p2pSyncPeersVar)
+	c.AddUint(limitsMessagesPerSecondVar)
+	c.AddUint(limitsMessageBurstVar)
+	c.AddUint(limitsMaxOpenOrdersVar)
+	c.AddUint(limitsMaxQuoteTTLVar)
 	c.AddBoolean(websocketEnableVar)
+	c.AddBoolean(gatewayEnableVar)
+	c.AddBoolean(metricsEnableVar)
 	c.AddBoolean(dbInMemoryVar)
 	c.AddBoolean(p2pNATPortMapVar)
 	c.AddBoolean(p2pRelayVar)
@@ -168,16 +210,128 @@ func (c *Config) GetP2PPort() uint {
 	return c.uints[p2pPortVar]
 }
 
+// GetSyncInterval defines how often, in seconds, joined channels are synced with random peers. 0 disables periodic sync.
+func (c *Config) GetSyncInterval() uint {
+	return c.uints[p2pSyncIntervalVar]
+}
+
+// GetSyncPeers defines how many random peers a channel is synced with, both on join and periodically
+func (c *Config) GetSyncPeers() uint {
+	return c.uints[p2pSyncPeersVar]
+}
+
+// GetMessagesPerSecond defines how many messages per second each peer can send on a channel. 0 disables the limit.
+func (c *Config) GetMessagesPerSecond() uint {
+	return c.uints[limitsMessagesPerSecondVar]
+}
+
+// GetMessageBurst defines how many messages each peer can send on a channel in a burst over limits.messagesPerSecond
+func (c *Config) GetMessageBurst() uint {
+	return c.uints[limitsMessageBurstVar]
+}
+
+// GetMaxOpenOrders defines how many orders each peer can have open on a channel. 0 disables the limit.
+func (c *Config) GetMaxOpenOrders() uint {
+	return c.uints[limitsMaxOpenOrdersVar]
+}
+
+// GetMaxQuoteTTL defines the longest time in seconds that quote requests and quotes can be valid for
+func (c *Config) GetMaxQuoteTTL() uint {
+	return c.uints[limitsMaxQuoteTTLVar]
+}
+
 // GetRPCPort defines the port the gRPC is running at
 func (c *Config) GetRPCPort() uint {
 	return c.uints[rpcPortVar]
 }
 
+// GetRPCAddress defines the interface the gRPC API listens on. Empty listens on all interfaces.
+func (c *Config) GetRPCAddress() string {
+	return c.strings[rpcAddressVar]
+}
+
+// GetRPCCertFile defines the TLS certificate of the gRPC API. TLS is used when both it and rpc.keyFile are set.
+func (c *Config) GetRPCCertFile() string {
+	return c.strings[rpcCertFileVar]
+}
+
+// GetRPCKeyFile defines the private key of the gRPC API's TLS certificate
+func (c *Config) GetRPCKeyFile() string {
+	return c.strings[rpcKeyFileVar]
+}
+
+// GetRPCClientCAFile defines the CA certificates gRPC clients must present a certificate from. Empty doesn't ask clients for certificates.
+func (c *Config) GetRPCClientCAFile() string {
+	return c.strings[rpcClientCAFileVar]
+}
+
+// GetRPCAPIToken defines the bearer token gRPC clients must authenticate with. Empty disables authentication.
+func (c *Config) GetRPCAPIToken() string {
+	return c.strings[rpcAPITokenVar]
+}
+
+// GetGatewayPort defines the port the REST/JSON gateway to the gRPC API is running at. gateway.enable must be true or the port is not used.
+func (c *Config) GetGatewayPort() uint {
+	return c.uints[gatewayPortVar]
+}
+
+// GetGatewayEnable defines if the gRPC API is also served as REST/JSON using gateway.port
+func (c *Config) GetGatewayEnable() bool {
+	return c.booleans[gatewayEnableVar]
+}
+
+// GetMetricsPort defines the port Prometheus metrics are served at. metrics.enable must be true or the port is not used.
+func (c *Config) GetMetricsPort() uint {
+	return c.uints[metricsPortVar]
+}
+
+// GetMetricsAddress defines the interface Prometheus metrics are served on. Empty serves them on all interfaces.
+func (c *Config) GetMetricsAddress() string {
+	return c.strings[metricsAddressVar]
+}
+
+// GetMetricsEnable defines if Prometheus metrics are served at /metrics using metrics.port
+func (c *Config) GetMetricsEnable() bool {
+	return c.booleans[metricsEnableVar]
+}
+
 // GetWebsocketPort defines port for websocket connections. websocket.enable must be true or the port is not used.
 func (c *Config) GetWebsocketPort() uint {
 	return c.uints[websocketPortVar]
 }
 
+// GetWebsocketMaxConnections defines how many websocket clients can be connected at the same time. 0 disables the limit.
+func (c *Config) GetWebsocketMaxConnections() uint {
+	return c.uints[websocketMaxConnectionsVar]
+}
+
+// GetWebsocketAllowedOrigins defines the comma separated origins of the web pages that can connect to the websocket,
+// in addition to pages served from the websocket's own host. "*" allows any origin.
+func (c *Config) GetWebsocketAllowedOrigins() []string {
+	origins := []string{}
+	for _, origin := range strings.Split(c.strings[websocketAllowedOriginsVar], ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// GetWebsocketAPIKey defines a key that lets websocket clients receive the feed without an API key. Empty disables it.
+func (c *Config) GetWebsocketAPIKey() string {
+	return c.strings[websocketAPIKeyVar]
+}
+
+// GetWebsocketCertFile defines the TLS certificate of the websocket listener. TLS is used when both it and websocket.keyFile are set.
+func (c *Config) GetWebsocketCertFile() string {
+	return c.strings[websocketCertFileVar]
+}
+
+// GetWebsocketKeyFile defines the private key of the websocket listener's TLS certificate
+func (c *Config) GetWebsocketKeyFile() string {
+	return c.strings[websocketKeyFileVar]
+}
+
 // GetWebsocketEnable defines if websocket connections are allowed. Starts waiting http request using websocket.port
 func (c *Config) GetWebsocketEnable() bool {
 	return c.booleans[websocketEnableVar]
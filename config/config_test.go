@@ -14,8 +14,21 @@ const invalidConfigPath string = "invalid"
 const defaultDBPath string = "/var/lib/sprawl/data"
 const defaultExternalIP string = ""
 const defaultAPIPort uint = 1337
+const defaultRPCAddress string = "127.0.0.1"
+const defaultGatewayPort uint = 8080
+const defaultGatewayEnableSetting bool = false
+const defaultMetricsPort uint = 9300
+const defaultMetricsAddress string = "127.0.0.1"
+const defaultMetricsEnableSetting bool = false
 const defaultP2PPort uint = 4001
 const defaultWebsocketPort uint = 3000
+const defaultSyncInterval uint = 300
+const defaultSyncPeers uint = 3
+const defaultMessagesPerSecond uint = 20
+const defaultMessageBurst uint = 100
+const defaultMaxOpenOrders uint = 500
+const defaultMaxQuoteTTL uint = 3600
+const defaultWebsocketMaxConnections uint = 100
 const defaultWebsocketEnableSetting bool = false
 const defaultDatabaseInMemorySetting bool = false
 const defaultNATPortMapSetting bool = true
@@ -26,6 +39,9 @@ const defaultStackTraceSetting bool = false
 const defaultIPFSPeerSetting bool = true
 const defaultLogLevel string = "INFO"
 const defaultLogFormat string = "console"
+const defaultWebsocketAPIKey string = ""
+const defaultWebsocketCertFile string = ""
+const defaultWebsocketKeyFile string = ""
 
 const dbPathEnvVar string = "SPRAWL_DATABASE_PATH"
 const useInMemoryEnvVar string = "SPRAWL_DATABASE_INMEMORY"
@@ -34,6 +50,7 @@ const websocketPortEnvVar string = "SPRAWL_WEBSOCKET_PORT"
 const p2pDebugEnvVar string = "SPRAWL_P2P_DEBUG"
 const errorsEnableStackTraceEnvVar string = "SPRAWL_ERRORS_ENABLESTACKTRACE"
 const websocketEnableEnvVar string = "SPRAWL_WEBSOCKET_ENABLE"
+const websocketAllowedOriginsEnvVar string = "SPRAWL_WEBSOCKET_ALLOWEDORIGINS"
 
 const envTestDBPath string = "/var/lib/sprawl/justforthistest"
 const envTestAPIPort uint = 9001
@@ -42,6 +59,7 @@ const envTestP2PDebug string = "true"
 const envTestErrorsEnableStackTrace string = "true"
 const envTestUseInMemory string = "true"
 const envTestWebsocketEnable string = "true"
+const envTestWebsocketAllowedOrigins string = "https://sprawl.example, http://localhost:8080,"
 
 var logger *zap.Logger
 var log *zap.SugaredLogger
@@ -67,6 +85,7 @@ func resetEnv() {
 	os.Unsetenv(errorsEnableStackTraceEnvVar)
 	os.Unsetenv(useInMemoryEnvVar)
 	os.Unsetenv(websocketEnableEnvVar)
+	os.Unsetenv(websocketAllowedOriginsEnvVar)
 }
 
 func TestErrors(t *testing.T) {
@@ -87,6 +106,16 @@ func TestDefaults(t *testing.T) {
 	databasePath := config.GetDatabasePath()
 	inMemory := config.GetInMemoryDatabaseSetting()
 	rpcPort := config.GetRPCPort()
+	rpcAddress := config.GetRPCAddress()
+	rpcCertFile := config.GetRPCCertFile()
+	rpcKeyFile := config.GetRPCKeyFile()
+	rpcClientCAFile := config.GetRPCClientCAFile()
+	rpcAPIToken := config.GetRPCAPIToken()
+	gatewayEnable := config.GetGatewayEnable()
+	gatewayPort := config.GetGatewayPort()
+	metricsEnable := config.GetMetricsEnable()
+	metricsPort := config.GetMetricsPort()
+	metricsAddress := config.GetMetricsAddress()
 	p2pDebug := config.GetDebugSetting()
 	errorsEnableStackTrace := config.GetStackTraceSetting()
 	externalIP := config.GetExternalIP()
@@ -99,10 +128,31 @@ func TestDefaults(t *testing.T) {
 	ipfsPeers := config.GetIPFSPeerSetting()
 	websocketEnable := config.GetWebsocketEnable()
 	websocketPort := config.GetWebsocketPort()
+	websocketMaxConnections := config.GetWebsocketMaxConnections()
+	websocketAllowedOrigins := config.GetWebsocketAllowedOrigins()
+	websocketAPIKey := config.GetWebsocketAPIKey()
+	websocketCertFile := config.GetWebsocketCertFile()
+	websocketKeyFile := config.GetWebsocketKeyFile()
+	syncInterval := config.GetSyncInterval()
+	syncPeers := config.GetSyncPeers()
+	messagesPerSecond := config.GetMessagesPerSecond()
+	messageBurst := config.GetMessageBurst()
+	maxOpenOrders := config.GetMaxOpenOrders()
+	maxQuoteTTL := config.GetMaxQuoteTTL()
 
 	assert.Equal(t, databasePath, defaultDBPath)
 	assert.Equal(t, inMemory, defaultDatabaseInMemorySetting)
 	assert.Equal(t, rpcPort, defaultAPIPort)
+	assert.Equal(t, rpcAddress, defaultRPCAddress)
+	assert.Empty(t, rpcCertFile)
+	assert.Empty(t, rpcKeyFile)
+	assert.Empty(t, rpcClientCAFile)
+	assert.Empty(t, rpcAPIToken)
+	assert.Equal(t, gatewayEnable, defaultGatewayEnableSetting)
+	assert.Equal(t, gatewayPort, defaultGatewayPort)
+	assert.Equal(t, metricsEnable, defaultMetricsEnableSetting)
+	assert.Equal(t, metricsPort, defaultMetricsPort)
+	assert.Equal(t, metricsAddress, defaultMetricsAddress)
 	assert.Equal(t, p2pDebug, defaultDebugSetting)
 	assert.Equal(t, errorsEnableStackTrace, defaultStackTraceSetting)
 	assert.Equal(t, externalIP, defaultExternalIP)
@@ -115,16 +165,30 @@ func TestDefaults(t *testing.T) {
 	assert.Equal(t, ipfsPeers, defaultIPFSPeerSetting)
 	assert.Equal(t, websocketEnable, defaultWebsocketEnableSetting)
 	assert.Equal(t, websocketPort, defaultWebsocketPort)
+	assert.Equal(t, websocketMaxConnections, defaultWebsocketMaxConnections)
+	assert.Empty(t, websocketAllowedOrigins)
+	assert.Equal(t, websocketAPIKey, defaultWebsocketAPIKey)
+	assert.Equal(t, websocketCertFile, defaultWebsocketCertFile)
+	assert.Equal(t, websocketKeyFile, defaultWebsocketKeyFile)
+	assert.Equal(t, syncInterval, defaultSyncInterval)
+	assert.Equal(t, syncPeers, defaultSyncPeers)
+	assert.Equal(t, messagesPerSecond, defaultMessagesPerSecond)
+	assert.Equal(t, messageBurst, defaultMessageBurst)
+	assert.Equal(t, maxOpenOrders, defaultMaxOpenOrders)
+	assert.Equal(t, maxQuoteTTL, defaultMaxQuoteTTL)
 }
 
 // TestEnvironment tests that environment variables overwrite any other configuration
 func TestEnvironment(t *testing.T) {
 	os.Setenv(dbPathEnvVar, envTestDBPath)
+	os.Setenv(websocketAllowedOriginsEnvVar, envTestWebsocketAllowedOrigins)
 
 	config.ReadConfig("")
 	databasePath := config.GetDatabasePath()
+	websocketAllowedOrigins := config.GetWebsocketAllowedOrigins()
 
 	assert.Equal(t, databasePath, envTestDBPath)
+	assert.Equal(t, websocketAllowedOrigins, []string{"https://sprawl.example", "http://localhost:8080"})
 
 	resetEnv()
 }
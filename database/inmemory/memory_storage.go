@@ -71,6 +71,17 @@ func (storage *Storage) GetAllWithPrefix(prefix string) (map[string]string, erro
 	return entries, nil
 }
 
+// CountWithPrefix counts the entries in the database with the specified prefix. An empty prefix counts every entry.
+func (storage *Storage) CountWithPrefix(prefix string) (int, error) {
+	count := 0
+	for k := range storage.Db {
+		if strings.HasPrefix(k, prefix) {
+			count++
+		}
+	}
+	return count, nil
+}
+
 // DeleteAll deletes all entries from the database
 // USE CAREFULLY
 func (storage *Storage) DeleteAll() error {
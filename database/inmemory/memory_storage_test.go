@@ -95,6 +95,29 @@ func TestStorageGetAllWithPrefix(t *testing.T) {
 	assert.Equal(t, len(testMessages)*2, len(allItems))
 }
 
+func TestStorageCountWithPrefix(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	for key, value := range testMessages {
+		key = orderPrefix + key
+		storage.Put([]byte(key), []byte(value))
+	}
+
+	for key, value := range testMessages {
+		key = channelPrefix + key
+		storage.Put([]byte(key), []byte(value))
+	}
+
+	orders, err := storage.CountWithPrefix(orderPrefix)
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, len(testMessages), orders)
+	all, err := storage.CountWithPrefix("")
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, len(testMessages)*2, all)
+}
+
 func TestStorageDeleteAllWithPrefix(t *testing.T) {
 	storage.Run()
 	defer storage.Close()
@@ -87,6 +87,23 @@ func (storage *Storage) GetAllWithPrefix(prefix string) (map[string]string, erro
 	return entries, err
 }
 
+// CountWithPrefix counts the entries in the database with the specified prefix without reading their values.
+// An empty prefix counts every entry.
+func (storage *Storage) CountWithPrefix(prefix string) (int, error) {
+	count := 0
+	iter := storage.db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+
+	// Iterate over the keys with the prefix, only counting them
+	for iter.Next() {
+		count++
+	}
+
+	iter.Release()
+	err = errors.E(errors.Op("Count with prefix using iterator"), iter.Error())
+
+	return count, err
+}
+
 // DeleteAll deletes all entries from the database
 // USE CAREFULLY
 func (storage *Storage) DeleteAll() error {
@@ -0,0 +1,135 @@
+package identity
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"io"
+	"math/big"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	pb "github.com/libp2p/go-libp2p-core/crypto/pb"
+	"github.com/sprawl/sprawl/errors"
+	"golang.org/x/crypto/curve25519"
+)
+
+const curve25519KeyLength = 32
+
+// curve25519Prime is the field prime 2^255 - 19 shared by Ed25519 and Curve25519
+var curve25519Prime = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+// EncryptTo encrypts data so that only the holder of the private key matching publicKey can read it.
+// An ephemeral Curve25519 key is agreed with the recipient's Ed25519 identity converted to Curve25519,
+// and the ephemeral public key is prepended to the AES-GCM ciphertext.
+func EncryptTo(publicKey crypto.PubKey, reader io.Reader, plaintext []byte) ([]byte, error) {
+	recipientKey, err := curve25519PublicKey(publicKey)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Convert recipient public key"), err)
+	}
+
+	var ephemeralPrivate, ephemeralPublic, shared [curve25519KeyLength]byte
+	_, err = io.ReadFull(reader, ephemeralPrivate[:])
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Read ephemeral key"), err)
+	}
+	curve25519.ScalarBaseMult(&ephemeralPublic, &ephemeralPrivate)
+	curve25519.ScalarMult(&shared, &ephemeralPrivate, &recipientKey)
+
+	ciphertext, err := Encrypt(deriveBoxKey(shared, ephemeralPublic, recipientKey), reader, plaintext)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Encrypt box"), err)
+	}
+	return append(ephemeralPublic[:], ciphertext...), nil
+}
+
+// DecryptWith decrypts data encrypted with EncryptTo using this node's private key
+func DecryptWith(privateKey crypto.PrivKey, box []byte) ([]byte, error) {
+	if len(box) < curve25519KeyLength {
+		return nil, errors.E(errors.Op("Check box length"), "box is shorter than the ephemeral key")
+	}
+
+	ownPrivate, ownPublic, err := curve25519KeyPair(privateKey)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Convert private key"), err)
+	}
+
+	var ephemeralPublic, shared [curve25519KeyLength]byte
+	copy(ephemeralPublic[:], box[:curve25519KeyLength])
+	curve25519.ScalarMult(&shared, &ownPrivate, &ephemeralPublic)
+
+	plaintext, err := Decrypt(deriveBoxKey(shared, ephemeralPublic, ownPublic), box[curve25519KeyLength:])
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Decrypt box"), err)
+	}
+	return plaintext, nil
+}
+
+func deriveBoxKey(shared, ephemeralPublic, recipientPublic [curve25519KeyLength]byte) []byte {
+	h := sha256.New()
+	h.Write(shared[:])
+	h.Write(ephemeralPublic[:])
+	h.Write(recipientPublic[:])
+	return h.Sum(nil)
+}
+
+// curve25519PublicKey maps an Ed25519 public key to its birationally equivalent Curve25519 point, u = (1 + y) / (1 - y)
+func curve25519PublicKey(publicKey crypto.PubKey) ([curve25519KeyLength]byte, error) {
+	var u [curve25519KeyLength]byte
+	if publicKey.Type() != pb.KeyType_Ed25519 {
+		return u, errors.E(errors.Op("Check key type"), "only Ed25519 keys can be used for encryption")
+	}
+	raw, err := publicKey.Raw()
+	if !errors.IsEmpty(err) {
+		return u, errors.E(errors.Op("Get raw public key"), err)
+	}
+
+	// The key is the little-endian y coordinate, with the sign of x in the highest bit
+	y := new(big.Int).SetBytes(reverse(raw))
+	y.SetBit(y, 255, 0)
+
+	numerator := new(big.Int).Add(big.NewInt(1), y)
+	denominator := new(big.Int).Sub(big.NewInt(1), y)
+	denominator.Mod(denominator, curve25519Prime)
+	if denominator.Sign() == 0 {
+		return u, errors.E(errors.Op("Convert public key"), "invalid Ed25519 public key")
+	}
+	denominator.ModInverse(denominator, curve25519Prime)
+	numerator.Mul(numerator, denominator).Mod(numerator, curve25519Prime)
+
+	copy(u[:], reverse(leftPad(numerator.Bytes(), curve25519KeyLength)))
+	return u, nil
+}
+
+// curve25519KeyPair derives the Curve25519 key pair matching an Ed25519 private key, like RFC 8032 derives its scalar
+func curve25519KeyPair(privateKey crypto.PrivKey) ([curve25519KeyLength]byte, [curve25519KeyLength]byte, error) {
+	var private, public [curve25519KeyLength]byte
+	if privateKey.Type() != pb.KeyType_Ed25519 {
+		return private, public, errors.E(errors.Op("Check key type"), "only Ed25519 keys can be used for decryption")
+	}
+	raw, err := privateKey.Raw()
+	if !errors.IsEmpty(err) {
+		return private, public, errors.E(errors.Op("Get raw private key"), err)
+	}
+
+	digest := sha512.Sum512(raw[:curve25519KeyLength])
+	copy(private[:], digest[:curve25519KeyLength])
+	private[0] &= 248
+	private[31] &= 127
+	private[31] |= 64
+	curve25519.ScalarBaseMult(&public, &private)
+	return private, public, nil
+}
+
+func reverse(data []byte) []byte {
+	reversed := make([]byte, len(data))
+	for i, b := range data {
+		reversed[len(data)-1-i] = b
+	}
+	return reversed
+}
+
+func leftPad(data []byte, length int) []byte {
+	if len(data) >= length {
+		return data
+	}
+	return append(make([]byte, length-len(data)), data...)
+}
@@ -0,0 +1,49 @@
+package identity
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+const testMessage = "Settle to this address"
+
+func TestBoxKeyConversion(t *testing.T) {
+	privateKey, publicKey, err := GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+
+	// The converted public key must match the public half of the converted private key
+	_, ownPublic, err := curve25519KeyPair(privateKey)
+	assert.NoError(t, err)
+	convertedPublic, err := curve25519PublicKey(publicKey)
+	assert.NoError(t, err)
+	assert.Equal(t, ownPublic, convertedPublic)
+}
+
+func TestEncryptToAndDecryptWith(t *testing.T) {
+	privateKey, publicKey, err := GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	otherPrivateKey, _, err := GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+
+	box, err := EncryptTo(publicKey, rand.Reader, []byte(testMessage))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(box), testMessage)
+
+	plaintext, err := DecryptWith(privateKey, box)
+	assert.NoError(t, err)
+	assert.Equal(t, testMessage, string(plaintext))
+
+	_, err = DecryptWith(otherPrivateKey, box)
+	assert.Error(t, err)
+
+	_, err = DecryptWith(privateKey, box[:10])
+	assert.Error(t, err)
+
+	_, rsaPublicKey, err := crypto.GenerateRSAKeyPair(2048, rand.Reader)
+	assert.NoError(t, err)
+	_, err = EncryptTo(rsaPublicKey, rand.Reader, []byte(testMessage))
+	assert.Error(t, err)
+}
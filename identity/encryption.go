@@ -0,0 +1,64 @@
+package identity
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"io"
+
+	"github.com/sprawl/sprawl/errors"
+)
+
+const symmetricKeyLength = 32
+
+// GenerateSymmetricKey generates a random AES-256 key, used to encrypt data on private channels
+func GenerateSymmetricKey(reader io.Reader) ([]byte, error) {
+	key := make([]byte, symmetricKeyLength)
+	_, err := io.ReadFull(reader, key)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Read random key"), err)
+	}
+	return key, nil
+}
+
+// Encrypt encrypts and authenticates data with AES-GCM, prepending the random nonce to the ciphertext
+func Encrypt(key []byte, reader io.Reader, plaintext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Create cipher"), err)
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = io.ReadFull(reader, nonce)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Read random nonce"), err)
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt decrypts data encrypted with Encrypt, failing if the data has been tampered with
+func Decrypt(key []byte, ciphertext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Create cipher"), err)
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.E(errors.Op("Check ciphertext length"), "ciphertext is shorter than the nonce")
+	}
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Open ciphertext"), err)
+	}
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,37 @@
+package identity
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPlaintext = "Sprawl private channel data"
+
+func TestEncryptAndDecrypt(t *testing.T) {
+	key, err := GenerateSymmetricKey(rand.Reader)
+	assert.NoError(t, err)
+	assert.Len(t, key, symmetricKeyLength)
+
+	ciphertext, err := Encrypt(key, rand.Reader, []byte(testPlaintext))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), testPlaintext)
+
+	plaintext, err := Decrypt(key, ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, testPlaintext, string(plaintext))
+
+	// Decrypting with another key or tampered data must fail
+	otherKey, err := GenerateSymmetricKey(rand.Reader)
+	assert.NoError(t, err)
+	_, err = Decrypt(otherKey, ciphertext)
+	assert.Error(t, err)
+
+	ciphertext[len(ciphertext)-1] ^= 0xff
+	_, err = Decrypt(key, ciphertext)
+	assert.Error(t, err)
+
+	_, err = Decrypt(key, []byte("short"))
+	assert.Error(t, err)
+}
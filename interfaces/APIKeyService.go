@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sprawl/sprawl/pb"
+)
+
+// APIKeyService is an interface to the API key endpoints in sprawl.proto
+type APIKeyService interface {
+	RegisterStorage(db Storage)
+	CreateAPIKey(ctx context.Context, in *pb.CreateAPIKeyRequest) (*pb.CreateAPIKeyResponse, error)
+	GetAllAPIKeys(ctx context.Context, in *pb.Empty) (*pb.APIKeyList, error)
+	RevokeAPIKey(ctx context.Context, in *pb.APIKeySpecificRequest) (*pb.Empty, error)
+	Authenticate(key string) (*pb.APIKey, error)
+	HasKeys() (bool, error)
+}
@@ -0,0 +1,9 @@
+package interfaces
+
+// Authorizer authorizes clients by the token they authenticate with, the same way the gRPC API authorizes bearer tokens
+type Authorizer interface {
+	// Authenticate tells if token is the API token or one of the API keys
+	Authenticate(token string) (bool, error)
+	// Authorize checks that a client authenticating with token can call the gRPC method fullMethod, like "/pb.OrderHandler/Create"
+	Authorize(token string, fullMethod string) error
+}
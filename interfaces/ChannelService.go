@@ -10,8 +10,12 @@ import (
 type ChannelService interface {
 	RegisterStorage(db Storage)
 	RegisterP2p(p2p P2p)
+	RegisterChannelActivity(activity ChannelActivity)
 	Join(ctx context.Context, in *pb.JoinRequest) (*pb.JoinResponse, error)
 	Leave(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.Empty, error)
 	GetChannel(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.Channel, error)
 	GetAllChannels(ctx context.Context, in *pb.Empty) (*pb.ChannelList, error)
+	ListNetworkChannels(ctx context.Context, in *pb.Empty) (*pb.NetworkChannelList, error)
+	GetChannelStats(ctx context.Context, in *pb.Empty) (*pb.ChannelStatsList, error)
+	GetSyncStatus(ctx context.Context, in *pb.Empty) (*pb.SyncStatusList, error)
 }
@@ -15,7 +15,28 @@ type Config interface {
 	GetLogFormat() string
 	GetP2PPort() uint
 	GetRPCPort() uint
+	GetRPCAddress() string
+	GetRPCCertFile() string
+	GetRPCKeyFile() string
+	GetRPCClientCAFile() string
+	GetRPCAPIToken() string
+	GetGatewayPort() uint
+	GetGatewayEnable() bool
+	GetMetricsPort() uint
+	GetMetricsAddress() string
+	GetMetricsEnable() bool
+	GetSyncInterval() uint
+	GetSyncPeers() uint
+	GetMessagesPerSecond() uint
+	GetMessageBurst() uint
+	GetMaxOpenOrders() uint
+	GetMaxQuoteTTL() uint
 	GetWebsocketPort() uint
+	GetWebsocketMaxConnections() uint
+	GetWebsocketAllowedOrigins() []string
+	GetWebsocketAPIKey() string
+	GetWebsocketCertFile() string
+	GetWebsocketKeyFile() string
 	GetWebsocketEnable() bool
 	GetInMemoryDatabaseSetting() bool
 	GetNATPortMapSetting() bool
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// Limiter limits how many messages and open orders each peer can send on a channel
+type Limiter interface {
+	Allow(channelID []byte, from peer.ID) bool
+	ReserveOrder(channelID []byte, from peer.ID, orderID []byte) bool
+	ReleaseOrder(channelID []byte, from peer.ID, orderID []byte)
+	GetOffenders() []*pb.Offender
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/sprawl/sprawl/pb"
+)
+
+// Metrics records what the node's services are doing, for monitoring
+type Metrics interface {
+	MessageSent(op pb.Operation)
+	MessageReceived(op pb.Operation)
+	MessageRejected(reason string)
+	SyncCompleted(duration time.Duration)
+	SyncItemsSent(items int)
+	SyncItemsReceived(items int)
+	RPCHandled(method string, code string, duration time.Duration)
+}
+
+// OrderCounter counts the stored orders of every joined channel by their state
+type OrderCounter interface {
+	CountOrders() (map[string]map[pb.State]int, error)
+}
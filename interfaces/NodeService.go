@@ -3,11 +3,23 @@ package interfaces
 import (
 	"context"
 
+	peer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/sprawl/sprawl/pb"
 )
 
+// NodeService is an interface to the Node endpoints in sprawl.proto
 type NodeService interface {
+	RegisterStorage(db Storage)
 	RegisterP2p(p2p P2p)
 	GetAllPeers(ctx context.Context, in *pb.Empty) (*pb.PeerListResponse, error)
 	BlacklistPeer(ctx context.Context, in *pb.Peer) (*pb.Empty, error)
+	GetNodeInfo(ctx context.Context, in *pb.Empty) (*pb.NodeInfo, error)
+	ConnectPeer(ctx context.Context, in *pb.ConnectRequest) (*pb.Empty, error)
+	DisconnectPeer(ctx context.Context, in *pb.Peer) (*pb.Empty, error)
+	GetProtectedPeers(ctx context.Context, in *pb.Empty) (*pb.PeerAddressList, error)
+	SendDirectMessage(ctx context.Context, in *pb.DirectMessageRequest) (*pb.Empty, error)
+	ReceiveDirectMessages(in *pb.Empty, stream pb.NodeHandler_ReceiveDirectMessagesServer) error
+	ReceiveDirectMessage(data []byte, from peer.ID) error
+	RegisterLimiter(limiter Limiter)
+	GetRateLimitOffenders(ctx context.Context, in *pb.Empty) (*pb.OffenderList, error)
 }
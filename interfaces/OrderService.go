@@ -13,13 +13,22 @@ type OrderService interface {
 	RegisterStorage(db Storage)
 	RegisterP2p(p2p P2p)
 	RegisterWebsocket(websocket WebsocketService)
+	RegisterDirectMessageReceiver(receiver DirectMessageReceiver)
+	RegisterLimiter(limiter Limiter)
 	Create(ctx context.Context, in *pb.CreateRequest) (*pb.CreateResponse, error)
 	Receive(data []byte, from peer.ID) error
+	GetChannelActivity(channelID []byte) *pb.ChannelStats
 	Delete(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.Empty, error)
 	Lock(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.Empty, error)
 	Unlock(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.Empty, error)
 	GetOrder(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.Order, error)
 	GetAllOrders(ctx context.Context, in *pb.Empty) (*pb.OrderList, error)
+	RequestQuote(ctx context.Context, in *pb.CreateQuoteRequest) (*pb.QuoteRequest, error)
+	GetQuoteRequests(ctx context.Context, in *pb.Empty) (*pb.QuoteRequestList, error)
+	SendQuote(ctx context.Context, in *pb.SendQuoteRequest) (*pb.Quote, error)
+	GetQuotes(ctx context.Context, in *pb.QuoteRequestSpecificRequest) (*pb.QuoteList, error)
+	AcceptQuote(ctx context.Context, in *pb.QuoteSpecificRequest) (*pb.Order, error)
+	StreamEvents(in *pb.StreamEventsRequest, stream pb.OrderHandler_StreamEventsServer) error
 	GetSignature(order *pb.Order) ([]byte, error)
 	VerifyOrder(publicKey crypto.PubKey, order *pb.Order) (bool, error)
 }
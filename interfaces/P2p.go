@@ -2,21 +2,39 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	peer "github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/sprawl/sprawl/pb"
 )
 
+// WireVersion is the major version of the WireMessage format this node sends.
+// Messages with a newer major version are rejected, and messages without one come from nodes older than versioning.
+const WireVersion uint32 = 1
+
 // P2p is a general p2p connection handler
 type P2p interface {
 	GetHostID() peer.ID
 	GetHostIDString() string
+	GetAddrs() []ma.Multiaddr
+	GetProtocolVersion() string
+	GetCapabilities() []string
+	GetTopics() []string
+	GetNetworkChannels() []*pb.NetworkChannel
+	GetUptime() time.Duration
 	AddReceiver(receiver Receiver)
 	Send(message *pb.WireMessage)
 	Subscribe(channel *pb.Channel) (context.Context, error)
 	Unsubscribe(channel *pb.Channel)
 	GetAllPeers() []peer.ID
+	GetChannelPeers(channelID []byte) []peer.ID
+	GetSyncStatus(channelID []byte) *pb.SyncStatus
 	BlacklistPeer(peerID *pb.Peer)
+	ConnectPeer(addr ma.Multiaddr, protected bool) error
+	DisconnectPeer(peerID peer.ID) error
+	GetProtectedPeers() []ma.Multiaddr
+	IsLegacyPeer(peerID peer.ID) bool
 	OpenStream(peerID peer.ID) (Stream, error)
 	CloseStream(peerID peer.ID) error
 	Run()
@@ -26,4 +44,5 @@ type P2p interface {
 // Stream is a single stream instance between two peers
 type Stream interface {
 	WriteToStream(data []byte) error
+	Request(data []byte) ([]byte, error)
 }
@@ -1,8 +1,32 @@
 package interfaces
 
-import peer "github.com/libp2p/go-libp2p-core/peer"
+import (
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/pb"
+)
 
 // Receiver receives and parses all Wiremessages from p2p
 type Receiver interface {
 	Receive(data []byte, from peer.ID) error
 }
+
+// RequestReceiver answers the requests other peers send over streams. The reply is sent back on the same stream.
+type RequestReceiver interface {
+	ReceiveRequest(data []byte, from peer.ID) ([]byte, error)
+}
+
+// Validator checks messages published on a channel before they are passed on to other peers
+type Validator interface {
+	Validate(channelID []byte, data []byte, from peer.ID) error
+}
+
+// DirectMessageReceiver receives the encrypted direct messages other peers send to this node
+type DirectMessageReceiver interface {
+	ReceiveDirectMessage(data []byte, from peer.ID) error
+}
+
+// ChannelActivity reports how many messages were received and rejected on a channel, and what syncing it has repaired
+type ChannelActivity interface {
+	GetChannelActivity(channelID []byte) *pb.ChannelStats
+	GetSyncResults(channelID []byte) *pb.SyncStatus
+}
@@ -11,6 +11,7 @@ type Storage interface {
 	Delete(key []byte) error
 	GetAll() (map[string]string, error)
 	GetAllWithPrefix(prefix string) (map[string]string, error)
+	CountWithPrefix(prefix string) (int, error)
 	DeleteAll() error
 	DeleteAllWithPrefix(prefix string) error
 }
@@ -23,4 +24,14 @@ const (
 	OrderPrefix Prefix = "order-"
 	// ChannelPrefix is the prefix used to signify all channels in Storage
 	ChannelPrefix Prefix = "channel-"
+	// TombstonePrefix is the prefix used to signify the records of deleted orders in Storage
+	TombstonePrefix Prefix = "tombstone-"
+	// QuoteRequestPrefix is the prefix used to signify all quote requests in Storage
+	QuoteRequestPrefix Prefix = "quoterequest-"
+	// QuotePrefix is the prefix used to signify all quotes in Storage
+	QuotePrefix Prefix = "quote-"
+	// PeerPrefix is the prefix used to signify all protected peers in Storage
+	PeerPrefix Prefix = "peer-"
+	// APIKeyPrefix is the prefix used to signify the hashed API keys in Storage
+	APIKeyPrefix Prefix = "apikey-"
 )
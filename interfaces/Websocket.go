@@ -6,4 +6,5 @@ type WebsocketService interface {
 	Start()
 	Close()
 	PushToWebsockets(message *pb.WireMessage)
+	RegisterHandlers(orders pb.OrderHandlerServer, channels pb.ChannelHandlerServer, nodes pb.NodeHandlerServer, authorizer Authorizer)
 }
@@ -0,0 +1,195 @@
+// Package limits implements per-peer, per-channel limits on incoming messages and open orders.
+// Messages are limited with token buckets, so peers can send short bursts but not sustained floods.
+package limits
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ptypes "github.com/golang/protobuf/ptypes"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"golang.org/x/time/rate"
+)
+
+// reportInterval is how often the same offender is reported in the logs at most
+const reportInterval = time.Minute
+
+// offenderTTL is how long a peer stays listed as an offender after its last offense
+const offenderTTL = time.Hour
+
+// sweepInterval is how often idle buckets and old offenders are looked for at most
+const sweepInterval = time.Minute
+
+type offender struct {
+	stats    *pb.Offender
+	reported time.Time
+	offended time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// Limiter enforces the configured limits and keeps track of the peers that exceed them
+type Limiter struct {
+	messageRate   rate.Limit
+	burst         int
+	maxOpenOrders int
+	idleTimeout   time.Duration
+	buckets       map[string]*bucket
+	openOrders    map[string]map[string]bool
+	offenders     map[string]*offender
+	lastSweep     time.Time
+	lock          sync.Mutex
+	Logger        interfaces.Logger
+}
+
+// NewLimiter returns a Limiter that allows messagesPerSecond messages, in bursts of up to burst messages,
+// and up to maxOpenOrders orders per peer on each channel. Zero disables a limit.
+func NewLimiter(messagesPerSecond uint, burst uint, maxOpenOrders uint, logger interfaces.Logger) *Limiter {
+	if logger == nil {
+		logger = new(util.PlaceholderLogger)
+	}
+	if burst < messagesPerSecond {
+		burst = messagesPerSecond
+	}
+	// A bucket left alone this long has refilled, and is no different from a new one
+	var idleTimeout time.Duration
+	if messagesPerSecond > 0 {
+		idleTimeout = time.Duration(burst) * time.Second / time.Duration(messagesPerSecond)
+	}
+	return &Limiter{
+		messageRate:   rate.Limit(messagesPerSecond),
+		burst:         int(burst),
+		maxOpenOrders: int(maxOpenOrders),
+		idleTimeout:   idleTimeout,
+		buckets:       make(map[string]*bucket),
+		openOrders:    make(map[string]map[string]bool),
+		offenders:     make(map[string]*offender),
+		Logger:        logger,
+	}
+}
+
+func getKey(channelID []byte, from peer.ID) string {
+	return strings.Join([]string{string(channelID), from.String()}, "/")
+}
+
+// Allow takes a token from the peer's bucket on the channel, and reports whether there was one
+func (limiter *Limiter) Allow(channelID []byte, from peer.ID) bool {
+	if limiter.messageRate == 0 {
+		return true
+	}
+
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+	now := time.Now()
+	limiter.sweep(now)
+	key := getKey(channelID, from)
+	entry, ok := limiter.buckets[key]
+	if !ok {
+		entry = &bucket{limiter: rate.NewLimiter(limiter.messageRate, limiter.burst)}
+		limiter.buckets[key] = entry
+	}
+	entry.lastUsed = now
+	if entry.limiter.AllowN(now, 1) {
+		return true
+	}
+
+	limiter.recordOffense(channelID, from, func(stats *pb.Offender) { stats.DroppedMessages++ })
+	return false
+}
+
+// ReserveOrder counts an order towards the peer's open orders on the channel,
+// and reports whether the peer was still under its limit
+func (limiter *Limiter) ReserveOrder(channelID []byte, from peer.ID, orderID []byte) bool {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+	key := getKey(channelID, from)
+	orders, ok := limiter.openOrders[key]
+	if !ok {
+		orders = make(map[string]bool)
+		limiter.openOrders[key] = orders
+	}
+	if orders[string(orderID)] {
+		return true
+	}
+	if limiter.maxOpenOrders > 0 && len(orders) >= limiter.maxOpenOrders {
+		limiter.recordOffense(channelID, from, func(stats *pb.Offender) { stats.RejectedOrders++ })
+		return false
+	}
+	orders[string(orderID)] = true
+	return true
+}
+
+// ReleaseOrder stops counting a deleted order towards the peer's open orders
+func (limiter *Limiter) ReleaseOrder(channelID []byte, from peer.ID, orderID []byte) {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+	key := getKey(channelID, from)
+	delete(limiter.openOrders[key], string(orderID))
+	if len(limiter.openOrders[key]) == 0 {
+		delete(limiter.openOrders, key)
+	}
+}
+
+// recordOffense updates the offender's counters and reports it, unless it was reported recently
+func (limiter *Limiter) recordOffense(channelID []byte, from peer.ID, record func(stats *pb.Offender)) {
+	key := getKey(channelID, from)
+	entry, ok := limiter.offenders[key]
+	if !ok {
+		entry = &offender{stats: &pb.Offender{Peer: from.String(), ChannelID: channelID}}
+		limiter.offenders[key] = entry
+	}
+	record(entry.stats)
+	entry.offended = time.Now()
+	entry.stats.LastOffense = ptypes.TimestampNow()
+
+	if time.Since(entry.reported) >= reportInterval {
+		entry.reported = time.Now()
+		limiter.Logger.Warnf("Peer %s exceeds the limits on channel %s, %d messages dropped and %d orders rejected so far", from, channelID, entry.stats.GetDroppedMessages(), entry.stats.GetRejectedOrders())
+	}
+}
+
+// sweep forgets the buckets that have been idle long enough to refill and the offenders that have behaved for a while,
+// unless it did so recently. The lock must be held.
+func (limiter *Limiter) sweep(now time.Time) {
+	if now.Sub(limiter.lastSweep) < sweepInterval {
+		return
+	}
+	limiter.lastSweep = now
+	for key, entry := range limiter.buckets {
+		if now.Sub(entry.lastUsed) >= limiter.idleTimeout {
+			delete(limiter.buckets, key)
+		}
+	}
+	for key, entry := range limiter.offenders {
+		if now.Sub(entry.offended) >= offenderTTL {
+			delete(limiter.offenders, key)
+		}
+	}
+}
+
+// GetOffenders returns the peers that have exceeded the limits on any channel lately
+func (limiter *Limiter) GetOffenders() []*pb.Offender {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+	limiter.sweep(time.Now())
+	offenders := make([]*pb.Offender, 0, len(limiter.offenders))
+	for _, entry := range limiter.offenders {
+		statsCopy := *entry.stats
+		offenders = append(offenders, &statsCopy)
+	}
+	sort.Slice(offenders, func(i, j int) bool {
+		if offenders[i].GetPeer() != offenders[j].GetPeer() {
+			return offenders[i].GetPeer() < offenders[j].GetPeer()
+		}
+		return string(offenders[i].GetChannelID()) < string(offenders[j].GetChannelID())
+	})
+	return offenders
+}
@@ -0,0 +1,95 @@
+package limits
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+var channelID = []byte("testChannel")
+
+func newPeerID(t *testing.T) peer.ID {
+	_, publicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	peerID, err := peer.IDFromPublicKey(publicKey)
+	assert.NoError(t, err)
+	return peerID
+}
+
+func TestMessageRate(t *testing.T) {
+	limiter := NewLimiter(1, 3, 0, nil)
+	flooder := newPeerID(t)
+	other := newPeerID(t)
+
+	// A burst is allowed, but nothing after it until tokens are refilled
+	for i := 0; i < 3; i++ {
+		assert.True(t, limiter.Allow(channelID, flooder))
+	}
+	assert.False(t, limiter.Allow(channelID, flooder))
+	assert.False(t, limiter.Allow(channelID, flooder))
+
+	// Other peers and channels have buckets of their own
+	assert.True(t, limiter.Allow(channelID, other))
+	assert.True(t, limiter.Allow([]byte("otherChannel"), flooder))
+
+	offenders := limiter.GetOffenders()
+	assert.Len(t, offenders, 1)
+	assert.Equal(t, flooder.String(), offenders[0].GetPeer())
+	assert.Equal(t, channelID, offenders[0].GetChannelID())
+	assert.Equal(t, uint64(2), offenders[0].GetDroppedMessages())
+	assert.NotNil(t, offenders[0].GetLastOffense())
+
+	// Zero disables the limit
+	unlimited := NewLimiter(0, 0, 0, nil)
+	for i := 0; i < 100; i++ {
+		assert.True(t, unlimited.Allow(channelID, flooder))
+	}
+}
+
+func TestOpenOrders(t *testing.T) {
+	limiter := NewLimiter(0, 0, 2, nil)
+	creator := newPeerID(t)
+
+	assert.True(t, limiter.ReserveOrder(channelID, creator, []byte("order1")))
+	assert.True(t, limiter.ReserveOrder(channelID, creator, []byte("order2")))
+	// The same order can be received again, but not a new one
+	assert.True(t, limiter.ReserveOrder(channelID, creator, []byte("order2")))
+	assert.False(t, limiter.ReserveOrder(channelID, creator, []byte("order3")))
+
+	// Deleting an order makes room for another
+	limiter.ReleaseOrder(channelID, creator, []byte("order1"))
+	assert.True(t, limiter.ReserveOrder(channelID, creator, []byte("order3")))
+
+	offenders := limiter.GetOffenders()
+	assert.Len(t, offenders, 1)
+	assert.Equal(t, uint64(1), offenders[0].GetRejectedOrders())
+	assert.Equal(t, uint64(0), offenders[0].GetDroppedMessages())
+}
+
+func TestSweep(t *testing.T) {
+	limiter := NewLimiter(1, 1, 0, nil)
+	flooder := newPeerID(t)
+	for i := 0; i < 10; i++ {
+		limiter.Allow([]byte(string(rune('a'+i))), flooder)
+	}
+	assert.False(t, limiter.Allow([]byte("a"), flooder))
+	assert.Len(t, limiter.buckets, 10)
+	assert.Len(t, limiter.GetOffenders(), 1)
+
+	// Refilled buckets are forgotten on the next sweep, and so are offenders that have behaved long enough
+	for _, entry := range limiter.buckets {
+		entry.lastUsed = entry.lastUsed.Add(-limiter.idleTimeout)
+	}
+	limiter.lastSweep = time.Time{}
+	assert.True(t, limiter.Allow(channelID, flooder))
+	assert.Len(t, limiter.buckets, 1)
+	assert.Len(t, limiter.GetOffenders(), 1)
+
+	limiter.offenders[getKey([]byte("a"), flooder)].offended = time.Now().Add(-offenderTTL)
+	limiter.lastSweep = time.Time{}
+	assert.Empty(t, limiter.GetOffenders())
+}
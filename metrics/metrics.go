@@ -0,0 +1,214 @@
+// Package metrics collects Prometheus metrics on the node's peers, messages, sync, orders, gRPC API and storage,
+// and serves them for scraping at /metrics.
+package metrics
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+)
+
+const namespace = "sprawl"
+
+// Metrics holds the node's collectors in a registry of its own
+type Metrics struct {
+	registry         *prometheus.Registry
+	messagesSent     *prometheus.CounterVec
+	messagesReceived *prometheus.CounterVec
+	messagesRejected *prometheus.CounterVec
+	syncDuration     prometheus.Histogram
+	syncItems        *prometheus.HistogramVec
+	rpcDuration      *prometheus.HistogramVec
+	storageDuration  *prometheus.HistogramVec
+	server           *http.Server
+	Logger           interfaces.Logger
+}
+
+// NewMetrics returns Metrics with the message, sync, gRPC and storage collectors registered, along with the Go runtime's
+func NewMetrics(logger interfaces.Logger) *Metrics {
+	if logger == nil {
+		logger = new(util.PlaceholderLogger)
+	}
+	metrics := &Metrics{
+		registry: prometheus.NewRegistry(),
+		messagesSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "p2p",
+			Name:      "messages_sent_total",
+			Help:      "Messages sent to other peers, by operation.",
+		}, []string{"operation"}),
+		messagesReceived: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "p2p",
+			Name:      "messages_received_total",
+			Help:      "Messages received from other peers, by operation.",
+		}, []string{"operation"}),
+		messagesRejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "p2p",
+			Name:      "messages_rejected_total",
+			Help:      "Messages from other peers that were dropped, by the reason they were rejected.",
+		}, []string{"reason"}),
+		syncDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "sync",
+			Name:      "duration_seconds",
+			Help:      "How long reconciling a channel with a peer took, from receiving its fingerprints to being in sync or applying its update.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 4, 8),
+		}),
+		syncItems: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "sync",
+			Name:      "update_items",
+			Help:      "Orders and tombstones in the sync updates sent to and received from peers.",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+		}, []string{"direction"}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "How long gRPC calls took to handle, by method and status code. Streams are measured until they end.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		storageDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "storage",
+			Name:      "operation_duration_seconds",
+			Help:      "How long storage operations took, by operation.",
+			Buckets:   prometheus.ExponentialBuckets(0.00001, 4, 10),
+		}, []string{"operation"}),
+		Logger: logger,
+	}
+
+	metrics.registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		metrics.messagesSent,
+		metrics.messagesReceived,
+		metrics.messagesRejected,
+		metrics.syncDuration,
+		metrics.syncItems,
+		metrics.rpcDuration,
+		metrics.storageDuration,
+	)
+	return metrics
+}
+
+// RegisterP2p reports the peers the node is connected to and the channel topics it has joined
+func (metrics *Metrics) RegisterP2p(p2p interfaces.P2p) {
+	metrics.registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "p2p",
+			Name:      "connected_peers",
+			Help:      "Peers the node is connected to.",
+		}, func() float64 { return float64(len(p2p.GetAllPeers())) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "p2p",
+			Name:      "joined_topics",
+			Help:      "Channel topics the node has joined.",
+		}, func() float64 { return float64(len(p2p.GetTopics())) }),
+	)
+}
+
+// RegisterOrderCounter reports the stored orders of each channel by state, as counted when the metrics are scraped
+func (metrics *Metrics) RegisterOrderCounter(counter interfaces.OrderCounter) {
+	metrics.registry.MustRegister(&orderCollector{counter: counter, logger: metrics.Logger})
+}
+
+// MessageSent counts a message sent to other peers
+func (metrics *Metrics) MessageSent(op pb.Operation) {
+	metrics.messagesSent.WithLabelValues(op.String()).Inc()
+}
+
+// MessageReceived counts a message received from another peer
+func (metrics *Metrics) MessageReceived(op pb.Operation) {
+	metrics.messagesReceived.WithLabelValues(op.String()).Inc()
+}
+
+// MessageRejected counts a message from another peer that was dropped for reason
+func (metrics *Metrics) MessageRejected(reason string) {
+	metrics.messagesRejected.WithLabelValues(reason).Inc()
+}
+
+// SyncCompleted records how long reconciling a channel with a peer took
+func (metrics *Metrics) SyncCompleted(duration time.Duration) {
+	metrics.syncDuration.Observe(duration.Seconds())
+}
+
+// SyncItemsSent records the size of a sync update sent to a peer
+func (metrics *Metrics) SyncItemsSent(items int) {
+	metrics.syncItems.WithLabelValues("sent").Observe(float64(items))
+}
+
+// SyncItemsReceived records the size of a sync update received from a peer
+func (metrics *Metrics) SyncItemsReceived(items int) {
+	metrics.syncItems.WithLabelValues("received").Observe(float64(items))
+}
+
+// RPCHandled records how long a gRPC call took
+func (metrics *Metrics) RPCHandled(method string, code string, duration time.Duration) {
+	metrics.rpcDuration.WithLabelValues(method, code).Observe(duration.Seconds())
+}
+
+// Handler returns the HTTP handler that serves the metrics in the Prometheus text format
+func (metrics *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(metrics.registry, promhttp.HandlerOpts{})
+}
+
+// Run serves the metrics at /metrics on the given address and port. An empty address serves them on all interfaces.
+func (metrics *Metrics) Run(address string, port uint) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	metrics.server = &http.Server{Addr: net.JoinHostPort(address, strconv.FormatUint(uint64(port), 10)), Handler: mux}
+	err := metrics.server.ListenAndServe()
+	if err != http.ErrServerClosed {
+		metrics.Logger.Error(errors.E(errors.Op("Serve metrics"), err))
+	}
+}
+
+// Close shuts down the metrics server
+func (metrics *Metrics) Close() {
+	if metrics.server != nil {
+		metrics.server.Shutdown(context.Background())
+	}
+}
+
+// orderCollector reports the order counts when the metrics are scraped
+type orderCollector struct {
+	counter interfaces.OrderCounter
+	logger  interfaces.Logger
+}
+
+var ordersDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "orders", "stored"),
+	"Orders stored on each joined channel, by state.",
+	[]string{"channel", "state"}, nil,
+)
+
+func (collector *orderCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- ordersDesc
+}
+
+func (collector *orderCollector) Collect(metrics chan<- prometheus.Metric) {
+	counts, err := collector.counter.CountOrders()
+	if !errors.IsEmpty(err) {
+		collector.logger.Error(errors.E(errors.Op("Count orders for metrics"), err))
+		return
+	}
+	for channelID, states := range counts {
+		for state := range pb.State_name {
+			metrics <- prometheus.MustNewConstMetric(ordersDesc, prometheus.GaugeValue, float64(states[pb.State(state)]), channelID, pb.State(state).String())
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+const channelID = "testChannel"
+
+type testOrderCounter struct{}
+
+func (counter testOrderCounter) CountOrders() (map[string]map[pb.State]int, error) {
+	return map[string]map[pb.State]int{channelID: {pb.State_OPEN: 2, pb.State_LOCKED: 1}}, nil
+}
+
+func scrape(t *testing.T, metrics *Metrics) string {
+	recorder := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := ioutil.ReadAll(recorder.Body)
+	assert.NoError(t, err)
+	return string(body)
+}
+
+func TestMessageMetrics(t *testing.T) {
+	metrics := NewMetrics(nil)
+	metrics.MessageSent(pb.Operation_CREATE)
+	metrics.MessageSent(pb.Operation_CREATE)
+	metrics.MessageReceived(pb.Operation_DELETE)
+	metrics.MessageRejected("rate_limit")
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.messagesSent.WithLabelValues("CREATE")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.messagesReceived.WithLabelValues("DELETE")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.messagesRejected.WithLabelValues("rate_limit")))
+
+	metrics.SyncCompleted(time.Second)
+	metrics.SyncItemsSent(3)
+	metrics.SyncItemsReceived(5)
+	metrics.RPCHandled("/pb.OrderHandler/Create", "OK", time.Millisecond)
+
+	body := scrape(t, metrics)
+	assert.Contains(t, body, `sprawl_p2p_messages_sent_total{operation="CREATE"} 2`)
+	assert.Contains(t, body, "sprawl_sync_duration_seconds_count 1")
+	assert.Contains(t, body, `sprawl_sync_update_items_sum{direction="received"} 5`)
+	assert.Contains(t, body, `sprawl_grpc_request_duration_seconds_count{code="OK",method="/pb.OrderHandler/Create"} 1`)
+}
+
+func TestOrderMetrics(t *testing.T) {
+	metrics := NewMetrics(nil)
+	metrics.RegisterOrderCounter(testOrderCounter{})
+
+	body := scrape(t, metrics)
+	assert.Contains(t, body, `sprawl_orders_stored{channel="testChannel",state="OPEN"} 2`)
+	assert.Contains(t, body, `sprawl_orders_stored{channel="testChannel",state="LOCKED"} 1`)
+}
+
+func TestStorageMetrics(t *testing.T) {
+	metrics := NewMetrics(nil)
+	storage := metrics.InstrumentStorage(&inmemory.Storage{Db: make(map[string]string)})
+
+	assert.NoError(t, storage.Put([]byte("key"), []byte("value")))
+	data, err := storage.Get([]byte("key"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), data)
+	_, err = storage.GetAllWithPrefix("k")
+	assert.NoError(t, err)
+	count, err := storage.CountWithPrefix("k")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	body := scrape(t, metrics)
+	assert.Contains(t, body, `sprawl_storage_operation_duration_seconds_count{operation="put"} 1`)
+	assert.Contains(t, body, `sprawl_storage_operation_duration_seconds_count{operation="get"} 1`)
+	assert.Contains(t, body, `sprawl_storage_operation_duration_seconds_count{operation="get_all_with_prefix"} 1`)
+	assert.Contains(t, body, `sprawl_storage_operation_duration_seconds_count{operation="count_with_prefix"} 1`)
+}
+
+func TestRun(t *testing.T) {
+	metrics := NewMetrics(nil)
+	go metrics.Run("127.0.0.1", 9301)
+	defer metrics.Close()
+
+	var resp *http.Response
+	assert.Eventually(t, func() bool {
+		var err error
+		resp, err = http.Get("http://127.0.0.1:9301/metrics")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/sprawl/sprawl/interfaces"
+)
+
+// Storage times the operations of the storage it wraps
+type Storage struct {
+	interfaces.Storage
+	metrics *Metrics
+}
+
+// InstrumentStorage returns storage with the latencies of its operations recorded
+func (metrics *Metrics) InstrumentStorage(storage interfaces.Storage) *Storage {
+	return &Storage{Storage: storage, metrics: metrics}
+}
+
+func (storage *Storage) observe(operation string, start time.Time) {
+	storage.metrics.storageDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
+
+// Has tells if the key is stored
+func (storage *Storage) Has(key []byte) (bool, error) {
+	defer storage.observe("has", time.Now())
+	return storage.Storage.Has(key)
+}
+
+// Get fetches the data stored at key
+func (storage *Storage) Get(key []byte) ([]byte, error) {
+	defer storage.observe("get", time.Now())
+	return storage.Storage.Get(key)
+}
+
+// Put stores data at key
+func (storage *Storage) Put(key []byte, data []byte) error {
+	defer storage.observe("put", time.Now())
+	return storage.Storage.Put(key, data)
+}
+
+// Delete deletes the data stored at key
+func (storage *Storage) Delete(key []byte) error {
+	defer storage.observe("delete", time.Now())
+	return storage.Storage.Delete(key)
+}
+
+// GetAll fetches everything stored
+func (storage *Storage) GetAll() (map[string]string, error) {
+	defer storage.observe("get_all", time.Now())
+	return storage.Storage.GetAll()
+}
+
+// GetAllWithPrefix fetches everything stored with keys starting with prefix
+func (storage *Storage) GetAllWithPrefix(prefix string) (map[string]string, error) {
+	defer storage.observe("get_all_with_prefix", time.Now())
+	return storage.Storage.GetAllWithPrefix(prefix)
+}
+
+// CountWithPrefix counts the entries stored with keys starting with prefix
+func (storage *Storage) CountWithPrefix(prefix string) (int, error) {
+	defer storage.observe("count_with_prefix", time.Now())
+	return storage.Storage.CountWithPrefix(prefix)
+}
+
+// DeleteAll deletes everything stored
+func (storage *Storage) DeleteAll() error {
+	defer storage.observe("delete_all", time.Now())
+	return storage.Storage.DeleteAll()
+}
+
+// DeleteAllWithPrefix deletes everything stored with keys starting with prefix
+func (storage *Storage) DeleteAllWithPrefix(prefix string) error {
+	defer storage.observe("delete_all_with_prefix", time.Now())
+	return storage.Storage.DeleteAllWithPrefix(prefix)
+}
@@ -0,0 +1,234 @@
+package p2p
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	connmgr "github.com/libp2p/go-libp2p-core/connmgr"
+	network "github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+)
+
+// The connection manager closes connections once there are more than connHighWater of them, down to connLowWater,
+// leaving connections younger than connGracePeriod alone
+const connLowWater = 100
+const connHighWater = 400
+const connGracePeriod = time.Minute
+
+// connTrimSilence is the least time between trims started by new connections
+const connTrimSilence = 10 * time.Second
+
+// connManager keeps the number of open connections between its water marks. When it trims them,
+// it closes the connections of the peers with the lowest tag values first and never the ones of protected peers.
+type connManager struct {
+	lowWater    int
+	highWater   int
+	gracePeriod time.Duration
+	peers       map[peer.ID]*connPeer
+	protected   map[peer.ID]map[string]bool
+	connCount   int
+	lastTrim    time.Time
+	lock        sync.Mutex
+}
+
+// connPeer is what the connection manager knows of a peer
+type connPeer struct {
+	firstSeen time.Time
+	tags      map[string]int
+	conns     map[network.Conn]time.Time
+}
+
+var _ connmgr.ConnManager = &connManager{}
+
+func newConnManager(lowWater int, highWater int, gracePeriod time.Duration) *connManager {
+	return &connManager{
+		lowWater:    lowWater,
+		highWater:   highWater,
+		gracePeriod: gracePeriod,
+		peers:       make(map[peer.ID]*connPeer),
+		protected:   make(map[peer.ID]map[string]bool),
+	}
+}
+
+// getPeer returns what's known of a peer, starting to track it if it wasn't. The lock must be held.
+func (cm *connManager) getPeer(peerID peer.ID) *connPeer {
+	info, ok := cm.peers[peerID]
+	if !ok {
+		info = &connPeer{firstSeen: time.Now(), tags: make(map[string]int), conns: make(map[network.Conn]time.Time)}
+		cm.peers[peerID] = info
+	}
+	return info
+}
+
+func (info *connPeer) value() int {
+	value := 0
+	for _, tagValue := range info.tags {
+		value += tagValue
+	}
+	return value
+}
+
+func (cm *connManager) TagPeer(peerID peer.ID, tag string, value int) {
+	cm.lock.Lock()
+	defer cm.lock.Unlock()
+	cm.getPeer(peerID).tags[tag] = value
+}
+
+func (cm *connManager) UntagPeer(peerID peer.ID, tag string) {
+	cm.lock.Lock()
+	defer cm.lock.Unlock()
+	if info, ok := cm.peers[peerID]; ok {
+		delete(info.tags, tag)
+	}
+}
+
+func (cm *connManager) UpsertTag(peerID peer.ID, tag string, upsert func(int) int) {
+	cm.lock.Lock()
+	defer cm.lock.Unlock()
+	info := cm.getPeer(peerID)
+	info.tags[tag] = upsert(info.tags[tag])
+}
+
+func (cm *connManager) GetTagInfo(peerID peer.ID) *connmgr.TagInfo {
+	cm.lock.Lock()
+	defer cm.lock.Unlock()
+	info, ok := cm.peers[peerID]
+	if !ok {
+		return nil
+	}
+	tagInfo := &connmgr.TagInfo{
+		FirstSeen: info.firstSeen,
+		Value:     info.value(),
+		Tags:      make(map[string]int, len(info.tags)),
+		Conns:     make(map[string]time.Time, len(info.conns)),
+	}
+	for tag, value := range info.tags {
+		tagInfo.Tags[tag] = value
+	}
+	for conn, opened := range info.conns {
+		tagInfo.Conns[conn.RemoteMultiaddr().String()] = opened
+	}
+	return tagInfo
+}
+
+func (cm *connManager) Protect(peerID peer.ID, tag string) {
+	cm.lock.Lock()
+	defer cm.lock.Unlock()
+	tags, ok := cm.protected[peerID]
+	if !ok {
+		tags = make(map[string]bool)
+		cm.protected[peerID] = tags
+	}
+	tags[tag] = true
+}
+
+func (cm *connManager) Unprotect(peerID peer.ID, tag string) bool {
+	cm.lock.Lock()
+	defer cm.lock.Unlock()
+	tags, ok := cm.protected[peerID]
+	if !ok {
+		return false
+	}
+	delete(tags, tag)
+	if len(tags) == 0 {
+		delete(cm.protected, peerID)
+		return false
+	}
+	return true
+}
+
+// TrimOpenConns closes connections until there are no more than the low water mark, if there are more than the high one
+func (cm *connManager) TrimOpenConns(ctx context.Context) {
+	for _, conn := range cm.getConnsToClose() {
+		if ctx.Err() != nil {
+			return
+		}
+		conn.Close()
+	}
+}
+
+func (cm *connManager) getConnsToClose() []network.Conn {
+	cm.lock.Lock()
+	defer cm.lock.Unlock()
+	cm.lastTrim = time.Now()
+
+	count := 0
+	candidates := []*connPeer{}
+	for peerID, info := range cm.peers {
+		if len(info.conns) == 0 {
+			// Peers tagged without ever connecting, or after disconnecting
+			delete(cm.peers, peerID)
+			continue
+		}
+		count += len(info.conns)
+		if len(cm.protected[peerID]) > 0 || time.Since(info.firstSeen) < cm.gracePeriod {
+			continue
+		}
+		candidates = append(candidates, info)
+	}
+	if count <= cm.highWater {
+		return nil
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].value() < candidates[j].value()
+	})
+	conns := []network.Conn{}
+	for _, info := range candidates {
+		if count <= cm.lowWater {
+			break
+		}
+		for conn := range info.conns {
+			conns = append(conns, conn)
+		}
+		count -= len(info.conns)
+	}
+	return conns
+}
+
+func (cm *connManager) Notifee() network.Notifiee {
+	return &network.NotifyBundle{ConnectedF: cm.connected, DisconnectedF: cm.disconnected}
+}
+
+func (cm *connManager) connected(n network.Network, conn network.Conn) {
+	cm.lock.Lock()
+	info := cm.getPeer(conn.RemotePeer())
+	if len(info.conns) == 0 {
+		info.firstSeen = time.Now()
+	}
+	if _, ok := info.conns[conn]; !ok {
+		cm.connCount++
+	}
+	info.conns[conn] = time.Now()
+	trim := cm.connCount > cm.highWater && time.Since(cm.lastTrim) > connTrimSilence
+	if trim {
+		cm.lastTrim = time.Now()
+	}
+	cm.lock.Unlock()
+
+	if trim {
+		go cm.TrimOpenConns(context.Background())
+	}
+}
+
+func (cm *connManager) disconnected(n network.Network, conn network.Conn) {
+	cm.lock.Lock()
+	defer cm.lock.Unlock()
+	info, ok := cm.peers[conn.RemotePeer()]
+	if !ok {
+		return
+	}
+	if _, ok := info.conns[conn]; ok {
+		cm.connCount--
+	}
+	delete(info.conns, conn)
+	if len(info.conns) == 0 {
+		delete(cm.peers, conn.RemotePeer())
+	}
+}
+
+func (cm *connManager) Close() error {
+	return nil
+}
@@ -0,0 +1,62 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTrimTestInstances returns a node that trims its connections down to one once it has more than that,
+// and two other nodes for it to connect to
+func newTrimTestInstances(t *testing.T) (*P2p, *P2p, *P2p) {
+	privateKey3, publicKey3, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	p2pInstance1 := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance2 := NewP2p(testConfig, privateKey2, publicKey2, Logger(log))
+	p2pInstance3 := NewP2p(testConfig, privateKey3, publicKey3, Logger(log))
+	p2pInstance1.InitHost(p2pInstance1.CreateOptions()...)
+	p2pInstance2.InitHost(p2pInstance2.CreateOptions()...)
+	p2pInstance3.InitHost(p2pInstance3.CreateOptions()...)
+	p2pInstance1.connManager.lowWater = 1
+	p2pInstance1.connManager.highWater = 1
+	p2pInstance1.connManager.gracePeriod = 0
+	return p2pInstance1, p2pInstance2, p2pInstance3
+}
+
+func TestTaggedPeersSurviveTrimming(t *testing.T) {
+	p2pInstance1, p2pInstance2, p2pInstance3 := newTrimTestInstances(t)
+	defer p2pInstance1.Close()
+	defer p2pInstance2.Close()
+	defer p2pInstance3.Close()
+
+	tag := channelPeerTag(testChannel.GetId())
+	addrInfo := peer.AddrInfo{ID: p2pInstance2.GetHostID(), Addrs: p2pInstance2.GetAddrs()}
+	assert.NoError(t, p2pInstance1.connectChannelPeer(context.Background(), addrInfo, tag))
+	assert.NoError(t, p2pInstance1.host.Connect(context.Background(), p2pInstance3.GetAddrInfo()))
+	assert.Equal(t, channelPeerTagValue, p2pInstance1.host.ConnManager().GetTagInfo(p2pInstance2.GetHostID()).Tags[tag])
+
+	// The peer sharing a channel is kept over the one that doesn't
+	p2pInstance1.host.ConnManager().TrimOpenConns(context.Background())
+	assert.Equal(t, network.Connected, p2pInstance1.host.Network().Connectedness(p2pInstance2.GetHostID()))
+	assert.NotEqual(t, network.Connected, p2pInstance1.host.Network().Connectedness(p2pInstance3.GetHostID()))
+}
+
+func TestProtectedPeersSurviveTrimming(t *testing.T) {
+	p2pInstance1, p2pInstance2, p2pInstance3 := newTrimTestInstances(t)
+	defer p2pInstance1.Close()
+	defer p2pInstance2.Close()
+	defer p2pInstance3.Close()
+
+	assert.NoError(t, p2pInstance1.ConnectPeer(getP2pAddr(t, p2pInstance2), true))
+	p2pInstance1.connManager.lowWater = 0
+	assert.NoError(t, p2pInstance1.host.Connect(context.Background(), p2pInstance3.GetAddrInfo()))
+
+	p2pInstance1.host.ConnManager().TrimOpenConns(context.Background())
+	assert.Equal(t, network.Connected, p2pInstance1.host.Network().Connectedness(p2pInstance2.GetHostID()))
+	assert.NotEqual(t, network.Connected, p2pInstance1.host.Network().Connectedness(p2pInstance3.GetHostID()))
+}
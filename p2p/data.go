@@ -2,9 +2,12 @@ package p2p
 
 import (
 	"context"
+	"fmt"
 
+	peer "github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
 	"github.com/sprawl/sprawl/pb"
 )
 
@@ -33,3 +36,26 @@ func (p2p *P2p) listenToChannel(ctx context.Context, sub *pubsub.Subscription, c
 		}
 	}(ctx)
 }
+
+// channelValidator returns the pubsub topic validator of a channel. Messages the receiver rejects are neither
+// delivered nor forwarded to other peers.
+func (p2p *P2p) channelValidator(channelID []byte) pubsub.Validator {
+	return func(ctx context.Context, from peer.ID, msg *pubsub.Message) bool {
+		author := msg.GetFrom()
+		// Our own messages are trusted, and already stored by the time they're published
+		if author == p2p.host.ID() {
+			return true
+		}
+
+		validator, ok := p2p.Receiver.(interfaces.Validator)
+		if !ok {
+			return true
+		}
+		err := validator.Validate(channelID, msg.GetData(), author)
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Debug(errors.E(errors.Op(fmt.Sprintf("Validate message from %s forwarded by %s", author, from)), err))
+			return false
+		}
+		return true
+	}
+}
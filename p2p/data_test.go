@@ -0,0 +1,51 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	libp2p "github.com/libp2p/go-libp2p"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type validatingReceiver struct {
+	collectingReceiver
+	validated []byte
+}
+
+func (r *validatingReceiver) Validate(channelID []byte, data []byte, from peer.ID) error {
+	r.validated = channelID
+	if string(data) != "valid" {
+		return errors.E(errors.Op("Validate"), "invalid message")
+	}
+	return nil
+}
+
+func TestChannelValidator(t *testing.T) {
+	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance.host, _ = libp2p.New(p2pInstance.ctx)
+	defer p2pInstance.host.Close()
+	validator := p2pInstance.channelValidator(testChannel.GetId())
+
+	author, err := peer.IDFromPublicKey(publicKey2)
+	assert.NoError(t, err)
+	message := func(author peer.ID, data string) *pubsub.Message {
+		return &pubsub.Message{Message: &pubsubpb.Message{From: []byte(author), Data: []byte(data)}}
+	}
+
+	// Without a validating receiver everything passes
+	assert.True(t, validator(context.Background(), author, message(author, "invalid")))
+
+	receiver := &validatingReceiver{}
+	p2pInstance.AddReceiver(receiver)
+	assert.True(t, validator(context.Background(), author, message(author, "valid")))
+	assert.Equal(t, testChannel.GetId(), receiver.validated)
+	assert.False(t, validator(context.Background(), author, message(author, "invalid")))
+
+	// Our own messages aren't validated again
+	assert.True(t, validator(context.Background(), author, message(p2pInstance.GetHostID(), "invalid")))
+}
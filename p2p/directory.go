@@ -0,0 +1,303 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	discovery "github.com/libp2p/go-libp2p-discovery"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// directoryRendezvous is the rendezvous point where nodes advertise that they publish a directory record
+const directoryRendezvous = networkID + "directory/"
+
+// directoryNamespace is the DHT namespace of the directory records, which list the public channels each node has joined
+const directoryNamespace = "sprawl-directory"
+const directoryRefreshInterval = time.Minute
+const directoryEntryTTL = 3 * directoryRefreshInterval
+const directoryQueryTimeout = 10 * time.Second
+const directoryPeerLimit = 50
+
+// directoryEntry is a channel seen on the network, with the peers that reported it and when
+type directoryEntry struct {
+	channel *pb.Channel
+	peers   map[peer.ID]time.Time
+}
+
+// directoryKey is the DHT key of a node's directory record
+func directoryKey(peerID peer.ID) string {
+	return "/" + directoryNamespace + "/" + string(peerID)
+}
+
+// getDirectoryRecordData returns the part of a directory record its node signs
+func getDirectoryRecordData(record *pb.DirectoryRecord) ([]byte, error) {
+	return proto.Marshal(&pb.DirectoryRecord{Channels: record.GetChannels(), Updated: record.GetUpdated()})
+}
+
+// directoryValidator accepts the directory records signed by the node in their key, and prefers the newest one
+type directoryValidator struct{}
+
+func (validator directoryValidator) Validate(key string, value []byte) error {
+	prefix := "/" + directoryNamespace + "/"
+	if !strings.HasPrefix(key, prefix) {
+		return errors.E(errors.Op("Check directory record key"), "not a directory record key")
+	}
+	peerID, err := peer.IDFromBytes([]byte(strings.TrimPrefix(key, prefix)))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Parse directory record key"), err)
+	}
+	publicKey, err := peerID.ExtractPublicKey()
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Extract public key of directory record"), err)
+	}
+
+	record := &pb.DirectoryRecord{}
+	err = proto.Unmarshal(value, record)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal directory record"), err)
+	}
+	data, err := getDirectoryRecordData(record)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal directory record"), err)
+	}
+	valid, err := publicKey.Verify(data, record.GetSignature())
+	if !errors.IsEmpty(err) || !valid {
+		return errors.E(errors.Op("Verify directory record"), "directory record isn't signed by "+peerID.String())
+	}
+	return nil
+}
+
+func (validator directoryValidator) Select(key string, values [][]byte) (int, error) {
+	newest := -1
+	var newestTime time.Time
+	for i, value := range values {
+		record := &pb.DirectoryRecord{}
+		if !errors.IsEmpty(proto.Unmarshal(value, record)) {
+			continue
+		}
+		updated, err := ptypes.Timestamp(record.GetUpdated())
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		if newest < 0 || updated.After(newestTime) {
+			newest = i
+			newestTime = updated
+		}
+	}
+	if newest < 0 {
+		return 0, errors.E(errors.Op("Select directory record"), "no valid directory records")
+	}
+	return newest, nil
+}
+
+// addPublicChannel starts sharing a joined channel with the rest of the network. Private channels are never shared.
+func (p2p *P2p) addPublicChannel(channel *pb.Channel) {
+	if channel.GetOptions().GetType() != pb.ChannelType_PUBLIC {
+		return
+	}
+	p2p.directoryLock.Lock()
+	defer p2p.directoryLock.Unlock()
+	p2p.publicChannels[string(channel.GetId())] = &pb.Channel{Id: channel.GetId(), Options: channel.GetOptions()}
+}
+
+func (p2p *P2p) removePublicChannel(channel *pb.Channel) {
+	p2p.directoryLock.Lock()
+	defer p2p.directoryLock.Unlock()
+	delete(p2p.publicChannels, string(channel.GetId()))
+}
+
+// startDirectory advertises this node in the channel directory, and keeps publishing its public channels in the DHT
+// and reading the ones other nodes have published until p2p is closed
+func (p2p *P2p) startDirectory() {
+	ctx := p2p.ctx
+	discovery.Advertise(ctx, p2p.routingDiscovery, directoryRendezvous)
+
+	p2p.background.Add(1)
+	go func() {
+		defer p2p.background.Done()
+		ticker := time.NewTicker(directoryRefreshInterval)
+		defer ticker.Stop()
+		for {
+			p2p.refreshDirectory(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// refreshDirectory publishes our directory record, and reads the records of the nodes found at the directory rendezvous
+func (p2p *P2p) refreshDirectory(ctx context.Context) {
+	err := p2p.publishDirectory(ctx)
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Debug(errors.E(errors.Op("Publish directory record"), err))
+	}
+
+	findCtx, cancel := context.WithTimeout(ctx, directoryQueryTimeout)
+	found, err := discovery.FindPeers(findCtx, p2p.routingDiscovery, directoryRendezvous, discovery.Limit(directoryPeerLimit))
+	cancel()
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Debug(errors.E(errors.Op("Find directory peers"), err))
+	}
+
+	// The records are looked up in the DHT, the nodes that published them aren't dialed
+	var wg sync.WaitGroup
+	for _, addrInfo := range found {
+		if addrInfo.ID == p2p.host.ID() {
+			continue
+		}
+		wg.Add(1)
+		go func(peerID peer.ID) {
+			defer wg.Done()
+			record, err := p2p.getDirectoryRecord(ctx, peerID)
+			if !errors.IsEmpty(err) {
+				p2p.Logger.Debug(errors.E(errors.Op("Get directory record of "+peerID.String()), err))
+				return
+			}
+			p2p.recordChannels(peerID, record)
+		}(addrInfo.ID)
+	}
+	wg.Wait()
+
+	p2p.pruneDirectory()
+}
+
+// publishDirectory stores the public channels this node has joined in the DHT, signed so that no other node can replace them
+func (p2p *P2p) publishDirectory(ctx context.Context) error {
+	p2p.directoryLock.RLock()
+	record := &pb.DirectoryRecord{Updated: ptypes.TimestampNow()}
+	for _, channel := range p2p.publicChannels {
+		record.Channels = append(record.Channels, channel)
+	}
+	p2p.directoryLock.RUnlock()
+	sort.Slice(record.Channels, func(i, j int) bool {
+		return bytes.Compare(record.Channels[i].GetId(), record.Channels[j].GetId()) < 0
+	})
+
+	data, err := getDirectoryRecordData(record)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal directory record"), err)
+	}
+	record.Signature, err = p2p.privateKey.Sign(data)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Sign directory record"), err)
+	}
+	value, err := proto.Marshal(record)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal directory record"), err)
+	}
+
+	putCtx, cancel := context.WithTimeout(ctx, directoryQueryTimeout)
+	defer cancel()
+	return p2p.kademliaDHT.PutValue(putCtx, directoryKey(p2p.host.ID()), value)
+}
+
+func (p2p *P2p) getDirectoryRecord(ctx context.Context, peerID peer.ID) (*pb.DirectoryRecord, error) {
+	getCtx, cancel := context.WithTimeout(ctx, directoryQueryTimeout)
+	defer cancel()
+	value, err := p2p.kademliaDHT.GetValue(getCtx, directoryKey(peerID))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get directory record"), err)
+	}
+	record := &pb.DirectoryRecord{}
+	err = proto.Unmarshal(value, record)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal directory record"), err)
+	}
+	return record, nil
+}
+
+// recordChannels records the channels in a node's directory record as seen when the record was published,
+// and forgets the node on the channels it has left since
+func (p2p *P2p) recordChannels(peerID peer.ID, record *pb.DirectoryRecord) {
+	updated, err := ptypes.Timestamp(record.GetUpdated())
+	if !errors.IsEmpty(err) {
+		return
+	}
+	listed := make(map[string]bool)
+	p2p.directoryLock.Lock()
+	defer p2p.directoryLock.Unlock()
+	for _, channel := range record.GetChannels() {
+		if channel.GetOptions().GetType() != pb.ChannelType_PUBLIC {
+			continue
+		}
+		listed[string(channel.GetId())] = true
+		entry, ok := p2p.directory[string(channel.GetId())]
+		if !ok {
+			entry = &directoryEntry{peers: make(map[peer.ID]time.Time)}
+			p2p.directory[string(channel.GetId())] = entry
+		}
+		entry.channel = &pb.Channel{Id: channel.GetId(), Options: channel.GetOptions()}
+		if updated.After(entry.peers[peerID]) {
+			entry.peers[peerID] = updated
+		}
+	}
+	for channelID, entry := range p2p.directory {
+		if !listed[channelID] && !entry.peers[peerID].After(updated) {
+			delete(entry.peers, peerID)
+		}
+	}
+}
+
+// pruneDirectory forgets peers, and channels, that haven't published their records in a while
+func (p2p *P2p) pruneDirectory() {
+	p2p.directoryLock.Lock()
+	defer p2p.directoryLock.Unlock()
+	for channelID, entry := range p2p.directory {
+		for peerID, seen := range entry.peers {
+			if time.Since(seen) > directoryEntryTTL {
+				delete(entry.peers, peerID)
+			}
+		}
+		if len(entry.peers) == 0 {
+			delete(p2p.directory, channelID)
+		}
+	}
+}
+
+// GetNetworkChannels returns all public channels discovered on the network, and the ones this node has joined,
+// with an approximate count of the peers on each
+func (p2p *P2p) GetNetworkChannels() []*pb.NetworkChannel {
+	p2p.directoryLock.RLock()
+	defer p2p.directoryLock.RUnlock()
+
+	channels := make(map[string]*pb.NetworkChannel)
+	for channelID, entry := range p2p.directory {
+		var lastSeen time.Time
+		for _, seen := range entry.peers {
+			if seen.After(lastSeen) {
+				lastSeen = seen
+			}
+		}
+		timestamp, _ := ptypes.TimestampProto(lastSeen)
+		channels[channelID] = &pb.NetworkChannel{Channel: entry.channel, Peers: uint32(len(entry.peers)), LastSeen: timestamp}
+	}
+	for channelID, channel := range p2p.publicChannels {
+		networkChannel, ok := channels[channelID]
+		if !ok {
+			networkChannel = &pb.NetworkChannel{Channel: channel, LastSeen: ptypes.TimestampNow()}
+			channels[channelID] = networkChannel
+		}
+		networkChannel.Joined = true
+		networkChannel.Peers++
+	}
+
+	networkChannels := make([]*pb.NetworkChannel, 0, len(channels))
+	for _, networkChannel := range channels {
+		networkChannels = append(networkChannels, networkChannel)
+	}
+	sort.Slice(networkChannels, func(i, j int) bool {
+		return string(networkChannels[i].GetChannel().GetId()) < string(networkChannels[j].GetChannel().GetId())
+	})
+	return networkChannels
+}
@@ -0,0 +1,106 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	discovery "github.com/libp2p/go-libp2p-discovery"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirectoryValidator(t *testing.T) {
+	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance.InitHost(p2pInstance.CreateOptions()...)
+	defer p2pInstance.Close()
+
+	record := &pb.DirectoryRecord{Channels: []*pb.Channel{testChannel}, Updated: ptypes.TimestampNow()}
+	data, err := getDirectoryRecordData(record)
+	assert.NoError(t, err)
+	record.Signature, err = privateKey.Sign(data)
+	assert.NoError(t, err)
+	value, err := proto.Marshal(record)
+	assert.NoError(t, err)
+
+	// Records are only valid under the key of the node that signed them
+	validator := directoryValidator{}
+	assert.NoError(t, validator.Validate(directoryKey(p2pInstance.GetHostID()), value))
+	other := NewP2p(testConfig, privateKey2, publicKey2, Logger(log))
+	other.InitHost(other.CreateOptions()...)
+	defer other.Close()
+	assert.Error(t, validator.Validate(directoryKey(other.GetHostID()), value))
+
+	// The newest record wins
+	older := &pb.DirectoryRecord{Updated: &timestamp.Timestamp{Seconds: record.GetUpdated().GetSeconds() - 60}}
+	olderValue, err := proto.Marshal(older)
+	assert.NoError(t, err)
+	selected, err := validator.Select(directoryKey(p2pInstance.GetHostID()), [][]byte{olderValue, value})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, selected)
+}
+
+func TestChannelDirectory(t *testing.T) {
+	p2pInstance1 := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance2 := NewP2p(testConfig, privateKey2, publicKey2, Logger(log))
+
+	p2pInstance1.InitHost(p2pInstance1.CreateOptions()...)
+	p2pInstance2.InitHost(p2pInstance2.CreateOptions()...)
+	p2pInstance1.initPubSub()
+	p2pInstance2.initPubSub()
+	p2pInstance1.routingDiscovery = discovery.NewRoutingDiscovery(p2pInstance1.kademliaDHT)
+	p2pInstance2.routingDiscovery = discovery.NewRoutingDiscovery(p2pInstance2.kademliaDHT)
+	defer p2pInstance1.Close()
+	defer p2pInstance2.Close()
+
+	privateChannel := &pb.Channel{Id: []byte("privateChannel"), Options: &pb.ChannelOptions{Type: pb.ChannelType_PRIVATE}}
+	_, err := p2pInstance2.Subscribe(testChannel)
+	assert.NoError(t, err)
+	_, err = p2pInstance2.Subscribe(privateChannel)
+	assert.NoError(t, err)
+
+	err = p2pInstance1.ConnectPeer(getP2pAddr(t, p2pInstance2), false)
+	assert.NoError(t, err)
+
+	// The other node publishes its record in the DHT and advertises it at the directory rendezvous
+	// It can only do that once the nodes have added each other to their routing tables.
+	for attempt := 0; attempt < 50; attempt++ {
+		_, err = p2pInstance2.routingDiscovery.Advertise(p2pInstance2.ctx, directoryRendezvous)
+		if err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	assert.NoError(t, err)
+	assert.NoError(t, p2pInstance2.publishDirectory(p2pInstance2.ctx))
+	p2pInstance1.refreshDirectory(p2pInstance1.ctx)
+
+	// Only the public channel is shared, and the other node hasn't joined it
+	networkChannels := p2pInstance1.GetNetworkChannels()
+	assert.Len(t, networkChannels, 1)
+	assert.Equal(t, testChannel.GetId(), networkChannels[0].GetChannel().GetId())
+	assert.Equal(t, uint32(1), networkChannels[0].GetPeers())
+	assert.False(t, networkChannels[0].GetJoined())
+
+	_, err = p2pInstance1.Subscribe(testChannel)
+	assert.NoError(t, err)
+	networkChannels = p2pInstance1.GetNetworkChannels()
+	assert.Len(t, networkChannels, 1)
+	assert.Equal(t, uint32(2), networkChannels[0].GetPeers())
+	assert.True(t, networkChannels[0].GetJoined())
+
+	// Channels the other node has left are forgotten with its next record
+	p2pInstance2.removePublicChannel(testChannel)
+	assert.NoError(t, p2pInstance2.publishDirectory(p2pInstance2.ctx))
+	p2pInstance1.refreshDirectory(p2pInstance1.ctx)
+	networkChannels = p2pInstance1.GetNetworkChannels()
+	assert.Len(t, networkChannels, 1)
+	assert.Equal(t, uint32(1), networkChannels[0].GetPeers())
+
+	// Closing stops the directory
+	p2pInstance1.startDirectory()
+	p2pInstance1.Close()
+	assert.Error(t, p2pInstance1.ctx.Err())
+}
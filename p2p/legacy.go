@@ -0,0 +1,103 @@
+package p2p
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// legacyStream sends messages to a node from before versioning on the unversioned networkID protocol.
+// Those nodes read a single unframed message per stream, so a new stream is opened for every message.
+type legacyStream struct {
+	p2p    *P2p
+	peerID peer.ID
+}
+
+// WriteToStream writes data unframed to a new stream, closing it so the other node knows the message is complete
+func (stream *legacyStream) WriteToStream(data []byte) error {
+	if len(data) > maxMessageSize {
+		return errors.E(errors.Op("Check message length"), fmt.Sprintf("message of %d bytes exceeds the maximum of %d", len(data), maxMessageSize))
+	}
+	buf, err := stream.p2p.host.NewStream(stream.p2p.ctx, stream.peerID, networkID)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Open a legacy stream"), err)
+	}
+	buf.SetWriteDeadline(time.Now().Add(requestTimeout))
+	_, err = buf.Write(data)
+	if !errors.IsEmpty(err) {
+		buf.Reset()
+		return errors.E(errors.Op("Write to legacy stream"), err)
+	}
+	return buf.Close()
+}
+
+// Request isn't supported, nodes from before versioning never reply on the stream they're written to
+func (stream *legacyStream) Request(data []byte) ([]byte, error) {
+	return nil, errors.E(errors.Op("Request on legacy stream"), "peers from before versioning don't answer requests")
+}
+
+// handleLegacyStream passes the single message a node from before versioning wrote on the stream to the receiver
+func (p2p *P2p) handleLegacyStream(buf network.Stream) {
+	defer buf.Close()
+	buf.SetReadDeadline(time.Now().Add(requestTimeout))
+
+	peerID := buf.Conn().RemotePeer()
+	data, err := ioutil.ReadAll(io.LimitReader(buf, maxMessageSize+1))
+	if !errors.IsEmpty(err) {
+		buf.Reset()
+		p2p.Logger.Debug(errors.E(errors.Op("Read legacy stream from "+peerID.String()), err))
+		return
+	}
+	if len(data) > maxMessageSize {
+		buf.Reset()
+		p2p.Logger.Debugf("Dropping a message from %s that exceeds the maximum size", peerID)
+		return
+	}
+	if p2p.Receiver == nil {
+		return
+	}
+	err = p2p.Receiver.Receive(data, peerID)
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Error(errors.E(errors.Op("Passing data from legacy stream to receiver"), err))
+	}
+}
+
+// IsLegacyPeer tells if a peer is from before versioning, which means it didn't answer the handshake.
+// The handshake is done first if it hasn't been done yet.
+func (p2p *P2p) IsLegacyPeer(peerID peer.ID) bool {
+	if _, ok := p2p.GetPeerHandshake(peerID); ok {
+		return false
+	}
+	err := p2p.handshake(peerID)
+	if !errors.IsEmpty(err) {
+		return false
+	}
+	_, ok := p2p.GetPeerHandshake(peerID)
+	return !ok
+}
+
+// sendLegacySyncRequest asks a node from before versioning to sync a channel.
+// It answers with all of its orders on the channel on a stream of its own.
+func (p2p *P2p) sendLegacySyncRequest(peerID peer.ID, topicString string) error {
+	syncMessage := &pb.WireMessage{Operation: pb.Operation_SYNC_REQUEST, ChannelID: []byte(topicString)}
+	marshaledData, err := proto.Marshal(syncMessage)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal sync request wireMessage"), err)
+	}
+	stream := &legacyStream{p2p: p2p, peerID: peerID}
+	err = stream.WriteToStream(marshaledData)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Send legacy sync request"), err)
+	}
+	if p2p.metrics != nil {
+		p2p.metrics.MessageSent(pb.Operation_SYNC_REQUEST)
+	}
+	return nil
+}
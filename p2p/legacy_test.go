@@ -0,0 +1,50 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLegacyPeer(t *testing.T) {
+	receiver := &collectingReceiver{}
+	p2pInstance1, p2pInstance2 := newConnectedInstances(t, receiver)
+	defer p2pInstance1.host.Close()
+	defer p2pInstance2.host.Close()
+	peerID := p2pInstance2.GetHostID()
+
+	assert.Eventually(t, func() bool {
+		_, ok := p2pInstance1.GetPeerHandshake(peerID)
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, p2pInstance1.IsLegacyPeer(peerID))
+
+	// Nodes from before versioning don't answer the handshake
+	p2pInstance2.host.RemoveStreamHandler(handshakeProtocol)
+	assert.NoError(t, p2pInstance1.host.Peerstore().SetProtocols(peerID))
+	p2pInstance1.handshakeLock.Lock()
+	delete(p2pInstance1.handshakes, peerID)
+	p2pInstance1.handshakeLock.Unlock()
+	assert.True(t, p2pInstance1.IsLegacyPeer(peerID))
+
+	// They're sent unframed messages, one per stream, and can't be sent requests
+	stream, err := p2pInstance1.OpenStream(peerID)
+	assert.NoError(t, err)
+	assert.NoError(t, stream.WriteToStream([]byte("first")))
+	assert.NoError(t, stream.WriteToStream([]byte("second")))
+	_, err = stream.Request([]byte("ping"))
+	assert.Error(t, err)
+	assert.Eventually(t, func() bool { return len(receiver.getReceived()) == 2 }, 2*time.Second, 10*time.Millisecond)
+	assert.ElementsMatch(t, [][]byte{[]byte("first"), []byte("second")}, receiver.getReceived())
+
+	// And they're asked to sync without set reconciliation
+	assert.True(t, p2pInstance1.syncWithPeer(string(testChannel.GetId()), peerID))
+	assert.Eventually(t, func() bool { return len(receiver.getReceived()) == 3 }, 2*time.Second, 10*time.Millisecond)
+	syncRequest := &pb.WireMessage{}
+	assert.NoError(t, proto.Unmarshal(receiver.getReceived()[2], syncRequest))
+	assert.Equal(t, pb.Operation_SYNC_REQUEST, syncRequest.GetOperation())
+	assert.Equal(t, testChannel.GetId(), syncRequest.GetChannelID())
+}
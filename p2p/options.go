@@ -11,6 +11,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/host"
 	routing "github.com/libp2p/go-libp2p-core/routing"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
+	dhtopts "github.com/libp2p/go-libp2p-kad-dht/opts"
 	libp2pConfig "github.com/libp2p/go-libp2p/config"
 	ma "github.com/multiformats/go-multiaddr"
 )
@@ -42,6 +43,14 @@ func Logger(logger interfaces.Logger) Option {
 	}
 }
 
+// Metrics records the messages p2p sends and how long syncing channels takes
+func Metrics(metrics interfaces.Metrics) Option {
+	return func(p *P2p) error {
+		p.metrics = metrics
+		return nil
+	}
+}
+
 // Receiver receives all data that other peers send on pubsub channels
 func Receiver(receiver interfaces.Receiver) Option {
 	return func(p *P2p) error {
@@ -68,7 +77,7 @@ func createMultiAddr(externalIP string, p2pPort string) (ma.Multiaddr, error) {
 func (p2p *P2p) initDHT() libp2pConfig.Option {
 	NewDHT := func(h host.Host) (routing.PeerRouting, error) {
 		var err error
-		p2p.kademliaDHT, err = dht.New(p2p.ctx, h)
+		p2p.kademliaDHT, err = dht.New(p2p.ctx, h, dhtopts.NamespacedValidator(directoryNamespace, directoryValidator{}))
 		if !errors.IsEmpty(err) {
 			p2p.Logger.Error(errors.E(errors.Op("Add dht"), err))
 		}
@@ -87,6 +96,10 @@ func (p2p *P2p) CreateOptions() []libp2pConfig.Option {
 	options = append(options, p2p.initDHT())
 	options = append(options, libp2p.Identity(p2p.privateKey))
 
+	// Tagged and protected peers only mean something to a connection manager that trims connections
+	p2p.connManager = newConnManager(connLowWater, connHighWater, connGracePeriod)
+	options = append(options, libp2p.ConnectionManager(p2p.connManager))
+
 	// libp2p relay options
 	if p2p.Config.GetRelaySetting() {
 		options = append(options, libp2p.EnableRelay())
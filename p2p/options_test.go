@@ -71,6 +71,7 @@ func TestCreateOptions(t *testing.T) {
 
 	options = append(options, p2pInstance.initDHT())
 	options = append(options, libp2p.Identity(p2pInstance.privateKey))
+	options = append(options, libp2p.ConnectionManager(p2pInstance.connManager))
 	options = append(options, libp2p.EnableRelay())
 	options = append(options, libp2p.EnableAutoRelay())
 	options = append(options, libp2p.NATPortMap())
@@ -87,6 +88,7 @@ func TestCreateOptions(t *testing.T) {
 
 	options = append(options, p2pInstance.initDHT())
 	options = append(options, libp2p.Identity(p2pInstance.privateKey))
+	options = append(options, libp2p.ConnectionManager(p2pInstance.connManager))
 	options = append(options, libp2p.EnableRelay())
 	options = append(options, libp2p.EnableAutoRelay())
 	multiaddrs := []ma.Multiaddr{}
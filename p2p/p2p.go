@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/sprawl/sprawl/interfaces"
@@ -22,6 +23,7 @@ import (
 	"github.com/sprawl/sprawl/pb"
 )
 
+// networkID is the prefix of all Sprawl rendezvous points, and the unversioned stream protocol of older nodes
 const networkID = "/sprawl/"
 
 // P2p stores all things required to converse with other peers in the Sprawl network and save data locally
@@ -31,7 +33,10 @@ type P2p struct {
 	publicKey        crypto.PubKey
 	ps               *pubsub.PubSub
 	ctx              context.Context
+	cancel           context.CancelFunc
+	background       sync.WaitGroup
 	host             host.Host
+	connManager      *connManager
 	kademliaDHT      *dht.IpfsDHT
 	routingDiscovery *discovery.RoutingDiscovery
 	peerChan         <-chan peer.AddrInfo
@@ -40,21 +45,38 @@ type P2p struct {
 	subLock          sync.RWMutex
 	streams          map[string]*Stream
 	streamLock       sync.RWMutex
+	protectedPeers   map[peer.ID]ma.Multiaddr
+	redialing        map[peer.ID]bool
+	protectedLock    sync.RWMutex
+	publicChannels   map[string]*pb.Channel
+	directory        map[string]*directoryEntry
+	directoryLock    sync.RWMutex
+	handshakes       map[peer.ID]*pb.Handshake
+	handshakeLock    sync.RWMutex
+	syncStatus       map[string]*pb.SyncStatus
+	syncLock         sync.RWMutex
 	Logger           interfaces.Logger
 	storage          interfaces.Storage
 	Receiver         interfaces.Receiver
+	metrics          interfaces.Metrics
+	started          time.Time
 }
 
 // NewP2p returns a P2p struct with an input channel
 func NewP2p(config interfaces.Config, privateKey crypto.PrivKey, publicKey crypto.PubKey, opts ...Option) (p2p *P2p) {
 	p2p = &P2p{
-		ctx:           context.Background(),
-		Config:        config,
-		privateKey:    privateKey,
-		publicKey:     publicKey,
-		input:         make(chan pb.WireMessage),
-		subscriptions: make(map[string]context.CancelFunc),
-		streams:       make(map[string]*Stream),
+		Config:         config,
+		privateKey:     privateKey,
+		publicKey:      publicKey,
+		input:          make(chan pb.WireMessage),
+		subscriptions:  make(map[string]context.CancelFunc),
+		streams:        make(map[string]*Stream),
+		protectedPeers: make(map[peer.ID]ma.Multiaddr),
+		redialing:      make(map[peer.ID]bool),
+		publicChannels: make(map[string]*pb.Channel),
+		directory:      make(map[string]*directoryEntry),
+		handshakes:     make(map[peer.ID]*pb.Handshake),
+		syncStatus:     make(map[string]*pb.SyncStatus),
 	}
 
 	for _, opt := range opts {
@@ -67,6 +89,7 @@ func NewP2p(config interfaces.Config, privateKey crypto.PrivKey, publicKey crypt
 	if p2p.Logger == nil {
 		p2p.Logger = new(util.PlaceholderLogger)
 	}
+	p2p.ctx, p2p.cancel = context.WithCancel(context.Background())
 
 	return p2p
 }
@@ -86,7 +109,7 @@ func (p2p *P2p) InitHost(options ...libp2pConfig.Option) {
 		options...)
 
 	// Set stream handler for libp2p host
-	p2p.host.SetStreamHandler(networkID, p2p.handleStream)
+	p2p.setProtocolHandlers()
 
 	if !errors.IsEmpty(err) {
 		p2p.Logger.Error(errors.E(errors.Op("Creating host"), err))
@@ -109,6 +132,35 @@ func (p2p *P2p) GetHostID() peer.ID {
 	return p2p.host.ID()
 }
 
+// GetAddrs returns the addresses the underlying libp2p host is listening to
+func (p2p *P2p) GetAddrs() []ma.Multiaddr {
+	return p2p.host.Addrs()
+}
+
+// GetProtocolVersion returns the versioned libp2p protocol ID Sprawl nodes use to talk to each other
+func (p2p *P2p) GetProtocolVersion() string {
+	return string(syncProtocol)
+}
+
+// GetTopics returns the IDs of all channels this node is currently subscribed to
+func (p2p *P2p) GetTopics() []string {
+	p2p.subLock.RLock()
+	defer p2p.subLock.RUnlock()
+	topics := make([]string, 0, len(p2p.subscriptions))
+	for topic := range p2p.subscriptions {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+// GetUptime returns the time elapsed since the p2p network was started
+func (p2p *P2p) GetUptime() time.Duration {
+	if p2p.started.IsZero() {
+		return 0
+	}
+	return time.Since(p2p.started)
+}
+
 // GetAddrInfo uses p2p.ConstructAddrInfo to get this peer's own AddrInfo
 func (p2p *P2p) GetAddrInfo() peer.AddrInfo {
 	return p2p.ConstructAddrInfo(p2p.GetHostID(), p2p.host.Addrs())
@@ -190,7 +242,7 @@ func (p2p *P2p) listenForPeers() {
 				} else {
 					p2p.Logger.Infof("Connected to: %s\n", peer)
 				}
-			}(p2p.ctx)
+			}(ctx)
 			wg.Wait()
 		}
 	}(p2p.ctx)
@@ -198,6 +250,7 @@ func (p2p *P2p) listenForPeers() {
 
 // handleInput takes in any local input, marshals it to Protobuf bytes and publishes it
 func (p2p *P2p) handleInput(message *pb.WireMessage) {
+	message.Version = interfaces.WireVersion
 	buf, err := proto.Marshal(message)
 	if !errors.IsEmpty(err) {
 		p2p.Logger.Error(errors.E(errors.Op("Marshal proto"), err))
@@ -206,6 +259,8 @@ func (p2p *P2p) handleInput(message *pb.WireMessage) {
 	err = p2p.ps.Publish(string(message.GetChannelID()), buf)
 	if !errors.IsEmpty(err) {
 		p2p.Logger.Error(errors.E(errors.Op("Marshal proto"), fmt.Sprintf("%v, message data: %s", err.Error(), message.Data)))
+	} else if p2p.metrics != nil {
+		p2p.metrics.MessageSent(message.GetOperation())
 	}
 }
 
@@ -233,6 +288,14 @@ func (p2p *P2p) GetAllPeers() []peer.ID {
 	return p2p.host.Network().Peers()
 }
 
+// GetChannelPeers returns the peers we're connected to on the channel's pubsub topic
+func (p2p *P2p) GetChannelPeers(channelID []byte) []peer.ID {
+	if p2p.ps == nil {
+		return nil
+	}
+	return p2p.ps.ListPeers(string(channelID))
+}
+
 // BlacklistPeer blacklists a peer from connecting to this node
 func (p2p *P2p) BlacklistPeer(pbPeer *pb.Peer) {
 	peer, _ := peer.IDFromString(pbPeer.GetId())
@@ -245,13 +308,22 @@ func (p2p *P2p) Subscribe(channel *pb.Channel) (context.Context, error) {
 
 	p2p.Logger.Infof("Subscribing to channel %s with options: %s", channel.GetId(), channel.GetOptions())
 
+	// Invalid messages are dropped before they're forwarded to the rest of the mesh
+	err := p2p.ps.RegisterTopicValidator(string(channel.GetId()), p2p.channelValidator(channel.GetId()))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Register topic validator"), err)
+	}
+
 	topic, err := p2p.ps.Join(string(channel.GetId()))
 	if !errors.IsEmpty(err) {
+		p2p.ps.UnregisterTopicValidator(string(channel.GetId()))
 		return nil, errors.E(errors.Op("Join libp2p Topic"), err)
 	}
 
 	sub, err = topic.Subscribe()
 	if !errors.IsEmpty(err) {
+		topic.Close()
+		p2p.ps.UnregisterTopicValidator(string(channel.GetId()))
 		return nil, errors.E(errors.Op("Subscribe to libp2p Topic"), err)
 	}
 
@@ -259,21 +331,30 @@ func (p2p *P2p) Subscribe(channel *pb.Channel) (context.Context, error) {
 	p2p.subLock.Lock()
 	p2p.subscriptions[string(channel.GetId())] = cancel
 	p2p.subLock.Unlock()
+	p2p.addPublicChannel(channel)
 
 	// Listen for new data
 	p2p.listenToChannel(subCtx, sub, channel)
 
 	p2p.requestSync(subCtx, sub.Topic(), topic)
 
+	// Find and connect to the other peers of this channel until it's left
+	p2p.discoverChannelPeers(subCtx, channel)
+
 	go func(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			sub.Cancel()
 			topic.Close()
+			p2p.ps.UnregisterTopicValidator(string(channel.GetId()))
 
 			p2p.subLock.Lock()
 			delete(p2p.subscriptions, string(channel.GetId()))
 			p2p.subLock.Unlock()
+			p2p.removePublicChannel(channel)
+			p2p.syncLock.Lock()
+			delete(p2p.syncStatus, string(channel.GetId()))
+			p2p.syncLock.Unlock()
 
 			p2p.Logger.Debugf("Left channel %s, remaining channels %s", string(channel.GetId()), p2p.subscriptions)
 
@@ -291,15 +372,31 @@ func (p2p *P2p) Unsubscribe(channel *pb.Channel) {
 
 // Run runs the p2p network
 func (p2p *P2p) Run() {
+	p2p.started = time.Now()
+
+	// A closed node starts over with a fresh context
+	if p2p.ctx.Err() != nil {
+		p2p.ctx, p2p.cancel = context.WithCancel(context.Background())
+	}
+
 	// Initialize the p2p host with options
 	p2p.InitHost(p2p.CreateOptions()...)
 
+	// Redial protected peers whenever they disconnect
+	p2p.watchProtectedPeers()
+
 	// Connect to Sprawl & IPFS main nodes for peer discovery
 	p2p.connectToNetwork()
 
+	// Connect to the peers this node should always be connected to
+	p2p.connectToProtectedPeers()
+
 	// Start finding peers on the network
 	p2p.startDiscovery()
 
+	// Share our public channels and discover the ones other nodes have joined
+	p2p.startDirectory()
+
 	// Start PubSub
 	p2p.initPubSub()
 
@@ -310,8 +407,16 @@ func (p2p *P2p) Run() {
 	p2p.listenForPeers()
 }
 
-// Close closes the underlying libp2p host
+// Close stops the background work of the node and closes the underlying libp2p host
 func (p2p *P2p) Close() {
 	p2p.Logger.Debug("P2P shutting down")
+	p2p.cancel()
+	p2p.background.Wait()
+
+	// Stop redialing protected peers, they're loaded from storage again on Run
+	p2p.protectedLock.Lock()
+	p2p.protectedPeers = make(map[peer.ID]ma.Multiaddr)
+	p2p.protectedLock.Unlock()
+
 	p2p.host.Close()
 }
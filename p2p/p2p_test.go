@@ -1,7 +1,6 @@
 package p2p
 
 import (
-	"context"
 	"crypto/rand"
 	"testing"
 	"time"
@@ -13,6 +12,7 @@ import (
 	"github.com/sprawl/sprawl/config"
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/interfaces"
 	"github.com/sprawl/sprawl/pb"
 	"github.com/sprawl/sprawl/service"
 	"github.com/sprawl/sprawl/util"
@@ -57,7 +57,7 @@ func TestConstructor(t *testing.T) {
 	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log), Receiver(orderService))
 	assert.Equal(t, orderService, p2pInstance.Receiver)
 	assert.Equal(t, log, p2pInstance.Logger)
-	assert.Equal(t, p2pInstance.ctx, context.Background())
+	assert.NoError(t, p2pInstance.ctx.Err())
 	p2pInstance = NewP2p(testConfig, privateKey, publicKey)
 	assert.Equal(t, p2pInstance.Logger, &util.PlaceholderLogger{})
 	assert.Nil(t, p2pInstance.Receiver)
@@ -112,6 +112,25 @@ func TestSubscription(t *testing.T) {
 	<-subCtx.Done()
 }
 
+func TestFailedSubscription(t *testing.T) {
+	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance.InitHost(p2pInstance.CreateOptions()...)
+	p2pInstance.initPubSub()
+	defer p2pInstance.Close()
+
+	// Joining fails while the topic is already open, and doesn't leave its validator behind
+	topic, err := p2pInstance.ps.Join(string(testChannel.GetId()))
+	assert.NoError(t, err)
+	_, err = p2pInstance.Subscribe(testChannel)
+	assert.Error(t, err)
+	assert.NoError(t, topic.Close())
+
+	subCtx, err := p2pInstance.Subscribe(testChannel)
+	assert.NoError(t, err)
+	p2pInstance.Unsubscribe(testChannel)
+	<-subCtx.Done()
+}
+
 func TestPublish(t *testing.T) {
 	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
 
@@ -124,7 +143,9 @@ func TestPublish(t *testing.T) {
 
 	testWireMessage = &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE, Data: testOrderInBytes}
 	p2pInstance.Send(testWireMessage)
-	wireMessageAsBytes, err := proto.Marshal(testWireMessage)
+
+	// Published messages are stamped with the wire version
+	wireMessageAsBytes, err := proto.Marshal(&pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE, Data: testOrderInBytes, Version: interfaces.WireVersion})
 	assert.NoError(t, err)
 	select {
 	case message := <-p2pInstance.input:
@@ -233,7 +254,7 @@ func TestSyncRequest(t *testing.T) {
 	p2pInstance1 := NewP2p(testConfig, privateKey, publicKey, Logger(log))
 	p2pInstance2 := NewP2p(testConfig, privateKey2, publicKey2, Logger(log))
 
-	testWireMessage = &pb.WireMessage{Operation: pb.Operation_SYNC_REQUEST, ChannelID: []byte(testChannel.GetId()), Data: nil}
+	testWireMessage = &pb.WireMessage{Operation: pb.Operation_SYNC_REQUEST, ChannelID: []byte(testChannel.GetId()), Data: nil, Version: interfaces.WireVersion}
 	wireMessageAsBytes, _ := proto.Marshal(testWireMessage)
 
 	receiver := new(TestReceiver)
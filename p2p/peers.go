@@ -0,0 +1,213 @@
+package p2p
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+)
+
+const protectedPeerTag = "sprawl-protected"
+const minRedialBackoff = time.Second
+const maxRedialBackoff = 5 * time.Minute
+
+func getPeerStorageKey(peerID peer.ID) []byte {
+	return []byte(strings.Join([]string{string(interfaces.PeerPrefix), peerID.String()}, ""))
+}
+
+// ConnectPeer connects directly to a peer, optionally marking it as protected so it's always redialed.
+// A protected peer that can't be connected to right away is redialed in the background.
+func (p2p *P2p) ConnectPeer(addr ma.Multiaddr, protected bool) error {
+	peerInfo, err := peer.AddrInfoFromP2pAddr(addr)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Parse peer address"), err)
+	}
+
+	if protected {
+		err = p2p.protectPeer(peerInfo.ID, addr)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Protect peer"), err)
+		}
+	}
+
+	err = p2p.host.Connect(p2p.ctx, *peerInfo)
+	if !errors.IsEmpty(err) {
+		if protected {
+			go p2p.redial(p2p.ctx, peerInfo.ID)
+		}
+		return errors.E(errors.Op("Connect to peer"), err)
+	}
+
+	p2p.Logger.Infof("Connected to: %s", peerInfo)
+	return nil
+}
+
+// DisconnectPeer closes all connections to a peer and removes it from the protected peers
+func (p2p *P2p) DisconnectPeer(peerID peer.ID) error {
+	err := p2p.unprotectPeer(peerID)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unprotect peer"), err)
+	}
+
+	err = p2p.host.Network().ClosePeer(peerID)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Close peer connections"), err)
+	}
+
+	p2p.Logger.Infof("Disconnected from: %s", peerID)
+	return nil
+}
+
+// GetProtectedPeers returns the addresses of all peers this node always keeps connected to
+func (p2p *P2p) GetProtectedPeers() []ma.Multiaddr {
+	p2p.protectedLock.RLock()
+	defer p2p.protectedLock.RUnlock()
+	addrs := make([]ma.Multiaddr, 0, len(p2p.protectedPeers))
+	for _, addr := range p2p.protectedPeers {
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+func (p2p *P2p) isProtected(peerID peer.ID) bool {
+	p2p.protectedLock.RLock()
+	defer p2p.protectedLock.RUnlock()
+	_, ok := p2p.protectedPeers[peerID]
+	return ok
+}
+
+func (p2p *P2p) protectPeer(peerID peer.ID, addr ma.Multiaddr) error {
+	if p2p.storage != nil {
+		err := p2p.storage.Put(getPeerStorageKey(peerID), addr.Bytes())
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Put protected peer"), err)
+		}
+	}
+
+	p2p.protectedLock.Lock()
+	p2p.protectedPeers[peerID] = addr
+	p2p.protectedLock.Unlock()
+
+	p2p.host.ConnManager().Protect(peerID, protectedPeerTag)
+	return nil
+}
+
+func (p2p *P2p) unprotectPeer(peerID peer.ID) error {
+	if p2p.storage != nil {
+		err := p2p.storage.Delete(getPeerStorageKey(peerID))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Delete protected peer"), err)
+		}
+	}
+
+	p2p.protectedLock.Lock()
+	delete(p2p.protectedPeers, peerID)
+	p2p.protectedLock.Unlock()
+
+	p2p.host.ConnManager().Unprotect(peerID, protectedPeerTag)
+	return nil
+}
+
+// connectToProtectedPeers reads the protected peers from storage and dials each of them
+func (p2p *P2p) connectToProtectedPeers() {
+	if p2p.storage == nil {
+		return
+	}
+
+	peers, err := p2p.storage.GetAllWithPrefix(string(interfaces.PeerPrefix))
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Error(errors.E(errors.Op("Get protected peers"), err))
+		return
+	}
+
+	for _, value := range peers {
+		addr, err := ma.NewMultiaddrBytes([]byte(value))
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Error(errors.E(errors.Op("Parse protected peer address"), err))
+			continue
+		}
+		peerInfo, err := peer.AddrInfoFromP2pAddr(addr)
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Error(errors.E(errors.Op("Parse protected peer address"), err))
+			continue
+		}
+
+		p2p.protectedLock.Lock()
+		p2p.protectedPeers[peerInfo.ID] = addr
+		p2p.protectedLock.Unlock()
+		p2p.host.ConnManager().Protect(peerInfo.ID, protectedPeerTag)
+
+		go p2p.redial(p2p.ctx, peerInfo.ID)
+	}
+}
+
+// watchProtectedPeers redials protected peers whenever their last connection closes, until p2p is closed
+func (p2p *P2p) watchProtectedPeers() {
+	ctx := p2p.ctx
+	p2p.host.Network().Notify(&network.NotifyBundle{
+		DisconnectedF: func(net network.Network, conn network.Conn) {
+			remotePeer := conn.RemotePeer()
+			if p2p.isProtected(remotePeer) && net.Connectedness(remotePeer) != network.Connected {
+				p2p.Logger.Debugf("Lost connection to protected peer %s, redialing", remotePeer)
+				go p2p.redial(ctx, remotePeer)
+			}
+		},
+	})
+}
+
+// redial keeps dialing a protected peer with exponential backoff until it's connected, no longer protected or ctx is done
+func (p2p *P2p) redial(ctx context.Context, peerID peer.ID) {
+	p2p.protectedLock.Lock()
+	if p2p.redialing[peerID] {
+		p2p.protectedLock.Unlock()
+		return
+	}
+	p2p.redialing[peerID] = true
+	p2p.protectedLock.Unlock()
+
+	defer func() {
+		p2p.protectedLock.Lock()
+		delete(p2p.redialing, peerID)
+		p2p.protectedLock.Unlock()
+	}()
+
+	backoff := minRedialBackoff
+	for {
+		p2p.protectedLock.RLock()
+		addr, ok := p2p.protectedPeers[peerID]
+		p2p.protectedLock.RUnlock()
+		if !ok || p2p.host.Network().Connectedness(peerID) == network.Connected {
+			return
+		}
+
+		peerInfo, err := peer.AddrInfoFromP2pAddr(addr)
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Error(errors.E(errors.Op("Parse protected peer address"), err))
+			return
+		}
+
+		err = p2p.host.Connect(ctx, *peerInfo)
+		if errors.IsEmpty(err) {
+			p2p.Logger.Infof("Reconnected to protected peer: %s", peerID)
+			return
+		}
+
+		p2p.Logger.Debugf("Redialing protected peer %s failed, retrying in %s: %s", peerID, backoff, err)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		backoff *= 2
+		if backoff > maxRedialBackoff {
+			backoff = maxRedialBackoff
+		}
+	}
+}
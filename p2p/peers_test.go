@@ -0,0 +1,101 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/stretchr/testify/assert"
+)
+
+func getP2pAddr(t *testing.T, p2pInstance *P2p) ma.Multiaddr {
+	addr, err := ma.NewMultiaddr(fmt.Sprintf("%s/p2p/%s", p2pInstance.GetAddrs()[0], p2pInstance.GetHostIDString()))
+	assert.NoError(t, err)
+	return addr
+}
+
+func TestProtectedPeers(t *testing.T) {
+	storage := &inmemory.Storage{Db: make(map[string]string)}
+	p2pInstance1 := NewP2p(testConfig, privateKey, publicKey, Logger(log), Storage(storage))
+	p2pInstance2 := NewP2p(testConfig, privateKey2, publicKey2, Logger(log))
+
+	p2pInstance1.InitHost(p2pInstance1.CreateOptions()...)
+	p2pInstance2.InitHost(p2pInstance2.CreateOptions()...)
+	p2pInstance1.watchProtectedPeers()
+	defer p2pInstance1.Close()
+	defer p2pInstance2.Close()
+
+	addr := getP2pAddr(t, p2pInstance2)
+	err := p2pInstance1.ConnectPeer(addr, true)
+	assert.NoError(t, err)
+	assert.Equal(t, network.Connected, p2pInstance1.host.Network().Connectedness(p2pInstance2.GetHostID()))
+	assert.Equal(t, []ma.Multiaddr{addr}, p2pInstance1.GetProtectedPeers())
+
+	storedPeers, err := storage.GetAllWithPrefix(string(interfaces.PeerPrefix))
+	assert.NoError(t, err)
+	assert.Len(t, storedPeers, 1)
+
+	// Closing the connection from the other end should trigger a redial
+	p2pInstance2.host.Network().ClosePeer(p2pInstance1.GetHostID())
+	assert.Eventually(t, func() bool {
+		return p2pInstance1.host.Network().Connectedness(p2pInstance2.GetHostID()) == network.Connected
+	}, 5*time.Second, 100*time.Millisecond)
+
+	err = p2pInstance1.DisconnectPeer(p2pInstance2.GetHostID())
+	assert.NoError(t, err)
+	assert.Empty(t, p2pInstance1.GetProtectedPeers())
+
+	storedPeers, err = storage.GetAllWithPrefix(string(interfaces.PeerPrefix))
+	assert.NoError(t, err)
+	assert.Empty(t, storedPeers)
+}
+
+func TestRedialFailedProtectedPeer(t *testing.T) {
+	p2pInstance1 := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance2 := NewP2p(testConfig, privateKey2, publicKey2, Logger(log))
+	p2pInstance1.InitHost(p2pInstance1.CreateOptions()...)
+	p2pInstance2.InitHost(p2pInstance2.CreateOptions()...)
+	defer p2pInstance2.Close()
+
+	// A port nothing listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	listener.Close()
+	addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d/p2p/%s", listener.Addr().(*net.TCPAddr).Port, p2pInstance2.GetHostIDString()))
+	assert.NoError(t, err)
+
+	// A protected peer that can't be reached yet is kept and redialed
+	err = p2pInstance1.ConnectPeer(addr, true)
+	assert.Error(t, err)
+	assert.Equal(t, []ma.Multiaddr{addr}, p2pInstance1.GetProtectedPeers())
+	assert.Eventually(t, func() bool {
+		p2pInstance1.protectedLock.RLock()
+		defer p2pInstance1.protectedLock.RUnlock()
+		return p2pInstance1.redialing[p2pInstance2.GetHostID()]
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Closing stops redialing without waiting for the backoff
+	p2pInstance1.Close()
+	assert.Eventually(t, func() bool {
+		p2pInstance1.protectedLock.RLock()
+		defer p2pInstance1.protectedLock.RUnlock()
+		return len(p2pInstance1.redialing) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestConnectPeerInvalidAddress(t *testing.T) {
+	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance.InitHost(p2pInstance.CreateOptions()...)
+	defer p2pInstance.Close()
+
+	addr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/4001")
+	assert.NoError(t, err)
+	err = p2pInstance.ConnectPeer(addr, true)
+	assert.Error(t, err)
+	assert.Empty(t, p2pInstance.GetProtectedPeers())
+}
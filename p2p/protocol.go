@@ -0,0 +1,194 @@
+package p2p
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// protocolVersion is the semantic version of the Sprawl protocols this node speaks.
+// Peers are compatible as long as their major version is the same as ours.
+const protocolVersion = "1.0.0"
+
+const syncProtocolBase = "/sprawl/sync/"
+const handshakeProtocolBase = "/sprawl/handshake/"
+const handshakeTimeout = 10 * time.Second
+
+// syncProtocol carries the WireMessages peers send to each other directly
+const syncProtocol = protocol.ID(syncProtocolBase + protocolVersion)
+const handshakeProtocol = protocol.ID(handshakeProtocolBase + protocolVersion)
+
+// reconcileCapability is announced by peers that sync channels with set reconciliation, only they are sent sync requests
+const reconcileCapability = "reconcile"
+
+// capabilities are the optional features this node supports, announced to peers in the handshake
+var capabilities = []string{reconcileCapability}
+
+func majorVersion(version string) string {
+	return strings.SplitN(version, ".", 2)[0]
+}
+
+// compatibleVersion reports whether a peer speaking version can talk to this node
+func compatibleVersion(version string) bool {
+	return version != "" && majorVersion(version) == majorVersion(protocolVersion)
+}
+
+// protocolMatcher accepts every version of a protocol compatible with ours, so peers on other minor versions can still reach us
+func protocolMatcher(base string) func(string) bool {
+	return func(id string) bool {
+		return strings.HasPrefix(id, base) && compatibleVersion(strings.TrimPrefix(id, base))
+	}
+}
+
+// setProtocolHandlers registers the stream handlers of all Sprawl protocols with the host,
+// including the unversioned ID older nodes send their unframed messages on
+func (p2p *P2p) setProtocolHandlers() {
+	p2p.host.SetStreamHandlerMatch(syncProtocol, protocolMatcher(syncProtocolBase), p2p.handleStream)
+	p2p.host.SetStreamHandler(networkID, p2p.handleLegacyStream)
+	p2p.host.SetStreamHandlerMatch(handshakeProtocol, protocolMatcher(handshakeProtocolBase), p2p.handleHandshakeStream)
+
+	p2p.host.Network().Notify(&network.NotifyBundle{
+		ConnectedF: func(n network.Network, conn network.Conn) {
+			// The dialing side starts the handshake
+			if conn.Stat().Direction == network.DirOutbound {
+				go p2p.handshake(conn.RemotePeer())
+			}
+		},
+		DisconnectedF: func(n network.Network, conn network.Conn) {
+			if n.Connectedness(conn.RemotePeer()) != network.Connected {
+				p2p.handshakeLock.Lock()
+				delete(p2p.handshakes, conn.RemotePeer())
+				p2p.handshakeLock.Unlock()
+			}
+		},
+	})
+}
+
+func (p2p *P2p) getHandshake() *pb.Handshake {
+	return &pb.Handshake{
+		Version:      protocolVersion,
+		Protocols:    []string{string(syncProtocol), string(handshakeProtocol)},
+		Capabilities: capabilities,
+	}
+}
+
+// handshake exchanges protocol versions and capabilities with a newly connected peer
+func (p2p *P2p) handshake(peerID peer.ID) error {
+	ctx, cancel := context.WithTimeout(p2p.ctx, handshakeTimeout)
+	defer cancel()
+
+	buf, err := p2p.host.NewStream(ctx, peerID, handshakeProtocol)
+	if !errors.IsEmpty(err) {
+		// Nodes from before versioning don't know the handshake, but still speak the unversioned protocol
+		p2p.Logger.Debugf("No handshake with %s, treating it as a legacy peer: %s", peerID, err)
+		return nil
+	}
+	defer buf.Close()
+	buf.SetDeadline(time.Now().Add(handshakeTimeout))
+
+	stream := newStream(buf)
+	err = writeHandshake(stream, p2p.getHandshake())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Send handshake"), err)
+	}
+	remote, err := readHandshake(stream)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Receive handshake"), err)
+	}
+	return p2p.acceptHandshake(peerID, remote)
+}
+
+func (p2p *P2p) handleHandshakeStream(buf network.Stream) {
+	defer buf.Close()
+	buf.SetDeadline(time.Now().Add(handshakeTimeout))
+
+	peerID := buf.Conn().RemotePeer()
+	stream := newStream(buf)
+	remote, err := readHandshake(stream)
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Debug(errors.E(errors.Op("Receive handshake from "+peerID.String()), err))
+		return
+	}
+	// Reply even to incompatible peers so they can log why they were rejected
+	err = writeHandshake(stream, p2p.getHandshake())
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Debug(errors.E(errors.Op("Send handshake to "+peerID.String()), err))
+	}
+	p2p.acceptHandshake(peerID, remote)
+}
+
+// acceptHandshake stores the versions and capabilities of a compatible peer, and disconnects an incompatible one
+func (p2p *P2p) acceptHandshake(peerID peer.ID, remote *pb.Handshake) error {
+	if !compatibleVersion(remote.GetVersion()) {
+		p2p.Logger.Warnf("Rejecting peer %s: it speaks Sprawl protocol %q, which is incompatible with our version %s", peerID, remote.GetVersion(), protocolVersion)
+		p2p.host.Network().ClosePeer(peerID)
+		return errors.E(errors.Op("Check protocol version"), "incompatible protocol version "+remote.GetVersion())
+	}
+
+	p2p.handshakeLock.Lock()
+	p2p.handshakes[peerID] = remote
+	p2p.handshakeLock.Unlock()
+	p2p.Logger.Debugf("Handshake with %s done, protocol version %s, capabilities %v", peerID, remote.GetVersion(), remote.GetCapabilities())
+	return nil
+}
+
+func writeHandshake(stream *Stream, handshake *pb.Handshake) error {
+	data, err := proto.Marshal(handshake)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal handshake"), err)
+	}
+	return stream.WriteToStream(data)
+}
+
+func readHandshake(stream *Stream) (*pb.Handshake, error) {
+	frame, err := stream.readFrame()
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Read handshake"), err)
+	}
+	handshake := &pb.Handshake{}
+	err = proto.Unmarshal(frame.GetData(), handshake)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal handshake"), err)
+	}
+	return handshake, nil
+}
+
+// GetPeerHandshake returns the protocol version and capabilities a peer announced, if it has done the handshake
+func (p2p *P2p) GetPeerHandshake(peerID peer.ID) (*pb.Handshake, bool) {
+	p2p.handshakeLock.RLock()
+	defer p2p.handshakeLock.RUnlock()
+	handshake, ok := p2p.handshakes[peerID]
+	return handshake, ok
+}
+
+// peerSupports tells if a peer announced capability in its handshake, doing the handshake first if it hasn't been done yet
+func (p2p *P2p) peerSupports(peerID peer.ID, capability string) bool {
+	handshake, ok := p2p.GetPeerHandshake(peerID)
+	if !ok {
+		err := p2p.handshake(peerID)
+		if !errors.IsEmpty(err) {
+			return false
+		}
+		if handshake, ok = p2p.GetPeerHandshake(peerID); !ok {
+			return false
+		}
+	}
+	for _, supported := range handshake.GetCapabilities() {
+		if supported == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCapabilities returns the optional features this node supports
+func (p2p *P2p) GetCapabilities() []string {
+	return capabilities
+}
@@ -0,0 +1,61 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProtocolMatcher(t *testing.T) {
+	assert.True(t, compatibleVersion(protocolVersion))
+	assert.True(t, compatibleVersion("1.4.2"))
+	assert.False(t, compatibleVersion("2.0.0"))
+	assert.False(t, compatibleVersion(""))
+
+	matcher := protocolMatcher(syncProtocolBase)
+	assert.True(t, matcher(string(syncProtocol)))
+	assert.True(t, matcher(syncProtocolBase+"1.1.0"))
+	assert.False(t, matcher(syncProtocolBase+"2.0.0"))
+	assert.False(t, matcher(string(handshakeProtocol)))
+}
+
+func TestHandshake(t *testing.T) {
+	p2pInstance1 := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance2 := NewP2p(testConfig, privateKey2, publicKey2, Logger(log))
+	p2pInstance1.InitHost(p2pInstance1.CreateOptions()...)
+	p2pInstance2.InitHost(p2pInstance2.CreateOptions()...)
+	defer p2pInstance1.host.Close()
+	defer p2pInstance2.host.Close()
+
+	err := p2pInstance1.host.Connect(p2pInstance1.ctx, p2pInstance2.GetAddrInfo())
+	assert.NoError(t, err)
+
+	// Both ends learn the other's version and capabilities
+	assert.Eventually(t, func() bool {
+		_, ok1 := p2pInstance1.GetPeerHandshake(p2pInstance2.GetHostID())
+		_, ok2 := p2pInstance2.GetPeerHandshake(p2pInstance1.GetHostID())
+		return ok1 && ok2
+	}, 5*time.Second, 10*time.Millisecond)
+	handshake, _ := p2pInstance1.GetPeerHandshake(p2pInstance2.GetHostID())
+	assert.Equal(t, protocolVersion, handshake.GetVersion())
+	assert.Equal(t, capabilities, handshake.GetCapabilities())
+	assert.Equal(t, string(syncProtocol), p2pInstance1.GetProtocolVersion())
+
+	assert.False(t, p2pInstance1.IsLegacyPeer(p2pInstance2.GetHostID()))
+
+	// Only peers that announced set reconciliation are synced with
+	assert.True(t, p2pInstance1.peerSupports(p2pInstance2.GetHostID(), reconcileCapability))
+	assert.NoError(t, p2pInstance1.acceptHandshake(p2pInstance2.GetHostID(), &pb.Handshake{Version: protocolVersion}))
+	assert.False(t, p2pInstance1.peerSupports(p2pInstance2.GetHostID(), reconcileCapability))
+	assert.False(t, p2pInstance1.syncWithPeer(string(testChannel.GetId()), p2pInstance2.GetHostID()))
+
+	// Peers with another major version are disconnected
+	err = p2pInstance1.acceptHandshake(p2pInstance2.GetHostID(), &pb.Handshake{Version: "2.0.0"})
+	assert.Error(t, err)
+	assert.Eventually(t, func() bool {
+		return p2pInstance1.host.Network().Connectedness(p2pInstance2.GetHostID()) != network.Connected
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,107 @@
+package p2p
+
+import (
+	"context"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	discovery "github.com/libp2p/go-libp2p-discovery"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+const channelRendezvousInterval = time.Minute
+const channelPeerLimit = 50
+const channelPeerTagValue = 20
+const channelConnectTimeout = 10 * time.Second
+
+// channelRendezvous returns the rendezvous point where the peers of a single channel find each other
+func channelRendezvous(channelID []byte) string {
+	return networkID + "channel/" + string(channelID)
+}
+
+// channelPeerTag is the connection manager tag given to peers that share a channel with us
+func channelPeerTag(channelID []byte) string {
+	return "sprawl-channel-" + string(channelID)
+}
+
+// discoverChannelPeers advertises this node on the channel's rendezvous point and keeps connecting to the
+// other peers found there until ctx is cancelled when the channel is left, or the node is closed
+func (p2p *P2p) discoverChannelPeers(ctx context.Context, channel *pb.Channel) {
+	if p2p.routingDiscovery == nil {
+		p2p.Logger.Debugf("Routing discovery not started, not looking for peers of channel %s", channel.GetId())
+		return
+	}
+
+	nodeCtx := p2p.ctx
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-nodeCtx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	rendezvous := channelRendezvous(channel.GetId())
+	tag := channelPeerTag(channel.GetId())
+	discovery.Advertise(ctx, p2p.routingDiscovery, rendezvous)
+
+	p2p.background.Add(1)
+	go func() {
+		defer p2p.background.Done()
+		defer cancel()
+		ticker := time.NewTicker(channelRendezvousInterval)
+		defer ticker.Stop()
+		for {
+			p2p.findChannelPeers(ctx, channel, rendezvous, tag)
+			select {
+			case <-ctx.Done():
+				for _, peerID := range p2p.GetAllPeers() {
+					p2p.host.ConnManager().UntagPeer(peerID, tag)
+				}
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (p2p *P2p) findChannelPeers(ctx context.Context, channel *pb.Channel, rendezvous string, tag string) {
+	findCtx, cancel := context.WithTimeout(ctx, channelConnectTimeout)
+	peers, err := discovery.FindPeers(findCtx, p2p.routingDiscovery, rendezvous, discovery.Limit(channelPeerLimit))
+	cancel()
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Debug(errors.E(errors.Op("Find peers of channel "+string(channel.GetId())), err))
+	}
+
+	for _, addrInfo := range peers {
+		if addrInfo.ID == p2p.host.ID() {
+			continue
+		}
+		err = p2p.connectChannelPeer(ctx, addrInfo, tag)
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Debug(errors.E(errors.Op("Connect to peer of channel "+string(channel.GetId())), err))
+		}
+	}
+
+	// Peers we already share the topic mesh with are just as valuable
+	if p2p.ps != nil {
+		for _, peerID := range p2p.ps.ListPeers(string(channel.GetId())) {
+			p2p.host.ConnManager().TagPeer(peerID, tag, channelPeerTagValue)
+		}
+	}
+}
+
+// connectChannelPeer connects to a peer sharing one of our channels and tags the connection,
+// so the connection manager prefers keeping it over connections to other peers
+func (p2p *P2p) connectChannelPeer(ctx context.Context, addrInfo peer.AddrInfo, tag string) error {
+	connectCtx, cancel := context.WithTimeout(ctx, channelConnectTimeout)
+	defer cancel()
+	err := p2p.host.Connect(connectCtx, addrInfo)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Connect"), err)
+	}
+	p2p.host.ConnManager().TagPeer(addrInfo.ID, tag, channelPeerTagValue)
+	return nil
+}
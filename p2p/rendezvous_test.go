@@ -0,0 +1,62 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	discovery "github.com/libp2p/go-libp2p-discovery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelRendezvous(t *testing.T) {
+	assert.Equal(t, networkID+"channel/testChannel", channelRendezvous(testChannel.GetId()))
+	assert.NotEqual(t, channelRendezvous(testChannel.GetId()), channelRendezvous([]byte("otherChannel")))
+	assert.NotEqual(t, networkID, channelRendezvous(testChannel.GetId()))
+}
+
+func TestConnectChannelPeer(t *testing.T) {
+	p2pInstance1 := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance2 := NewP2p(testConfig, privateKey2, publicKey2, Logger(log))
+
+	p2pInstance1.InitHost(p2pInstance1.CreateOptions()...)
+	p2pInstance2.InitHost(p2pInstance2.CreateOptions()...)
+	defer p2pInstance1.Close()
+	defer p2pInstance2.Close()
+
+	addrInfo := peer.AddrInfo{ID: p2pInstance2.GetHostID(), Addrs: p2pInstance2.GetAddrs()}
+	err := p2pInstance1.connectChannelPeer(context.Background(), addrInfo, channelPeerTag(testChannel.GetId()))
+	assert.NoError(t, err)
+	assert.Equal(t, network.Connected, p2pInstance1.host.Network().Connectedness(p2pInstance2.GetHostID()))
+
+	// Without routing discovery, subscribing still works but doesn't look for channel peers
+	p2pInstance1.initPubSub()
+	subCtx, err := p2pInstance1.Subscribe(testChannel)
+	assert.NoError(t, err)
+	p2pInstance1.Unsubscribe(testChannel)
+	<-subCtx.Done()
+}
+
+func TestCloseStopsChannelDiscovery(t *testing.T) {
+	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance.InitHost(p2pInstance.CreateOptions()...)
+	p2pInstance.initPubSub()
+	p2pInstance.routingDiscovery = discovery.NewRoutingDiscovery(p2pInstance.kademliaDHT)
+
+	_, err := p2pInstance.Subscribe(testChannel)
+	assert.NoError(t, err)
+
+	// Closing waits for the channel's discovery to stop, without the channel being left
+	closed := make(chan struct{})
+	go func() {
+		p2pInstance.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close didn't stop the discovery of channel peers")
+	}
+}
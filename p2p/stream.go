@@ -2,74 +2,278 @@ package p2p
 
 import (
 	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/libp2p/go-libp2p-core/network"
 	peer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
 )
 
-// Stream is a single streaming connection between two peers
+// maxMessageSize is the largest frame, in bytes, that is written to or read from a stream
+const maxMessageSize = 4 << 20
+
+// requestTimeout is how long Request waits for the other peer to reply
+const requestTimeout = 30 * time.Second
+
+// Stream is a single streaming connection between two peers.
+// Messages are sent as StreamFrames prefixed with their varint encoded length,
+// so any number of messages and requests can be exchanged over a single stream.
 type Stream struct {
 	stream     network.Stream
 	remotePeer peer.ID
 	input      *bufio.Writer
 	output     *bufio.Reader
+	writeLock  sync.Mutex
+	lastID     uint64
+	pending    map[uint64]chan []byte
+	pendLock   sync.Mutex
+	closed     chan struct{}
+}
+
+func newStream(stream network.Stream) *Stream {
+	return &Stream{
+		stream:     stream,
+		remotePeer: stream.Conn().RemotePeer(),
+		input:      bufio.NewWriter(stream),
+		output:     bufio.NewReader(stream),
+		pending:    make(map[uint64]chan []byte),
+		closed:     make(chan struct{}),
+	}
 }
 
 func (p2p *P2p) handleStream(buf network.Stream) {
 	p2p.Logger.Debugf("New stream opened with %s", buf.Conn().RemotePeer())
-	reader := bufio.NewReader(bufio.NewReader(buf))
-	remotePeer := buf.Conn().RemotePeer()
-	stream := &Stream{stream: buf, output: reader, remotePeer: remotePeer}
+	stream := newStream(buf)
 	go func() {
-		stream.receiveStream(reader, p2p.Receiver)
+		err := stream.receiveStream(p2p.Receiver, p2p.Logger)
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Debug(errors.E(errors.Op("Receive stream from "+stream.remotePeer.String()), err))
+		}
 		stream.stream.Close()
 	}()
 }
 
-func (stream *Stream) receiveStream(reader *bufio.Reader, receiver interfaces.Receiver) error {
-	data := []byte{}
+// receiveStream reads frames until the stream is closed, passing replies on to the requests waiting for them
+// and everything else to the receiver. A message the receiver rejects doesn't close the stream.
+func (stream *Stream) receiveStream(receiver interfaces.Receiver, logger interfaces.Logger) error {
+	defer stream.markClosed()
 	for {
-		line, _ := reader.ReadByte()
-		data = append(data, line)
-		if reader.Buffered() == 0 {
-			err := receiver.Receive(data, stream.remotePeer)
-			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Passing data from stream to receiver"), err)
-			}
+		frame, err := stream.readFrame()
+		if err == io.EOF {
 			return nil
 		}
+		if !errors.IsEmpty(err) {
+			stream.stream.Reset()
+			return errors.E(errors.Op("Read frame"), err)
+		}
+
+		if frame.GetReplyTo() != 0 {
+			stream.deliverReply(frame)
+			continue
+		}
+
+		err = stream.receiveFrame(frame, receiver)
+		if !errors.IsEmpty(err) {
+			logger.Error(errors.E(errors.Op("Passing data from stream to receiver"), err))
+		}
 	}
 }
 
-// WriteToStream writes data as bytes to specified stream
-func (stream *Stream) WriteToStream(data []byte) error {
-	_, err := stream.input.Write(data)
-	if err != nil {
+func (stream *Stream) receiveFrame(frame *pb.StreamFrame, receiver interfaces.Receiver) error {
+	if receiver == nil {
+		return errors.E(errors.Op("Check receiver"), "no receiver registered for stream data")
+	}
+	if !frame.GetRequest() {
+		return receiver.Receive(frame.GetData(), stream.remotePeer)
+	}
+
+	// Receivers that don't answer requests still get the data, and the requester an empty reply
+	var reply []byte
+	var err error
+	if requestReceiver, ok := receiver.(interfaces.RequestReceiver); ok {
+		reply, err = requestReceiver.ReceiveRequest(frame.GetData(), stream.remotePeer)
+	} else {
+		err = receiver.Receive(frame.GetData(), stream.remotePeer)
+	}
+	if !errors.IsEmpty(err) {
+		// Don't let the requester hang until its timeout
+		stream.writeFrame(&pb.StreamFrame{ReplyTo: frame.GetId()})
+		return err
+	}
+	return stream.writeFrame(&pb.StreamFrame{ReplyTo: frame.GetId(), Data: reply})
+}
+
+func (stream *Stream) readFrame() (*pb.StreamFrame, error) {
+	length, err := binary.ReadUvarint(stream.output)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	if length > maxMessageSize {
+		return nil, errors.E(errors.Op("Check frame length"), fmt.Sprintf("frame of %d bytes exceeds the maximum of %d", length, maxMessageSize))
+	}
+
+	data := make([]byte, length)
+	_, err = io.ReadFull(stream.output, data)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Read frame data"), err)
+	}
+
+	frame := &pb.StreamFrame{}
+	err = proto.Unmarshal(data, frame)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal frame"), err)
+	}
+	return frame, nil
+}
+
+func (stream *Stream) writeFrame(frame *pb.StreamFrame) error {
+	if frame.GetId() == 0 {
+		frame.Id = atomic.AddUint64(&stream.lastID, 1)
+	}
+	data, err := proto.Marshal(frame)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal frame"), err)
+	}
+	if len(data) > maxMessageSize {
+		return errors.E(errors.Op("Check frame length"), fmt.Sprintf("frame of %d bytes exceeds the maximum of %d", len(data), maxMessageSize))
+	}
+
+	length := make([]byte, binary.MaxVarintLen64)
+	length = length[:binary.PutUvarint(length, uint64(len(data)))]
+
+	stream.writeLock.Lock()
+	defer stream.writeLock.Unlock()
+	_, err = stream.input.Write(append(length, data...))
+	if !errors.IsEmpty(err) {
 		return errors.E(errors.Op("Write to stream"), err)
 	}
 	err = stream.input.Flush()
-	return errors.E(errors.Op("Flush the stream"), err)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Flush the stream"), err)
+	}
+	return nil
 }
 
-// OpenStream opens a stream with another Sprawl peer
+func (stream *Stream) deliverReply(frame *pb.StreamFrame) {
+	stream.pendLock.Lock()
+	reply, ok := stream.pending[frame.GetReplyTo()]
+	delete(stream.pending, frame.GetReplyTo())
+	stream.pendLock.Unlock()
+	if ok {
+		reply <- frame.GetData()
+	}
+}
+
+func (stream *Stream) markClosed() {
+	stream.pendLock.Lock()
+	defer stream.pendLock.Unlock()
+	select {
+	case <-stream.closed:
+	default:
+		close(stream.closed)
+	}
+}
+
+func (stream *Stream) isClosed() bool {
+	select {
+	case <-stream.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// WriteToStream writes data as a single message to the stream
+func (stream *Stream) WriteToStream(data []byte) error {
+	return stream.writeFrame(&pb.StreamFrame{Data: data})
+}
+
+// Request writes data as a request to the stream and waits for the other peer to reply to it
+func (stream *Stream) Request(data []byte) ([]byte, error) {
+	id := atomic.AddUint64(&stream.lastID, 1)
+	reply := make(chan []byte, 1)
+	stream.pendLock.Lock()
+	stream.pending[id] = reply
+	stream.pendLock.Unlock()
+	defer func() {
+		stream.pendLock.Lock()
+		delete(stream.pending, id)
+		stream.pendLock.Unlock()
+	}()
+
+	err := stream.writeFrame(&pb.StreamFrame{Id: id, Request: true, Data: data})
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Write request"), err)
+	}
+
+	select {
+	case data := <-reply:
+		return data, nil
+	case <-stream.closed:
+		return nil, errors.E(errors.Op("Wait for reply"), "stream closed before the reply arrived")
+	case <-time.After(requestTimeout):
+		return nil, errors.E(errors.Op("Wait for reply"), "request timed out")
+	}
+}
+
+// OpenStream returns a stream with another Sprawl peer, reusing the stream opened earlier if it's still open.
+// Peers from before versioning only speak the unversioned protocol without framing, so they get a legacy stream.
 func (p2p *P2p) OpenStream(peerID peer.ID) (interfaces.Stream, error) {
-	stream, err := p2p.host.NewStream(p2p.ctx, peerID, networkID)
-	var newStream *Stream
+	if p2p.IsLegacyPeer(peerID) {
+		return &legacyStream{p2p: p2p, peerID: peerID}, nil
+	}
+
+	p2p.streamLock.Lock()
+	defer p2p.streamLock.Unlock()
+
+	if existing, ok := p2p.streams[peerID.String()]; ok {
+		if !existing.isClosed() {
+			return existing, nil
+		}
+		delete(p2p.streams, peerID.String())
+	}
+
+	stream, err := p2p.host.NewStream(p2p.ctx, peerID, syncProtocol)
 	if err != nil {
-		p2p.Logger.Errorf("Stream open failed with peer %s on network %s: %s", peerID, networkID, err)
-	} else {
-		writer := bufio.NewWriter(bufio.NewWriter(stream))
-		newStream = &Stream{stream: stream, input: writer, remotePeer: peerID}
-		p2p.streams[peerID.String()] = newStream
+		p2p.Logger.Errorf("Stream open failed with peer %s on protocol %s: %s", peerID, syncProtocol, err)
+		return nil, err
 	}
-	return newStream, err
+
+	newStream := newStream(stream)
+	p2p.streams[peerID.String()] = newStream
+
+	// Replies and messages the other peer sends back arrive on the same stream
+	go func() {
+		err := newStream.receiveStream(p2p.Receiver, p2p.Logger)
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Debug(errors.E(errors.Op("Receive stream from "+peerID.String()), err))
+		}
+		p2p.streamLock.Lock()
+		if p2p.streams[peerID.String()] == newStream {
+			delete(p2p.streams, peerID.String())
+		}
+		p2p.streamLock.Unlock()
+	}()
+
+	return newStream, nil
 }
 
 // CloseStream removes and closes a stream
 func (p2p *P2p) CloseStream(peerID peer.ID) error {
-	err := p2p.streams[peerID.String()].stream.Close()
+	p2p.streamLock.Lock()
+	stream, ok := p2p.streams[peerID.String()]
 	delete(p2p.streams, peerID.String())
-	return err
+	p2p.streamLock.Unlock()
+	if !ok {
+		return nil
+	}
+	return stream.stream.Close()
 }
@@ -0,0 +1,92 @@
+package p2p
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+type collectingReceiver struct {
+	lock     sync.Mutex
+	received [][]byte
+}
+
+func (r *collectingReceiver) Receive(data []byte, from peer.ID) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.received = append(r.received, data)
+	return nil
+}
+
+func (r *collectingReceiver) ReceiveRequest(data []byte, from peer.ID) ([]byte, error) {
+	return append([]byte("reply to "), data...), nil
+}
+
+func (r *collectingReceiver) getReceived() [][]byte {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.received
+}
+
+func newConnectedInstances(t *testing.T, receiver *collectingReceiver) (*P2p, *P2p) {
+	p2pInstance1 := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance2 := NewP2p(testConfig, privateKey2, publicKey2, Logger(log), Receiver(receiver))
+	p2pInstance1.InitHost(p2pInstance1.CreateOptions()...)
+	p2pInstance2.InitHost(p2pInstance2.CreateOptions()...)
+
+	err := p2pInstance1.host.Connect(p2pInstance1.ctx, p2pInstance2.GetAddrInfo())
+	assert.NoError(t, err)
+	return p2pInstance1, p2pInstance2
+}
+
+func TestStreamFraming(t *testing.T) {
+	receiver := &collectingReceiver{}
+	p2pInstance1, p2pInstance2 := newConnectedInstances(t, receiver)
+	defer p2pInstance1.host.Close()
+	defer p2pInstance2.host.Close()
+
+	// A message larger than any single TCP segment arrives whole
+	large := bytes.Repeat([]byte("sprawl"), 200000)
+	messages := [][]byte{[]byte("first"), large, []byte("last")}
+
+	stream, err := p2pInstance1.OpenStream(p2pInstance2.GetHostID())
+	assert.NoError(t, err)
+	for _, message := range messages {
+		assert.NoError(t, stream.WriteToStream(message))
+	}
+
+	// The same stream is reused for later exchanges
+	reused, err := p2pInstance1.OpenStream(p2pInstance2.GetHostID())
+	assert.NoError(t, err)
+	assert.Equal(t, stream, reused)
+
+	reply, err := reused.Request([]byte("ping"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("reply to ping"), reply)
+
+	assert.Eventually(t, func() bool { return len(receiver.getReceived()) == len(messages) }, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, messages, receiver.getReceived())
+
+	assert.NoError(t, p2pInstance1.CloseStream(p2pInstance2.GetHostID()))
+	assert.NoError(t, p2pInstance1.CloseStream(p2pInstance2.GetHostID()))
+}
+
+func TestStreamMaxMessageSize(t *testing.T) {
+	receiver := &collectingReceiver{}
+	p2pInstance1, p2pInstance2 := newConnectedInstances(t, receiver)
+	defer p2pInstance1.host.Close()
+	defer p2pInstance2.host.Close()
+
+	stream, err := p2pInstance1.OpenStream(p2pInstance2.GetHostID())
+	assert.NoError(t, err)
+	err = stream.WriteToStream(make([]byte, maxMessageSize+1))
+	assert.Error(t, err)
+
+	// The stream is still usable after refusing to send the oversized message
+	assert.NoError(t, stream.WriteToStream([]byte("small")))
+	assert.Eventually(t, func() bool { return len(receiver.getReceived()) == 1 }, 2*time.Second, 10*time.Millisecond)
+}
@@ -2,39 +2,182 @@ package p2p
 
 import (
 	"context"
+	"fmt"
+	"math/rand"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
 	peer "github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
 	"github.com/sprawl/sprawl/pb"
 )
 
+// getSyncSettings returns how often channels are synced periodically, 0 if never, and with how many peers at a time
+func (p2p *P2p) getSyncSettings() (time.Duration, int) {
+	if p2p.Config == nil {
+		return 0, 1
+	}
+	peers := int(p2p.Config.GetSyncPeers())
+	if peers < 1 {
+		peers = 1
+	}
+	return time.Duration(p2p.Config.GetSyncInterval()) * time.Second, peers
+}
+
+// joinSyncPollInterval is how often the peers of a joined channel are checked for the first ones to sync with
+const joinSyncPollInterval = time.Second
+
+// joinSyncTimeout is how long the first peers of a joined channel are looked for, after which anti-entropy takes over
+const joinSyncTimeout = time.Minute
+
+// joinSyncAttempts is how many times a peer is asked to sync after joining before it's given up on
+const joinSyncAttempts = 3
+
+// requestSync syncs the channel with the first peers that show up on it after joining,
+// and then keeps repairing differences with random channel peers until the channel is left
 func (p2p *P2p) requestSync(ctx context.Context, topicString string, topic *pubsub.Topic) {
-	eventHandler, err := topic.EventHandler()
-	if !errors.IsEmpty(err) {
-		p2p.Logger.Error(errors.E(errors.Op("Return topic's event handler"), err))
+	interval, peers := p2p.getSyncSettings()
+	p2p.syncLock.Lock()
+	p2p.syncStatus[topicString] = &pb.SyncStatus{ChannelID: []byte(topicString)}
+	p2p.syncLock.Unlock()
+
+	go func() {
+		joinCtx, cancel := context.WithTimeout(ctx, joinSyncTimeout)
+		defer cancel()
+		p2p.syncOnJoin(joinCtx, topicString, topic, peers)
+	}()
+
+	if interval > 0 {
+		go p2p.antiEntropy(ctx, topicString, topic, interval, peers)
 	}
+}
 
-	//Add alternative if this fail
-	go func(ctx context.Context) {
-		for {
-			peerEvent, err := eventHandler.NextPeerEvent(ctx)
-			if !errors.IsEmpty(err) {
-				p2p.Logger.Error(errors.E(errors.Op("Get next peer event"), err))
-				p2p.Logger.Warn("Sync stopped due to error")
-				break
-			}
-			if peerEvent.Type == 0 && peerEvent.Peer.String() != p2p.host.ID().String() {
-				err = p2p.sendSyncRequest(peerEvent.Peer, topicString)
-				if !errors.IsEmpty(err) {
-					p2p.Logger.Error(errors.E(errors.Op("Request sync"), err))
+// syncOnJoin syncs the channel with the first peers found on it until ctx is done, trying each peer a few times.
+// The topic's peers are polled instead of read from a topic event handler, since cancelling one races with pubsub's event loop.
+func (p2p *P2p) syncOnJoin(ctx context.Context, topicString string, topic *pubsub.Topic, peers int) {
+	ticker := time.NewTicker(joinSyncPollInterval)
+	defer ticker.Stop()
+	attempts := make(map[peer.ID]int)
+	for synced := 0; synced < peers; {
+		for _, peerID := range topic.ListPeers() {
+			if synced < peers && attempts[peerID] < joinSyncAttempts && peerID != p2p.host.ID() {
+				if p2p.syncWithPeer(topicString, peerID) {
+					attempts[peerID] = joinSyncAttempts
+					synced++
 				} else {
-					break
+					attempts[peerID]++
 				}
 			}
 		}
-	}(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// antiEntropy periodically syncs the channel with random peers, repairing whatever gossip we missed
+func (p2p *P2p) antiEntropy(ctx context.Context, topicString string, topic *pubsub.Topic, interval time.Duration, peers int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		p2p.setNextRound(topicString, time.Now().Add(interval))
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p2p.syncRound(topicString, topic.ListPeers(), peers)
+		}
+	}
+}
+
+// syncRound sends sync requests to count random peers out of candidates
+func (p2p *P2p) syncRound(topicString string, candidates []peer.ID, count int) {
+	shuffled := make([]peer.ID, len(candidates))
+	copy(shuffled, candidates)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	if len(shuffled) > count {
+		shuffled = shuffled[:count]
+	}
+
+	p2p.updateSyncStatus(topicString, func(status *pb.SyncStatus) {
+		status.Syncing = true
+		status.Rounds++
+	})
+
+	synced := make([]string, 0, len(shuffled))
+	for _, peerID := range shuffled {
+		if p2p.syncWithPeer(topicString, peerID) {
+			synced = append(synced, peerID.String())
+		}
+	}
+	p2p.Logger.Debugf("Anti-entropy sync on channel %s reached %d/%d peers", topicString, len(synced), len(shuffled))
+
+	p2p.updateSyncStatus(topicString, func(status *pb.SyncStatus) {
+		status.Syncing = false
+		status.LastPeers = synced
+		status.LastRound = ptypes.TimestampNow()
+	})
+}
+
+// syncWithPeer sends a sync request to a peer and records whether it was accepted.
+// Peers from before versioning are asked for all of their orders instead, and peers that
+// don't announce set reconciliation in their handshake wouldn't understand the request, so they're skipped.
+func (p2p *P2p) syncWithPeer(topicString string, peerID peer.ID) bool {
+	var err error
+	if p2p.IsLegacyPeer(peerID) {
+		err = p2p.sendLegacySyncRequest(peerID, topicString)
+	} else if p2p.peerSupports(peerID, reconcileCapability) {
+		err = p2p.sendSyncRequest(peerID, topicString)
+	} else {
+		err = errors.E(errors.Op("Check capabilities"), fmt.Sprintf("peer %s doesn't support %s", peerID, reconcileCapability))
+	}
+	p2p.updateSyncStatus(topicString, func(status *pb.SyncStatus) {
+		status.RequestsSent++
+		if !errors.IsEmpty(err) {
+			status.RequestsFailed++
+		}
+	})
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Error(errors.E(errors.Op("Request sync"), err))
+		return false
+	}
+	return true
+}
+
+func (p2p *P2p) setNextRound(topicString string, next time.Time) {
+	timestamp, err := ptypes.TimestampProto(next)
+	if !errors.IsEmpty(err) {
+		return
+	}
+	p2p.updateSyncStatus(topicString, func(status *pb.SyncStatus) {
+		status.NextRound = timestamp
+	})
+}
+
+func (p2p *P2p) updateSyncStatus(topicString string, update func(status *pb.SyncStatus)) {
+	p2p.syncLock.Lock()
+	defer p2p.syncLock.Unlock()
+	if status, ok := p2p.syncStatus[topicString]; ok {
+		update(status)
+	}
+}
+
+// GetSyncStatus returns the progress of syncing a joined channel with its peers
+func (p2p *P2p) GetSyncStatus(channelID []byte) *pb.SyncStatus {
+	p2p.syncLock.RLock()
+	defer p2p.syncLock.RUnlock()
+	status, ok := p2p.syncStatus[string(channelID)]
+	if !ok {
+		return &pb.SyncStatus{ChannelID: channelID}
+	}
+	return proto.Clone(status).(*pb.SyncStatus)
 }
 
 func (p2p *P2p) sendSyncRequest(peerID peer.ID, topicString string) error {
@@ -42,19 +185,19 @@ func (p2p *P2p) sendSyncRequest(peerID peer.ID, topicString string) error {
 	if !errors.IsEmpty(err) {
 		return errors.E(errors.Op("Open a sync stream"), err)
 	}
-	syncMessage := &pb.WireMessage{Operation: pb.Operation_SYNC_REQUEST, ChannelID: []byte(topicString), Data: nil}
+	syncMessage := &pb.WireMessage{Operation: pb.Operation_SYNC_REQUEST, ChannelID: []byte(topicString), Data: nil, Version: interfaces.WireVersion}
 
 	marshaledData, err := proto.Marshal(syncMessage)
 	if !errors.IsEmpty(err) {
 		return errors.E(errors.Op("Marshal sync request wireMessage"), err)
 	}
-	err = stream.WriteToStream(marshaledData)
+	// Wait for the peer to acknowledge the request, the sync itself continues with the messages it sends back
+	_, err = stream.Request(marshaledData)
 	if !errors.IsEmpty(err) {
-		return errors.E(errors.Op("Write sync request to stream"), err)
+		return errors.E(errors.Op("Send sync request to stream"), err)
 	}
-	err = p2p.CloseStream(peerID)
-	if !errors.IsEmpty(err) {
-		return errors.E(errors.Op("Close the stream"), err)
+	if p2p.metrics != nil {
+		p2p.metrics.MessageSent(pb.Operation_SYNC_REQUEST)
 	}
 	return nil
 }
@@ -0,0 +1,98 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncRound(t *testing.T) {
+	receiver := &collectingReceiver{}
+	p2pInstance1, p2pInstance2 := newConnectedInstances(t, receiver)
+	defer p2pInstance1.host.Close()
+	defer p2pInstance2.host.Close()
+
+	topicString := string(testChannel.GetId())
+	p2pInstance1.syncStatus[topicString] = &pb.SyncStatus{ChannelID: testChannel.GetId()}
+
+	// A peer we can't reach counts as a failed request
+	_, unreachablePublicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	unreachable, err := peer.IDFromPublicKey(unreachablePublicKey)
+	assert.NoError(t, err)
+
+	p2pInstance1.syncRound(topicString, []peer.ID{p2pInstance2.GetHostID(), unreachable}, 2)
+
+	status := p2pInstance1.GetSyncStatus(testChannel.GetId())
+	assert.False(t, status.GetSyncing())
+	assert.Equal(t, uint64(1), status.GetRounds())
+	assert.Equal(t, uint64(2), status.GetRequestsSent())
+	assert.Equal(t, uint64(1), status.GetRequestsFailed())
+	assert.Equal(t, []string{p2pInstance2.GetHostIDString()}, status.GetLastPeers())
+	assert.NotNil(t, status.GetLastRound())
+
+	// Rounds only pick as many peers as configured
+	p2pInstance1.syncRound(topicString, []peer.ID{p2pInstance2.GetHostID(), unreachable}, 1)
+	status = p2pInstance1.GetSyncStatus(testChannel.GetId())
+	assert.Equal(t, uint64(2), status.GetRounds())
+	assert.Equal(t, uint64(3), status.GetRequestsSent())
+
+	// Channels that aren't joined have no progress
+	assert.Equal(t, uint64(0), p2pInstance1.GetSyncStatus([]byte("notJoined")).GetRounds())
+}
+
+func TestSyncOnJoin(t *testing.T) {
+	p2pInstance1 := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance2 := NewP2p(testConfig, privateKey2, publicKey2, Logger(log), Receiver(&collectingReceiver{}))
+	p2pInstance1.InitHost(p2pInstance1.CreateOptions()...)
+	p2pInstance2.InitHost(p2pInstance2.CreateOptions()...)
+	p2pInstance1.initPubSub()
+	p2pInstance2.initPubSub()
+	defer p2pInstance1.Close()
+	defer p2pInstance2.Close()
+	assert.NoError(t, p2pInstance1.host.Connect(p2pInstance1.ctx, p2pInstance2.GetAddrInfo()))
+
+	_, err := p2pInstance2.Subscribe(testChannel)
+	assert.NoError(t, err)
+	_, err = p2pInstance1.Subscribe(testChannel)
+	assert.NoError(t, err)
+
+	// The first peer found on the channel is synced with once
+	assert.Eventually(t, func() bool {
+		return p2pInstance1.GetSyncStatus(testChannel.GetId()).GetRequestsSent() > 0
+	}, 5*time.Second, 50*time.Millisecond)
+	time.Sleep(2 * joinSyncPollInterval)
+	status := p2pInstance1.GetSyncStatus(testChannel.GetId())
+	assert.Equal(t, uint64(1), status.GetRequestsSent())
+	assert.Equal(t, uint64(0), status.GetRequestsFailed())
+}
+
+func TestSyncOnJoinDeadline(t *testing.T) {
+	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance.InitHost(p2pInstance.CreateOptions()...)
+	p2pInstance.initPubSub()
+	defer p2pInstance.Close()
+	topic, err := p2pInstance.ps.Join(string(testChannel.GetId()))
+	assert.NoError(t, err)
+	defer topic.Close()
+
+	// A channel without peers isn't polled past the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		p2pInstance.syncOnJoin(ctx, string(testChannel.GetId()), topic, 1)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * joinSyncPollInterval):
+		t.Fatal("syncOnJoin kept polling past its deadline")
+	}
+}
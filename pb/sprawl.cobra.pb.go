@@ -12,6 +12,7 @@ It has these top-level commands:
 	OrderHandlerClientCommand
 	ChannelHandlerClientCommand
 	NodeHandlerClientCommand
+	APIKeyHandlerClientCommand
 */
 
 package pb
@@ -500,6 +501,277 @@ func init() {
 	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerGetAllOrdersClientCommand.Flags())
 }
 
+var _OrderHandlerRequestQuoteClientCommand = &cobra.Command{
+	Use:  "requestquote",
+	Long: "RequestQuote client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	requestquote -p > req.json
+
+Submit request using file:
+	requestquote -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | requestquote --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v CreateQuoteRequest
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.RequestQuote(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerRequestQuoteClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerRequestQuoteClientCommand.Flags())
+}
+
+var _OrderHandlerGetQuoteRequestsClientCommand = &cobra.Command{
+	Use:  "getquoterequests",
+	Long: "GetQuoteRequests client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getquoterequests -p > req.json
+
+Submit request using file:
+	getquoterequests -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getquoterequests --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetQuoteRequests(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerGetQuoteRequestsClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerGetQuoteRequestsClientCommand.Flags())
+}
+
+var _OrderHandlerSendQuoteClientCommand = &cobra.Command{
+	Use:  "sendquote",
+	Long: "SendQuote client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	sendquote -p > req.json
+
+Submit request using file:
+	sendquote -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | sendquote --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v SendQuoteRequest
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.SendQuote(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerSendQuoteClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerSendQuoteClientCommand.Flags())
+}
+
+var _OrderHandlerGetQuotesClientCommand = &cobra.Command{
+	Use:  "getquotes",
+	Long: "GetQuotes client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getquotes -p > req.json
+
+Submit request using file:
+	getquotes -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getquotes --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v QuoteRequestSpecificRequest
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetQuotes(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerGetQuotesClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerGetQuotesClientCommand.Flags())
+}
+
+var _OrderHandlerAcceptQuoteClientCommand = &cobra.Command{
+	Use:  "acceptquote",
+	Long: "AcceptQuote client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	acceptquote -p > req.json
+
+Submit request using file:
+	acceptquote -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | acceptquote --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v QuoteSpecificRequest
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.AcceptQuote(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerAcceptQuoteClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerAcceptQuoteClientCommand.Flags())
+}
+
+var _OrderHandlerStreamEventsClientCommand = &cobra.Command{
+	Use:  "streamevents",
+	Long: "StreamEvents client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	streamevents -p > req.json
+
+Submit request using file:
+	streamevents -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | streamevents --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v StreamEventsRequest
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			stream, err := cli.StreamEvents(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			for {
+				v, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return err
+				}
+				err = out.Encode(v)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerStreamEventsClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerStreamEventsClientCommand.Flags())
+}
+
 var _DefaultChannelHandlerClientCommandConfig = _NewChannelHandlerClientCommandConfig()
 
 type _ChannelHandlerClientCommandConfig struct {
@@ -544,148 +816,826 @@ func (o *_ChannelHandlerClientCommandConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.JWTKeyFile, "jwt-key-file", o.JWTKeyFile, "jwt key file")
 }
 
-var ChannelHandlerClientCommand = &cobra.Command{
-	Use: "channelhandler",
+var ChannelHandlerClientCommand = &cobra.Command{
+	Use: "channelhandler",
+}
+
+func _DialChannelHandler() (*grpc.ClientConn, ChannelHandlerClient, error) {
+	cfg := _DefaultChannelHandlerClientCommandConfig
+	opts := []grpc.DialOption{
+		grpc.WithBlock(),
+		grpc.WithTimeout(cfg.Timeout),
+	}
+	if cfg.TLS {
+		tlsConfig := &tls.Config{}
+		if cfg.InsecureSkipVerify {
+			tlsConfig.InsecureSkipVerify = true
+		}
+		if cfg.CACertFile != "" {
+			cacert, err := ioutil.ReadFile(cfg.CACertFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("ca cert: %v", err)
+			}
+			certpool := x509.NewCertPool()
+			certpool.AppendCertsFromPEM(cacert)
+			tlsConfig.RootCAs = certpool
+		}
+		if cfg.CertFile != "" {
+			if cfg.KeyFile == "" {
+				return nil, nil, fmt.Errorf("missing key file")
+			}
+			pair, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("cert/key: %v", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{pair}
+		}
+		if cfg.ServerName != "" {
+			tlsConfig.ServerName = cfg.ServerName
+		} else {
+			addr, _, _ := net.SplitHostPort(cfg.ServerAddr)
+			tlsConfig.ServerName = addr
+		}
+		//tlsConfig.BuildNameToCertificate()
+		cred := credentials.NewTLS(tlsConfig)
+		opts = append(opts, grpc.WithTransportCredentials(cred))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	if cfg.AuthToken != "" {
+		cred := oauth.NewOauthAccess(&oauth2.Token{
+			AccessToken: cfg.AuthToken,
+			TokenType:   cfg.AuthTokenType,
+		})
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	if cfg.JWTKey != "" {
+		cred, err := oauth.NewJWTAccessFromKey([]byte(cfg.JWTKey))
+		if err != nil {
+			return nil, nil, fmt.Errorf("jwt key: %v", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	if cfg.JWTKeyFile != "" {
+		cred, err := oauth.NewJWTAccessFromFile(cfg.JWTKeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("jwt key file: %v", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	conn, err := grpc.Dial(cfg.ServerAddr, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, NewChannelHandlerClient(conn), nil
+}
+
+type _ChannelHandlerRoundTripFunc func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error
+
+func _ChannelHandlerRoundTrip(sample interface{}, fn _ChannelHandlerRoundTripFunc) error {
+	cfg := _DefaultChannelHandlerClientCommandConfig
+	var em iocodec.EncoderMaker
+	var ok bool
+	if cfg.ResponseFormat == "" {
+		em = iocodec.DefaultEncoders["json"]
+	} else {
+		em, ok = iocodec.DefaultEncoders[cfg.ResponseFormat]
+		if !ok {
+			return fmt.Errorf("invalid response format: %q", cfg.ResponseFormat)
+		}
+	}
+	if cfg.PrintSampleRequest {
+		return em.NewEncoder(os.Stdout).Encode(sample)
+	}
+	var d iocodec.Decoder
+	if cfg.RequestFile == "" || cfg.RequestFile == "-" {
+		d = iocodec.DefaultDecoders["json"].NewDecoder(os.Stdin)
+	} else {
+		f, err := os.Open(cfg.RequestFile)
+		if err != nil {
+			return fmt.Errorf("request file: %v", err)
+		}
+		defer f.Close()
+		ext := filepath.Ext(cfg.RequestFile)
+		if len(ext) > 0 && ext[0] == '.' {
+			ext = ext[1:]
+		}
+		dm, ok := iocodec.DefaultDecoders[ext]
+		if !ok {
+			return fmt.Errorf("invalid request file format: %q", ext)
+		}
+		d = dm.NewDecoder(f)
+	}
+	conn, client, err := _DialChannelHandler()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return fn(client, d, em.NewEncoder(os.Stdout))
+}
+
+var _ChannelHandlerJoinClientCommand = &cobra.Command{
+	Use:  "join",
+	Long: "Join client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	join -p > req.json
+
+Submit request using file:
+	join -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | join --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v JoinRequest
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.Join(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerJoinClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerJoinClientCommand.Flags())
+}
+
+var _ChannelHandlerLeaveClientCommand = &cobra.Command{
+	Use:  "leave",
+	Long: "Leave client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	leave -p > req.json
+
+Submit request using file:
+	leave -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | leave --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v ChannelSpecificRequest
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.Leave(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerLeaveClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerLeaveClientCommand.Flags())
+}
+
+var _ChannelHandlerGetChannelClientCommand = &cobra.Command{
+	Use:  "getchannel",
+	Long: "GetChannel client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getchannel -p > req.json
+
+Submit request using file:
+	getchannel -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getchannel --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v ChannelSpecificRequest
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetChannel(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerGetChannelClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerGetChannelClientCommand.Flags())
+}
+
+var _ChannelHandlerGetAllChannelsClientCommand = &cobra.Command{
+	Use:  "getallchannels",
+	Long: "GetAllChannels client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getallchannels -p > req.json
+
+Submit request using file:
+	getallchannels -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getallchannels --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetAllChannels(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerGetAllChannelsClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerGetAllChannelsClientCommand.Flags())
+}
+
+var _ChannelHandlerListNetworkChannelsClientCommand = &cobra.Command{
+	Use:  "listnetworkchannels",
+	Long: "ListNetworkChannels client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	listnetworkchannels -p > req.json
+
+Submit request using file:
+	listnetworkchannels -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | listnetworkchannels --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.ListNetworkChannels(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerListNetworkChannelsClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerListNetworkChannelsClientCommand.Flags())
+}
+
+var _ChannelHandlerGetChannelStatsClientCommand = &cobra.Command{
+	Use:  "getchannelstats",
+	Long: "GetChannelStats client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getchannelstats -p > req.json
+
+Submit request using file:
+	getchannelstats -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getchannelstats --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetChannelStats(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerGetChannelStatsClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerGetChannelStatsClientCommand.Flags())
+}
+
+var _ChannelHandlerGetSyncStatusClientCommand = &cobra.Command{
+	Use:  "getsyncstatus",
+	Long: "GetSyncStatus client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getsyncstatus -p > req.json
+
+Submit request using file:
+	getsyncstatus -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getsyncstatus --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetSyncStatus(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerGetSyncStatusClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerGetSyncStatusClientCommand.Flags())
+}
+
+var _DefaultNodeHandlerClientCommandConfig = _NewNodeHandlerClientCommandConfig()
+
+type _NodeHandlerClientCommandConfig struct {
+	ServerAddr         string        `envconfig:"SERVER_ADDR" default:"localhost:8080"`
+	RequestFile        string        `envconfig:"REQUEST_FILE"`
+	PrintSampleRequest bool          `envconfig:"PRINT_SAMPLE_REQUEST"`
+	ResponseFormat     string        `envconfig:"RESPONSE_FORMAT" default:"json"`
+	Timeout            time.Duration `envconfig:"TIMEOUT" default:"10s"`
+	TLS                bool          `envconfig:"TLS"`
+	ServerName         string        `envconfig:"TLS_SERVER_NAME"`
+	InsecureSkipVerify bool          `envconfig:"TLS_INSECURE_SKIP_VERIFY"`
+	CACertFile         string        `envconfig:"TLS_CA_CERT_FILE"`
+	CertFile           string        `envconfig:"TLS_CERT_FILE"`
+	KeyFile            string        `envconfig:"TLS_KEY_FILE"`
+	AuthToken          string        `envconfig:"AUTH_TOKEN"`
+	AuthTokenType      string        `envconfig:"AUTH_TOKEN_TYPE" default:"Bearer"`
+	JWTKey             string        `envconfig:"JWT_KEY"`
+	JWTKeyFile         string        `envconfig:"JWT_KEY_FILE"`
+}
+
+func _NewNodeHandlerClientCommandConfig() *_NodeHandlerClientCommandConfig {
+	c := &_NodeHandlerClientCommandConfig{}
+	envconfig.Process("", c)
+	return c
+}
+
+func (o *_NodeHandlerClientCommandConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.ServerAddr, "server-addr", "s", o.ServerAddr, "server address in form of host:port")
+	fs.StringVarP(&o.RequestFile, "request-file", "f", o.RequestFile, "client request file (must be json, yaml, or xml); use \"-\" for stdin + json")
+	fs.BoolVarP(&o.PrintSampleRequest, "print-sample-request", "p", o.PrintSampleRequest, "print sample request file and exit")
+	fs.StringVarP(&o.ResponseFormat, "response-format", "o", o.ResponseFormat, "response format (json, prettyjson, yaml, or xml)")
+	fs.DurationVar(&o.Timeout, "timeout", o.Timeout, "client connection timeout")
+	fs.BoolVar(&o.TLS, "tls", o.TLS, "enable tls")
+	fs.StringVar(&o.ServerName, "tls-server-name", o.ServerName, "tls server name override")
+	fs.BoolVar(&o.InsecureSkipVerify, "tls-insecure-skip-verify", o.InsecureSkipVerify, "INSECURE: skip tls checks")
+	fs.StringVar(&o.CACertFile, "tls-ca-cert-file", o.CACertFile, "ca certificate file")
+	fs.StringVar(&o.CertFile, "tls-cert-file", o.CertFile, "client certificate file")
+	fs.StringVar(&o.KeyFile, "tls-key-file", o.KeyFile, "client key file")
+	fs.StringVar(&o.AuthToken, "auth-token", o.AuthToken, "authorization token")
+	fs.StringVar(&o.AuthTokenType, "auth-token-type", o.AuthTokenType, "authorization token type")
+	fs.StringVar(&o.JWTKey, "jwt-key", o.JWTKey, "jwt key")
+	fs.StringVar(&o.JWTKeyFile, "jwt-key-file", o.JWTKeyFile, "jwt key file")
+}
+
+var NodeHandlerClientCommand = &cobra.Command{
+	Use: "nodehandler",
+}
+
+func _DialNodeHandler() (*grpc.ClientConn, NodeHandlerClient, error) {
+	cfg := _DefaultNodeHandlerClientCommandConfig
+	opts := []grpc.DialOption{
+		grpc.WithBlock(),
+		grpc.WithTimeout(cfg.Timeout),
+	}
+	if cfg.TLS {
+		tlsConfig := &tls.Config{}
+		if cfg.InsecureSkipVerify {
+			tlsConfig.InsecureSkipVerify = true
+		}
+		if cfg.CACertFile != "" {
+			cacert, err := ioutil.ReadFile(cfg.CACertFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("ca cert: %v", err)
+			}
+			certpool := x509.NewCertPool()
+			certpool.AppendCertsFromPEM(cacert)
+			tlsConfig.RootCAs = certpool
+		}
+		if cfg.CertFile != "" {
+			if cfg.KeyFile == "" {
+				return nil, nil, fmt.Errorf("missing key file")
+			}
+			pair, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("cert/key: %v", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{pair}
+		}
+		if cfg.ServerName != "" {
+			tlsConfig.ServerName = cfg.ServerName
+		} else {
+			addr, _, _ := net.SplitHostPort(cfg.ServerAddr)
+			tlsConfig.ServerName = addr
+		}
+		//tlsConfig.BuildNameToCertificate()
+		cred := credentials.NewTLS(tlsConfig)
+		opts = append(opts, grpc.WithTransportCredentials(cred))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	if cfg.AuthToken != "" {
+		cred := oauth.NewOauthAccess(&oauth2.Token{
+			AccessToken: cfg.AuthToken,
+			TokenType:   cfg.AuthTokenType,
+		})
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	if cfg.JWTKey != "" {
+		cred, err := oauth.NewJWTAccessFromKey([]byte(cfg.JWTKey))
+		if err != nil {
+			return nil, nil, fmt.Errorf("jwt key: %v", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	if cfg.JWTKeyFile != "" {
+		cred, err := oauth.NewJWTAccessFromFile(cfg.JWTKeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("jwt key file: %v", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	conn, err := grpc.Dial(cfg.ServerAddr, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, NewNodeHandlerClient(conn), nil
+}
+
+type _NodeHandlerRoundTripFunc func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error
+
+func _NodeHandlerRoundTrip(sample interface{}, fn _NodeHandlerRoundTripFunc) error {
+	cfg := _DefaultNodeHandlerClientCommandConfig
+	var em iocodec.EncoderMaker
+	var ok bool
+	if cfg.ResponseFormat == "" {
+		em = iocodec.DefaultEncoders["json"]
+	} else {
+		em, ok = iocodec.DefaultEncoders[cfg.ResponseFormat]
+		if !ok {
+			return fmt.Errorf("invalid response format: %q", cfg.ResponseFormat)
+		}
+	}
+	if cfg.PrintSampleRequest {
+		return em.NewEncoder(os.Stdout).Encode(sample)
+	}
+	var d iocodec.Decoder
+	if cfg.RequestFile == "" || cfg.RequestFile == "-" {
+		d = iocodec.DefaultDecoders["json"].NewDecoder(os.Stdin)
+	} else {
+		f, err := os.Open(cfg.RequestFile)
+		if err != nil {
+			return fmt.Errorf("request file: %v", err)
+		}
+		defer f.Close()
+		ext := filepath.Ext(cfg.RequestFile)
+		if len(ext) > 0 && ext[0] == '.' {
+			ext = ext[1:]
+		}
+		dm, ok := iocodec.DefaultDecoders[ext]
+		if !ok {
+			return fmt.Errorf("invalid request file format: %q", ext)
+		}
+		d = dm.NewDecoder(f)
+	}
+	conn, client, err := _DialNodeHandler()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return fn(client, d, em.NewEncoder(os.Stdout))
+}
+
+var _NodeHandlerGetAllPeersClientCommand = &cobra.Command{
+	Use:  "getallpeers",
+	Long: "GetAllPeers client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getallpeers -p > req.json
+
+Submit request using file:
+	getallpeers -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getallpeers --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetAllPeers(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerGetAllPeersClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerGetAllPeersClientCommand.Flags())
+}
+
+var _NodeHandlerBlacklistPeerClientCommand = &cobra.Command{
+	Use:  "blacklistpeer",
+	Long: "BlacklistPeer client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	blacklistpeer -p > req.json
+
+Submit request using file:
+	blacklistpeer -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | blacklistpeer --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Peer
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.BlacklistPeer(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerBlacklistPeerClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerBlacklistPeerClientCommand.Flags())
+}
+
+var _NodeHandlerGetNodeInfoClientCommand = &cobra.Command{
+	Use:  "getnodeinfo",
+	Long: "GetNodeInfo client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getnodeinfo -p > req.json
+
+Submit request using file:
+	getnodeinfo -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getnodeinfo --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetNodeInfo(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerGetNodeInfoClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerGetNodeInfoClientCommand.Flags())
+}
+
+var _NodeHandlerConnectPeerClientCommand = &cobra.Command{
+	Use:  "connectpeer",
+	Long: "ConnectPeer client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	connectpeer -p > req.json
+
+Submit request using file:
+	connectpeer -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | connectpeer --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v ConnectRequest
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.ConnectPeer(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerConnectPeerClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerConnectPeerClientCommand.Flags())
 }
 
-func _DialChannelHandler() (*grpc.ClientConn, ChannelHandlerClient, error) {
-	cfg := _DefaultChannelHandlerClientCommandConfig
-	opts := []grpc.DialOption{
-		grpc.WithBlock(),
-		grpc.WithTimeout(cfg.Timeout),
-	}
-	if cfg.TLS {
-		tlsConfig := &tls.Config{}
-		if cfg.InsecureSkipVerify {
-			tlsConfig.InsecureSkipVerify = true
-		}
-		if cfg.CACertFile != "" {
-			cacert, err := ioutil.ReadFile(cfg.CACertFile)
+var _NodeHandlerDisconnectPeerClientCommand = &cobra.Command{
+	Use:  "disconnectpeer",
+	Long: "DisconnectPeer client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	disconnectpeer -p > req.json
+
+Submit request using file:
+	disconnectpeer -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | disconnectpeer --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Peer
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
 			if err != nil {
-				return nil, nil, fmt.Errorf("ca cert: %v", err)
-			}
-			certpool := x509.NewCertPool()
-			certpool.AppendCertsFromPEM(cacert)
-			tlsConfig.RootCAs = certpool
-		}
-		if cfg.CertFile != "" {
-			if cfg.KeyFile == "" {
-				return nil, nil, fmt.Errorf("missing key file")
+				return err
 			}
-			pair, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+
+			resp, err := cli.DisconnectPeer(context.Background(), &v)
+
 			if err != nil {
-				return nil, nil, fmt.Errorf("cert/key: %v", err)
+				return err
 			}
-			tlsConfig.Certificates = []tls.Certificate{pair}
-		}
-		if cfg.ServerName != "" {
-			tlsConfig.ServerName = cfg.ServerName
-		} else {
-			addr, _, _ := net.SplitHostPort(cfg.ServerAddr)
-			tlsConfig.ServerName = addr
-		}
-		//tlsConfig.BuildNameToCertificate()
-		cred := credentials.NewTLS(tlsConfig)
-		opts = append(opts, grpc.WithTransportCredentials(cred))
-	} else {
-		opts = append(opts, grpc.WithInsecure())
-	}
-	if cfg.AuthToken != "" {
-		cred := oauth.NewOauthAccess(&oauth2.Token{
-			AccessToken: cfg.AuthToken,
-			TokenType:   cfg.AuthTokenType,
+
+			return out.Encode(resp)
+
 		})
-		opts = append(opts, grpc.WithPerRPCCredentials(cred))
-	}
-	if cfg.JWTKey != "" {
-		cred, err := oauth.NewJWTAccessFromKey([]byte(cfg.JWTKey))
-		if err != nil {
-			return nil, nil, fmt.Errorf("jwt key: %v", err)
-		}
-		opts = append(opts, grpc.WithPerRPCCredentials(cred))
-	}
-	if cfg.JWTKeyFile != "" {
-		cred, err := oauth.NewJWTAccessFromFile(cfg.JWTKeyFile)
 		if err != nil {
-			return nil, nil, fmt.Errorf("jwt key file: %v", err)
+			log.Fatal(err)
 		}
-		opts = append(opts, grpc.WithPerRPCCredentials(cred))
-	}
-	conn, err := grpc.Dial(cfg.ServerAddr, opts...)
-	if err != nil {
-		return nil, nil, err
-	}
-	return conn, NewChannelHandlerClient(conn), nil
+	},
 }
 
-type _ChannelHandlerRoundTripFunc func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error
-
-func _ChannelHandlerRoundTrip(sample interface{}, fn _ChannelHandlerRoundTripFunc) error {
-	cfg := _DefaultChannelHandlerClientCommandConfig
-	var em iocodec.EncoderMaker
-	var ok bool
-	if cfg.ResponseFormat == "" {
-		em = iocodec.DefaultEncoders["json"]
-	} else {
-		em, ok = iocodec.DefaultEncoders[cfg.ResponseFormat]
-		if !ok {
-			return fmt.Errorf("invalid response format: %q", cfg.ResponseFormat)
-		}
-	}
-	if cfg.PrintSampleRequest {
-		return em.NewEncoder(os.Stdout).Encode(sample)
-	}
-	var d iocodec.Decoder
-	if cfg.RequestFile == "" || cfg.RequestFile == "-" {
-		d = iocodec.DefaultDecoders["json"].NewDecoder(os.Stdin)
-	} else {
-		f, err := os.Open(cfg.RequestFile)
-		if err != nil {
-			return fmt.Errorf("request file: %v", err)
-		}
-		defer f.Close()
-		ext := filepath.Ext(cfg.RequestFile)
-		if len(ext) > 0 && ext[0] == '.' {
-			ext = ext[1:]
-		}
-		dm, ok := iocodec.DefaultDecoders[ext]
-		if !ok {
-			return fmt.Errorf("invalid request file format: %q", ext)
-		}
-		d = dm.NewDecoder(f)
-	}
-	conn, client, err := _DialChannelHandler()
-	if err != nil {
-		return err
-	}
-	defer conn.Close()
-	return fn(client, d, em.NewEncoder(os.Stdout))
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerDisconnectPeerClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerDisconnectPeerClientCommand.Flags())
 }
 
-var _ChannelHandlerJoinClientCommand = &cobra.Command{
-	Use:  "join",
-	Long: "Join client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+var _NodeHandlerGetProtectedPeersClientCommand = &cobra.Command{
+	Use:  "getprotectedpeers",
+	Long: "GetProtectedPeers client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
 	Example: `
 Save a sample request to a file (or refer to your protobuf descriptor to create one):
-	join -p > req.json
+	getprotectedpeers -p > req.json
 
 Submit request using file:
-	join -f req.json
+	getprotectedpeers -f req.json
 
 Authenticate using the Authorization header (requires transport security):
 	export AUTH_TOKEN=your_access_token
 	export SERVER_ADDR=api.example.com:443
-	echo '{json}' | join --tls`,
+	echo '{json}' | getprotectedpeers --tls`,
 	Run: func(cmd *cobra.Command, args []string) {
-		var v JoinRequest
-		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+		var v Empty
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
 
 			err := in.Decode(&v)
 			if err != nil {
 				return err
 			}
 
-			resp, err := cli.Join(context.Background(), &v)
+			resp, err := cli.GetProtectedPeers(context.Background(), &v)
 
 			if err != nil {
 				return err
@@ -701,34 +1651,34 @@ Authenticate using the Authorization header (requires transport security):
 }
 
 func init() {
-	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerJoinClientCommand)
-	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerJoinClientCommand.Flags())
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerGetProtectedPeersClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerGetProtectedPeersClientCommand.Flags())
 }
 
-var _ChannelHandlerLeaveClientCommand = &cobra.Command{
-	Use:  "leave",
-	Long: "Leave client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+var _NodeHandlerSendDirectMessageClientCommand = &cobra.Command{
+	Use:  "senddirectmessage",
+	Long: "SendDirectMessage client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
 	Example: `
 Save a sample request to a file (or refer to your protobuf descriptor to create one):
-	leave -p > req.json
+	senddirectmessage -p > req.json
 
 Submit request using file:
-	leave -f req.json
+	senddirectmessage -f req.json
 
 Authenticate using the Authorization header (requires transport security):
 	export AUTH_TOKEN=your_access_token
 	export SERVER_ADDR=api.example.com:443
-	echo '{json}' | leave --tls`,
+	echo '{json}' | senddirectmessage --tls`,
 	Run: func(cmd *cobra.Command, args []string) {
-		var v ChannelSpecificRequest
-		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+		var v DirectMessageRequest
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
 
 			err := in.Decode(&v)
 			if err != nil {
 				return err
 			}
 
-			resp, err := cli.Leave(context.Background(), &v)
+			resp, err := cli.SendDirectMessage(context.Background(), &v)
 
 			if err != nil {
 				return err
@@ -744,40 +1694,53 @@ Authenticate using the Authorization header (requires transport security):
 }
 
 func init() {
-	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerLeaveClientCommand)
-	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerLeaveClientCommand.Flags())
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerSendDirectMessageClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerSendDirectMessageClientCommand.Flags())
 }
 
-var _ChannelHandlerGetChannelClientCommand = &cobra.Command{
-	Use:  "getchannel",
-	Long: "GetChannel client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+var _NodeHandlerReceiveDirectMessagesClientCommand = &cobra.Command{
+	Use:  "receivedirectmessages",
+	Long: "ReceiveDirectMessages client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
 	Example: `
 Save a sample request to a file (or refer to your protobuf descriptor to create one):
-	getchannel -p > req.json
+	receivedirectmessages -p > req.json
 
 Submit request using file:
-	getchannel -f req.json
+	receivedirectmessages -f req.json
 
 Authenticate using the Authorization header (requires transport security):
 	export AUTH_TOKEN=your_access_token
 	export SERVER_ADDR=api.example.com:443
-	echo '{json}' | getchannel --tls`,
+	echo '{json}' | receivedirectmessages --tls`,
 	Run: func(cmd *cobra.Command, args []string) {
-		var v ChannelSpecificRequest
-		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+		var v Empty
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
 
 			err := in.Decode(&v)
 			if err != nil {
 				return err
 			}
 
-			resp, err := cli.GetChannel(context.Background(), &v)
+			stream, err := cli.ReceiveDirectMessages(context.Background(), &v)
 
 			if err != nil {
 				return err
 			}
 
-			return out.Encode(resp)
+			for {
+				v, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return err
+				}
+				err = out.Encode(v)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
 
 		})
 		if err != nil {
@@ -787,34 +1750,34 @@ Authenticate using the Authorization header (requires transport security):
 }
 
 func init() {
-	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerGetChannelClientCommand)
-	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerGetChannelClientCommand.Flags())
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerReceiveDirectMessagesClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerReceiveDirectMessagesClientCommand.Flags())
 }
 
-var _ChannelHandlerGetAllChannelsClientCommand = &cobra.Command{
-	Use:  "getallchannels",
-	Long: "GetAllChannels client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+var _NodeHandlerGetRateLimitOffendersClientCommand = &cobra.Command{
+	Use:  "getratelimitoffenders",
+	Long: "GetRateLimitOffenders client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
 	Example: `
 Save a sample request to a file (or refer to your protobuf descriptor to create one):
-	getallchannels -p > req.json
+	getratelimitoffenders -p > req.json
 
 Submit request using file:
-	getallchannels -f req.json
+	getratelimitoffenders -f req.json
 
 Authenticate using the Authorization header (requires transport security):
 	export AUTH_TOKEN=your_access_token
 	export SERVER_ADDR=api.example.com:443
-	echo '{json}' | getallchannels --tls`,
+	echo '{json}' | getratelimitoffenders --tls`,
 	Run: func(cmd *cobra.Command, args []string) {
 		var v Empty
-		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
 
 			err := in.Decode(&v)
 			if err != nil {
 				return err
 			}
 
-			resp, err := cli.GetAllChannels(context.Background(), &v)
+			resp, err := cli.GetRateLimitOffenders(context.Background(), &v)
 
 			if err != nil {
 				return err
@@ -830,13 +1793,13 @@ Authenticate using the Authorization header (requires transport security):
 }
 
 func init() {
-	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerGetAllChannelsClientCommand)
-	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerGetAllChannelsClientCommand.Flags())
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerGetRateLimitOffendersClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerGetRateLimitOffendersClientCommand.Flags())
 }
 
-var _DefaultNodeHandlerClientCommandConfig = _NewNodeHandlerClientCommandConfig()
+var _DefaultAPIKeyHandlerClientCommandConfig = _NewAPIKeyHandlerClientCommandConfig()
 
-type _NodeHandlerClientCommandConfig struct {
+type _APIKeyHandlerClientCommandConfig struct {
 	ServerAddr         string        `envconfig:"SERVER_ADDR" default:"localhost:8080"`
 	RequestFile        string        `envconfig:"REQUEST_FILE"`
 	PrintSampleRequest bool          `envconfig:"PRINT_SAMPLE_REQUEST"`
@@ -854,13 +1817,13 @@ type _NodeHandlerClientCommandConfig struct {
 	JWTKeyFile         string        `envconfig:"JWT_KEY_FILE"`
 }
 
-func _NewNodeHandlerClientCommandConfig() *_NodeHandlerClientCommandConfig {
-	c := &_NodeHandlerClientCommandConfig{}
+func _NewAPIKeyHandlerClientCommandConfig() *_APIKeyHandlerClientCommandConfig {
+	c := &_APIKeyHandlerClientCommandConfig{}
 	envconfig.Process("", c)
 	return c
 }
 
-func (o *_NodeHandlerClientCommandConfig) AddFlags(fs *pflag.FlagSet) {
+func (o *_APIKeyHandlerClientCommandConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVarP(&o.ServerAddr, "server-addr", "s", o.ServerAddr, "server address in form of host:port")
 	fs.StringVarP(&o.RequestFile, "request-file", "f", o.RequestFile, "client request file (must be json, yaml, or xml); use \"-\" for stdin + json")
 	fs.BoolVarP(&o.PrintSampleRequest, "print-sample-request", "p", o.PrintSampleRequest, "print sample request file and exit")
@@ -878,12 +1841,12 @@ func (o *_NodeHandlerClientCommandConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.JWTKeyFile, "jwt-key-file", o.JWTKeyFile, "jwt key file")
 }
 
-var NodeHandlerClientCommand = &cobra.Command{
-	Use: "nodehandler",
+var APIKeyHandlerClientCommand = &cobra.Command{
+	Use: "apikeyhandler",
 }
 
-func _DialNodeHandler() (*grpc.ClientConn, NodeHandlerClient, error) {
-	cfg := _DefaultNodeHandlerClientCommandConfig
+func _DialAPIKeyHandler() (*grpc.ClientConn, APIKeyHandlerClient, error) {
+	cfg := _DefaultAPIKeyHandlerClientCommandConfig
 	opts := []grpc.DialOption{
 		grpc.WithBlock(),
 		grpc.WithTimeout(cfg.Timeout),
@@ -949,13 +1912,13 @@ func _DialNodeHandler() (*grpc.ClientConn, NodeHandlerClient, error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	return conn, NewNodeHandlerClient(conn), nil
+	return conn, NewAPIKeyHandlerClient(conn), nil
 }
 
-type _NodeHandlerRoundTripFunc func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error
+type _APIKeyHandlerRoundTripFunc func(cli APIKeyHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error
 
-func _NodeHandlerRoundTrip(sample interface{}, fn _NodeHandlerRoundTripFunc) error {
-	cfg := _DefaultNodeHandlerClientCommandConfig
+func _APIKeyHandlerRoundTrip(sample interface{}, fn _APIKeyHandlerRoundTripFunc) error {
+	cfg := _DefaultAPIKeyHandlerClientCommandConfig
 	var em iocodec.EncoderMaker
 	var ok bool
 	if cfg.ResponseFormat == "" {
@@ -988,7 +1951,7 @@ func _NodeHandlerRoundTrip(sample interface{}, fn _NodeHandlerRoundTripFunc) err
 		}
 		d = dm.NewDecoder(f)
 	}
-	conn, client, err := _DialNodeHandler()
+	conn, client, err := _DialAPIKeyHandler()
 	if err != nil {
 		return err
 	}
@@ -996,30 +1959,73 @@ func _NodeHandlerRoundTrip(sample interface{}, fn _NodeHandlerRoundTripFunc) err
 	return fn(client, d, em.NewEncoder(os.Stdout))
 }
 
-var _NodeHandlerGetAllPeersClientCommand = &cobra.Command{
-	Use:  "getallpeers",
-	Long: "GetAllPeers client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+var _APIKeyHandlerCreateAPIKeyClientCommand = &cobra.Command{
+	Use:  "createapikey",
+	Long: "CreateAPIKey client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
 	Example: `
 Save a sample request to a file (or refer to your protobuf descriptor to create one):
-	getallpeers -p > req.json
+	createapikey -p > req.json
 
 Submit request using file:
-	getallpeers -f req.json
+	createapikey -f req.json
 
 Authenticate using the Authorization header (requires transport security):
 	export AUTH_TOKEN=your_access_token
 	export SERVER_ADDR=api.example.com:443
-	echo '{json}' | getallpeers --tls`,
+	echo '{json}' | createapikey --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v CreateAPIKeyRequest
+		err := _APIKeyHandlerRoundTrip(v, func(cli APIKeyHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.CreateAPIKey(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	APIKeyHandlerClientCommand.AddCommand(_APIKeyHandlerCreateAPIKeyClientCommand)
+	_DefaultAPIKeyHandlerClientCommandConfig.AddFlags(_APIKeyHandlerCreateAPIKeyClientCommand.Flags())
+}
+
+var _APIKeyHandlerGetAllAPIKeysClientCommand = &cobra.Command{
+	Use:  "getallapikeys",
+	Long: "GetAllAPIKeys client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getallapikeys -p > req.json
+
+Submit request using file:
+	getallapikeys -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getallapikeys --tls`,
 	Run: func(cmd *cobra.Command, args []string) {
 		var v Empty
-		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+		err := _APIKeyHandlerRoundTrip(v, func(cli APIKeyHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
 
 			err := in.Decode(&v)
 			if err != nil {
 				return err
 			}
 
-			resp, err := cli.GetAllPeers(context.Background(), &v)
+			resp, err := cli.GetAllAPIKeys(context.Background(), &v)
 
 			if err != nil {
 				return err
@@ -1035,34 +2041,34 @@ Authenticate using the Authorization header (requires transport security):
 }
 
 func init() {
-	NodeHandlerClientCommand.AddCommand(_NodeHandlerGetAllPeersClientCommand)
-	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerGetAllPeersClientCommand.Flags())
+	APIKeyHandlerClientCommand.AddCommand(_APIKeyHandlerGetAllAPIKeysClientCommand)
+	_DefaultAPIKeyHandlerClientCommandConfig.AddFlags(_APIKeyHandlerGetAllAPIKeysClientCommand.Flags())
 }
 
-var _NodeHandlerBlacklistPeerClientCommand = &cobra.Command{
-	Use:  "blacklistpeer",
-	Long: "BlacklistPeer client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+var _APIKeyHandlerRevokeAPIKeyClientCommand = &cobra.Command{
+	Use:  "revokeapikey",
+	Long: "RevokeAPIKey client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
 	Example: `
 Save a sample request to a file (or refer to your protobuf descriptor to create one):
-	blacklistpeer -p > req.json
+	revokeapikey -p > req.json
 
 Submit request using file:
-	blacklistpeer -f req.json
+	revokeapikey -f req.json
 
 Authenticate using the Authorization header (requires transport security):
 	export AUTH_TOKEN=your_access_token
 	export SERVER_ADDR=api.example.com:443
-	echo '{json}' | blacklistpeer --tls`,
+	echo '{json}' | revokeapikey --tls`,
 	Run: func(cmd *cobra.Command, args []string) {
-		var v Peer
-		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+		var v APIKeySpecificRequest
+		err := _APIKeyHandlerRoundTrip(v, func(cli APIKeyHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
 
 			err := in.Decode(&v)
 			if err != nil {
 				return err
 			}
 
-			resp, err := cli.BlacklistPeer(context.Background(), &v)
+			resp, err := cli.RevokeAPIKey(context.Background(), &v)
 
 			if err != nil {
 				return err
@@ -1078,6 +2084,6 @@ Authenticate using the Authorization header (requires transport security):
 }
 
 func init() {
-	NodeHandlerClientCommand.AddCommand(_NodeHandlerBlacklistPeerClientCommand)
-	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerBlacklistPeerClientCommand.Flags())
+	APIKeyHandlerClientCommand.AddCommand(_APIKeyHandlerRevokeAPIKeyClientCommand)
+	_DefaultAPIKeyHandlerClientCommandConfig.AddFlags(_APIKeyHandlerRevokeAPIKeyClientCommand.Flags())
 }
@@ -8,6 +8,7 @@ import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -53,30 +54,51 @@ func (State) EnumDescriptor() ([]byte, []int) {
 type Operation int32
 
 const (
-	Operation_CREATE       Operation = 0
-	Operation_DELETE       Operation = 1
-	Operation_LOCK         Operation = 2
-	Operation_UNLOCK       Operation = 3
-	Operation_SYNC_REQUEST Operation = 4
-	Operation_SYNC_RECEIVE Operation = 5
+	Operation_CREATE            Operation = 0
+	Operation_DELETE            Operation = 1
+	Operation_LOCK              Operation = 2
+	Operation_UNLOCK            Operation = 3
+	Operation_SYNC_REQUEST      Operation = 4
+	Operation_SYNC_RECEIVE      Operation = 5
+	Operation_CHANNEL_INVITE    Operation = 6
+	Operation_DIRECT_MESSAGE    Operation = 7
+	Operation_QUOTE_REQUEST     Operation = 8
+	Operation_QUOTE             Operation = 9
+	Operation_QUOTE_ACCEPT      Operation = 10
+	Operation_SYNC_FINGERPRINTS Operation = 11
+	Operation_SYNC_DIGEST       Operation = 12
 )
 
 var Operation_name = map[int32]string{
-	0: "CREATE",
-	1: "DELETE",
-	2: "LOCK",
-	3: "UNLOCK",
-	4: "SYNC_REQUEST",
-	5: "SYNC_RECEIVE",
+	0:  "CREATE",
+	1:  "DELETE",
+	2:  "LOCK",
+	3:  "UNLOCK",
+	4:  "SYNC_REQUEST",
+	5:  "SYNC_RECEIVE",
+	6:  "CHANNEL_INVITE",
+	7:  "DIRECT_MESSAGE",
+	8:  "QUOTE_REQUEST",
+	9:  "QUOTE",
+	10: "QUOTE_ACCEPT",
+	11: "SYNC_FINGERPRINTS",
+	12: "SYNC_DIGEST",
 }
 
 var Operation_value = map[string]int32{
-	"CREATE":       0,
-	"DELETE":       1,
-	"LOCK":         2,
-	"UNLOCK":       3,
-	"SYNC_REQUEST": 4,
-	"SYNC_RECEIVE": 5,
+	"CREATE":            0,
+	"DELETE":            1,
+	"LOCK":              2,
+	"UNLOCK":            3,
+	"SYNC_REQUEST":      4,
+	"SYNC_RECEIVE":      5,
+	"CHANNEL_INVITE":    6,
+	"DIRECT_MESSAGE":    7,
+	"QUOTE_REQUEST":     8,
+	"QUOTE":             9,
+	"QUOTE_ACCEPT":      10,
+	"SYNC_FINGERPRINTS": 11,
+	"SYNC_DIGEST":       12,
 }
 
 func (x Operation) String() string {
@@ -87,6 +109,118 @@ func (Operation) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_b5e409e9578376a3, []int{1}
 }
 
+type ChannelType int32
+
+const (
+	ChannelType_PUBLIC  ChannelType = 0
+	ChannelType_PRIVATE ChannelType = 1
+)
+
+var ChannelType_name = map[int32]string{
+	0: "PUBLIC",
+	1: "PRIVATE",
+}
+
+var ChannelType_value = map[string]int32{
+	"PUBLIC":  0,
+	"PRIVATE": 1,
+}
+
+func (x ChannelType) String() string {
+	return proto.EnumName(ChannelType_name, int32(x))
+}
+
+func (ChannelType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{2}
+}
+
+type EventType int32
+
+const (
+	EventType_ORDER_CREATED  EventType = 0
+	EventType_ORDER_DELETED  EventType = 1
+	EventType_ORDER_LOCKED   EventType = 2
+	EventType_ORDER_UNLOCKED EventType = 3
+	EventType_ORDER_SYNCED   EventType = 4
+)
+
+var EventType_name = map[int32]string{
+	0: "ORDER_CREATED",
+	1: "ORDER_DELETED",
+	2: "ORDER_LOCKED",
+	3: "ORDER_UNLOCKED",
+	4: "ORDER_SYNCED",
+}
+
+var EventType_value = map[string]int32{
+	"ORDER_CREATED":  0,
+	"ORDER_DELETED":  1,
+	"ORDER_LOCKED":   2,
+	"ORDER_UNLOCKED": 3,
+	"ORDER_SYNCED":   4,
+}
+
+func (x EventType) String() string {
+	return proto.EnumName(EventType_name, int32(x))
+}
+
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{3}
+}
+
+type WebsocketAction int32
+
+const (
+	WebsocketAction_SUBSCRIBE   WebsocketAction = 0
+	WebsocketAction_UNSUBSCRIBE WebsocketAction = 1
+)
+
+var WebsocketAction_name = map[int32]string{
+	0: "SUBSCRIBE",
+	1: "UNSUBSCRIBE",
+}
+
+var WebsocketAction_value = map[string]int32{
+	"SUBSCRIBE":   0,
+	"UNSUBSCRIBE": 1,
+}
+
+func (x WebsocketAction) String() string {
+	return proto.EnumName(WebsocketAction_name, int32(x))
+}
+
+func (WebsocketAction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{4}
+}
+
+type APIKeyRole int32
+
+const (
+	APIKeyRole_READ_ONLY APIKeyRole = 0
+	APIKeyRole_TRADER    APIKeyRole = 1
+	APIKeyRole_ADMIN     APIKeyRole = 2
+)
+
+var APIKeyRole_name = map[int32]string{
+	0: "READ_ONLY",
+	1: "TRADER",
+	2: "ADMIN",
+}
+
+var APIKeyRole_value = map[string]int32{
+	"READ_ONLY": 0,
+	"TRADER":    1,
+	"ADMIN":     2,
+}
+
+func (x APIKeyRole) String() string {
+	return proto.EnumName(APIKeyRole_name, int32(x))
+}
+
+func (APIKeyRole) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{5}
+}
+
 type Peer struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -137,6 +271,9 @@ type Order struct {
 	Signature            []byte               `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
 	Nonce                uint32               `protobuf:"varint,9,opt,name=nonce,proto3" json:"nonce,omitempty"`
 	Metadata             []byte               `protobuf:"bytes,10,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Stamp                uint64               `protobuf:"varint,11,opt,name=stamp,proto3" json:"stamp,omitempty"`
+	Creator              []byte               `protobuf:"bytes,12,opt,name=creator,proto3" json:"creator,omitempty"`
+	StateSignature       []byte               `protobuf:"bytes,13,opt,name=stateSignature,proto3" json:"stateSignature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -237,6 +374,90 @@ func (m *Order) GetMetadata() []byte {
 	return nil
 }
 
+func (m *Order) GetStamp() uint64 {
+	if m != nil {
+		return m.Stamp
+	}
+	return 0
+}
+
+func (m *Order) GetCreator() []byte {
+	if m != nil {
+		return m.Creator
+	}
+	return nil
+}
+
+func (m *Order) GetStateSignature() []byte {
+	if m != nil {
+		return m.StateSignature
+	}
+	return nil
+}
+
+type OrderState struct {
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	State                State    `protobuf:"varint,2,opt,name=state,proto3,enum=pb.State" json:"state,omitempty"`
+	Nonce                uint32   `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Deleted              bool     `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderState) Reset()         { *m = OrderState{} }
+func (m *OrderState) String() string { return proto.CompactTextString(m) }
+func (*OrderState) ProtoMessage()    {}
+func (*OrderState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{2}
+}
+
+func (m *OrderState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderState.Unmarshal(m, b)
+}
+func (m *OrderState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderState.Marshal(b, m, deterministic)
+}
+func (m *OrderState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderState.Merge(m, src)
+}
+func (m *OrderState) XXX_Size() int {
+	return xxx_messageInfo_OrderState.Size(m)
+}
+func (m *OrderState) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderState proto.InternalMessageInfo
+
+func (m *OrderState) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *OrderState) GetState() State {
+	if m != nil {
+		return m.State
+	}
+	return State_OPEN
+}
+
+func (m *OrderState) GetNonce() uint32 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *OrderState) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
 type OrderList struct {
 	Orders               []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -248,7 +469,7 @@ func (m *OrderList) Reset()         { *m = OrderList{} }
 func (m *OrderList) String() string { return proto.CompactTextString(m) }
 func (*OrderList) ProtoMessage()    {}
 func (*OrderList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{2}
+	return fileDescriptor_b5e409e9578376a3, []int{3}
 }
 
 func (m *OrderList) XXX_Unmarshal(b []byte) error {
@@ -276,1282 +497,4761 @@ func (m *OrderList) GetOrders() []*Order {
 	return nil
 }
 
-type Channel struct {
-	Id                   []byte          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Options              *ChannelOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+type StreamFrame struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReplyTo              uint64   `protobuf:"varint,2,opt,name=replyTo,proto3" json:"replyTo,omitempty"`
+	Request              bool     `protobuf:"varint,3,opt,name=request,proto3" json:"request,omitempty"`
+	Data                 []byte   `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Channel) Reset()         { *m = Channel{} }
-func (m *Channel) String() string { return proto.CompactTextString(m) }
-func (*Channel) ProtoMessage()    {}
-func (*Channel) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{3}
+func (m *StreamFrame) Reset()         { *m = StreamFrame{} }
+func (m *StreamFrame) String() string { return proto.CompactTextString(m) }
+func (*StreamFrame) ProtoMessage()    {}
+func (*StreamFrame) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{4}
 }
 
-func (m *Channel) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Channel.Unmarshal(m, b)
+func (m *StreamFrame) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamFrame.Unmarshal(m, b)
 }
-func (m *Channel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Channel.Marshal(b, m, deterministic)
+func (m *StreamFrame) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamFrame.Marshal(b, m, deterministic)
 }
-func (m *Channel) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Channel.Merge(m, src)
+func (m *StreamFrame) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamFrame.Merge(m, src)
 }
-func (m *Channel) XXX_Size() int {
-	return xxx_messageInfo_Channel.Size(m)
+func (m *StreamFrame) XXX_Size() int {
+	return xxx_messageInfo_StreamFrame.Size(m)
 }
-func (m *Channel) XXX_DiscardUnknown() {
-	xxx_messageInfo_Channel.DiscardUnknown(m)
+func (m *StreamFrame) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamFrame.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Channel proto.InternalMessageInfo
+var xxx_messageInfo_StreamFrame proto.InternalMessageInfo
 
-func (m *Channel) GetId() []byte {
+func (m *StreamFrame) GetId() uint64 {
 	if m != nil {
 		return m.Id
 	}
-	return nil
+	return 0
 }
 
-func (m *Channel) GetOptions() *ChannelOptions {
+func (m *StreamFrame) GetReplyTo() uint64 {
 	if m != nil {
-		return m.Options
+		return m.ReplyTo
+	}
+	return 0
+}
+
+func (m *StreamFrame) GetRequest() bool {
+	if m != nil {
+		return m.Request
+	}
+	return false
+}
+
+func (m *StreamFrame) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
 	return nil
 }
 
-type ChannelList struct {
-	Channels             []*Channel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+type Tombstone struct {
+	OrderID              []byte               `protobuf:"bytes,1,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	Nonce                uint32               `protobuf:"varint,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Deleted              *timestamp.Timestamp `protobuf:"bytes,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Order                *Order               `protobuf:"bytes,4,opt,name=order,proto3" json:"order,omitempty"`
+	Signature            []byte               `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *ChannelList) Reset()         { *m = ChannelList{} }
-func (m *ChannelList) String() string { return proto.CompactTextString(m) }
-func (*ChannelList) ProtoMessage()    {}
-func (*ChannelList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{4}
+func (m *Tombstone) Reset()         { *m = Tombstone{} }
+func (m *Tombstone) String() string { return proto.CompactTextString(m) }
+func (*Tombstone) ProtoMessage()    {}
+func (*Tombstone) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{5}
 }
 
-func (m *ChannelList) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ChannelList.Unmarshal(m, b)
+func (m *Tombstone) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tombstone.Unmarshal(m, b)
 }
-func (m *ChannelList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ChannelList.Marshal(b, m, deterministic)
+func (m *Tombstone) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tombstone.Marshal(b, m, deterministic)
 }
-func (m *ChannelList) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChannelList.Merge(m, src)
+func (m *Tombstone) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tombstone.Merge(m, src)
 }
-func (m *ChannelList) XXX_Size() int {
-	return xxx_messageInfo_ChannelList.Size(m)
+func (m *Tombstone) XXX_Size() int {
+	return xxx_messageInfo_Tombstone.Size(m)
 }
-func (m *ChannelList) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChannelList.DiscardUnknown(m)
+func (m *Tombstone) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tombstone.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ChannelList proto.InternalMessageInfo
+var xxx_messageInfo_Tombstone proto.InternalMessageInfo
 
-func (m *ChannelList) GetChannels() []*Channel {
+func (m *Tombstone) GetOrderID() []byte {
 	if m != nil {
-		return m.Channels
+		return m.OrderID
 	}
 	return nil
 }
 
-type Recipient struct {
-	PeerID               []byte   `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+func (m *Tombstone) GetNonce() uint32 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *Tombstone) GetDeleted() *timestamp.Timestamp {
+	if m != nil {
+		return m.Deleted
+	}
+	return nil
+}
+
+func (m *Tombstone) GetOrder() *Order {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+func (m *Tombstone) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type SyncBucket struct {
+	Index                uint32   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Fingerprint          []byte   `protobuf:"bytes,2,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Recipient) Reset()         { *m = Recipient{} }
-func (m *Recipient) String() string { return proto.CompactTextString(m) }
-func (*Recipient) ProtoMessage()    {}
-func (*Recipient) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{5}
+func (m *SyncBucket) Reset()         { *m = SyncBucket{} }
+func (m *SyncBucket) String() string { return proto.CompactTextString(m) }
+func (*SyncBucket) ProtoMessage()    {}
+func (*SyncBucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{6}
 }
 
-func (m *Recipient) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Recipient.Unmarshal(m, b)
+func (m *SyncBucket) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncBucket.Unmarshal(m, b)
 }
-func (m *Recipient) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Recipient.Marshal(b, m, deterministic)
+func (m *SyncBucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncBucket.Marshal(b, m, deterministic)
 }
-func (m *Recipient) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Recipient.Merge(m, src)
+func (m *SyncBucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncBucket.Merge(m, src)
 }
-func (m *Recipient) XXX_Size() int {
-	return xxx_messageInfo_Recipient.Size(m)
+func (m *SyncBucket) XXX_Size() int {
+	return xxx_messageInfo_SyncBucket.Size(m)
 }
-func (m *Recipient) XXX_DiscardUnknown() {
-	xxx_messageInfo_Recipient.DiscardUnknown(m)
+func (m *SyncBucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncBucket.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Recipient proto.InternalMessageInfo
+var xxx_messageInfo_SyncBucket proto.InternalMessageInfo
 
-func (m *Recipient) GetPeerID() []byte {
+func (m *SyncBucket) GetIndex() uint32 {
 	if m != nil {
-		return m.PeerID
+		return m.Index
+	}
+	return 0
+}
+
+func (m *SyncBucket) GetFingerprint() []byte {
+	if m != nil {
+		return m.Fingerprint
 	}
 	return nil
 }
 
-type WireMessage struct {
-	ChannelID            []byte    `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
-	Operation            Operation `protobuf:"varint,2,opt,name=operation,proto3,enum=pb.Operation" json:"operation,omitempty"`
-	Data                 []byte    `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type SyncFingerprints struct {
+	Buckets              []*SyncBucket `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *WireMessage) Reset()         { *m = WireMessage{} }
-func (m *WireMessage) String() string { return proto.CompactTextString(m) }
-func (*WireMessage) ProtoMessage()    {}
-func (*WireMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{6}
+func (m *SyncFingerprints) Reset()         { *m = SyncFingerprints{} }
+func (m *SyncFingerprints) String() string { return proto.CompactTextString(m) }
+func (*SyncFingerprints) ProtoMessage()    {}
+func (*SyncFingerprints) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{7}
 }
 
-func (m *WireMessage) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_WireMessage.Unmarshal(m, b)
+func (m *SyncFingerprints) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncFingerprints.Unmarshal(m, b)
 }
-func (m *WireMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_WireMessage.Marshal(b, m, deterministic)
+func (m *SyncFingerprints) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncFingerprints.Marshal(b, m, deterministic)
 }
-func (m *WireMessage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_WireMessage.Merge(m, src)
+func (m *SyncFingerprints) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncFingerprints.Merge(m, src)
 }
-func (m *WireMessage) XXX_Size() int {
-	return xxx_messageInfo_WireMessage.Size(m)
+func (m *SyncFingerprints) XXX_Size() int {
+	return xxx_messageInfo_SyncFingerprints.Size(m)
 }
-func (m *WireMessage) XXX_DiscardUnknown() {
-	xxx_messageInfo_WireMessage.DiscardUnknown(m)
+func (m *SyncFingerprints) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncFingerprints.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_WireMessage proto.InternalMessageInfo
+var xxx_messageInfo_SyncFingerprints proto.InternalMessageInfo
 
-func (m *WireMessage) GetChannelID() []byte {
+func (m *SyncFingerprints) GetBuckets() []*SyncBucket {
 	if m != nil {
-		return m.ChannelID
+		return m.Buckets
 	}
 	return nil
 }
 
-func (m *WireMessage) GetOperation() Operation {
-	if m != nil {
-		return m.Operation
-	}
-	return Operation_CREATE
-}
-
-func (m *WireMessage) GetData() []byte {
-	if m != nil {
-		return m.Data
-	}
-	return nil
-}
-
-type CreateRequest struct {
-	ChannelID            []byte   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
-	Asset                string   `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
-	CounterAsset         string   `protobuf:"bytes,3,opt,name=counterAsset,proto3" json:"counterAsset,omitempty"`
-	Amount               uint64   `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	Price                float32  `protobuf:"fixed32,5,opt,name=price,proto3" json:"price,omitempty"`
+type SyncItem struct {
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Nonce                uint32   `protobuf:"varint,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Deleted              bool     `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
-func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateRequest) ProtoMessage()    {}
-func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{7}
+func (m *SyncItem) Reset()         { *m = SyncItem{} }
+func (m *SyncItem) String() string { return proto.CompactTextString(m) }
+func (*SyncItem) ProtoMessage()    {}
+func (*SyncItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{8}
 }
 
-func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
+func (m *SyncItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncItem.Unmarshal(m, b)
 }
-func (m *CreateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateRequest.Marshal(b, m, deterministic)
+func (m *SyncItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncItem.Marshal(b, m, deterministic)
 }
-func (m *CreateRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateRequest.Merge(m, src)
+func (m *SyncItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncItem.Merge(m, src)
 }
-func (m *CreateRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateRequest.Size(m)
+func (m *SyncItem) XXX_Size() int {
+	return xxx_messageInfo_SyncItem.Size(m)
 }
-func (m *CreateRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateRequest.DiscardUnknown(m)
+func (m *SyncItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncItem.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateRequest proto.InternalMessageInfo
+var xxx_messageInfo_SyncItem proto.InternalMessageInfo
 
-func (m *CreateRequest) GetChannelID() []byte {
+func (m *SyncItem) GetId() []byte {
 	if m != nil {
-		return m.ChannelID
+		return m.Id
 	}
 	return nil
 }
 
-func (m *CreateRequest) GetAsset() string {
-	if m != nil {
-		return m.Asset
-	}
-	return ""
-}
-
-func (m *CreateRequest) GetCounterAsset() string {
-	if m != nil {
-		return m.CounterAsset
-	}
-	return ""
-}
-
-func (m *CreateRequest) GetAmount() uint64 {
+func (m *SyncItem) GetNonce() uint32 {
 	if m != nil {
-		return m.Amount
+		return m.Nonce
 	}
 	return 0
 }
 
-func (m *CreateRequest) GetPrice() float32 {
+func (m *SyncItem) GetDeleted() bool {
 	if m != nil {
-		return m.Price
+		return m.Deleted
 	}
-	return 0
+	return false
 }
 
-type JoinRequest struct {
-	Asset                string   `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
-	CounterAsset         string   `protobuf:"bytes,2,opt,name=counterAsset,proto3" json:"counterAsset,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type SyncDigest struct {
+	Buckets              []uint32    `protobuf:"varint,1,rep,packed,name=buckets,proto3" json:"buckets,omitempty"`
+	Items                []*SyncItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *JoinRequest) Reset()         { *m = JoinRequest{} }
-func (m *JoinRequest) String() string { return proto.CompactTextString(m) }
-func (*JoinRequest) ProtoMessage()    {}
-func (*JoinRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{8}
+func (m *SyncDigest) Reset()         { *m = SyncDigest{} }
+func (m *SyncDigest) String() string { return proto.CompactTextString(m) }
+func (*SyncDigest) ProtoMessage()    {}
+func (*SyncDigest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{9}
 }
 
-func (m *JoinRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_JoinRequest.Unmarshal(m, b)
+func (m *SyncDigest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncDigest.Unmarshal(m, b)
 }
-func (m *JoinRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_JoinRequest.Marshal(b, m, deterministic)
+func (m *SyncDigest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncDigest.Marshal(b, m, deterministic)
 }
-func (m *JoinRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_JoinRequest.Merge(m, src)
+func (m *SyncDigest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncDigest.Merge(m, src)
 }
-func (m *JoinRequest) XXX_Size() int {
-	return xxx_messageInfo_JoinRequest.Size(m)
+func (m *SyncDigest) XXX_Size() int {
+	return xxx_messageInfo_SyncDigest.Size(m)
 }
-func (m *JoinRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_JoinRequest.DiscardUnknown(m)
+func (m *SyncDigest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncDigest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_JoinRequest proto.InternalMessageInfo
+var xxx_messageInfo_SyncDigest proto.InternalMessageInfo
 
-func (m *JoinRequest) GetAsset() string {
+func (m *SyncDigest) GetBuckets() []uint32 {
 	if m != nil {
-		return m.Asset
+		return m.Buckets
 	}
-	return ""
+	return nil
 }
 
-func (m *JoinRequest) GetCounterAsset() string {
+func (m *SyncDigest) GetItems() []*SyncItem {
 	if m != nil {
-		return m.CounterAsset
+		return m.Items
 	}
-	return ""
+	return nil
 }
 
-type ChannelOptions struct {
-	AssetPair            string   `protobuf:"bytes,1,opt,name=assetPair,proto3" json:"assetPair,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type SyncUpdate struct {
+	Orders               []*Order     `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Tombstones           []*Tombstone `protobuf:"bytes,2,rep,name=tombstones,proto3" json:"tombstones,omitempty"`
+	Wanted               [][]byte     `protobuf:"bytes,3,rep,name=wanted,proto3" json:"wanted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *ChannelOptions) Reset()         { *m = ChannelOptions{} }
-func (m *ChannelOptions) String() string { return proto.CompactTextString(m) }
-func (*ChannelOptions) ProtoMessage()    {}
-func (*ChannelOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{9}
+func (m *SyncUpdate) Reset()         { *m = SyncUpdate{} }
+func (m *SyncUpdate) String() string { return proto.CompactTextString(m) }
+func (*SyncUpdate) ProtoMessage()    {}
+func (*SyncUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{10}
 }
 
-func (m *ChannelOptions) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ChannelOptions.Unmarshal(m, b)
+func (m *SyncUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncUpdate.Unmarshal(m, b)
 }
-func (m *ChannelOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ChannelOptions.Marshal(b, m, deterministic)
+func (m *SyncUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncUpdate.Marshal(b, m, deterministic)
 }
-func (m *ChannelOptions) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChannelOptions.Merge(m, src)
+func (m *SyncUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncUpdate.Merge(m, src)
 }
-func (m *ChannelOptions) XXX_Size() int {
-	return xxx_messageInfo_ChannelOptions.Size(m)
+func (m *SyncUpdate) XXX_Size() int {
+	return xxx_messageInfo_SyncUpdate.Size(m)
 }
-func (m *ChannelOptions) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChannelOptions.DiscardUnknown(m)
+func (m *SyncUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncUpdate.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ChannelOptions proto.InternalMessageInfo
+var xxx_messageInfo_SyncUpdate proto.InternalMessageInfo
 
-func (m *ChannelOptions) GetAssetPair() string {
+func (m *SyncUpdate) GetOrders() []*Order {
 	if m != nil {
-		return m.AssetPair
+		return m.Orders
 	}
-	return ""
+	return nil
 }
 
-type OrderSpecificRequest struct {
-	OrderID              []byte   `protobuf:"bytes,1,opt,name=orderID,proto3" json:"orderID,omitempty"`
-	ChannelID            []byte   `protobuf:"bytes,2,opt,name=channelID,proto3" json:"channelID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *SyncUpdate) GetTombstones() []*Tombstone {
+	if m != nil {
+		return m.Tombstones
+	}
+	return nil
 }
 
-func (m *OrderSpecificRequest) Reset()         { *m = OrderSpecificRequest{} }
-func (m *OrderSpecificRequest) String() string { return proto.CompactTextString(m) }
-func (*OrderSpecificRequest) ProtoMessage()    {}
-func (*OrderSpecificRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{10}
+func (m *SyncUpdate) GetWanted() [][]byte {
+	if m != nil {
+		return m.Wanted
+	}
+	return nil
 }
 
-func (m *OrderSpecificRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OrderSpecificRequest.Unmarshal(m, b)
+type QuoteRequest struct {
+	Id                   []byte               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChannelID            []byte               `protobuf:"bytes,2,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Requester            string               `protobuf:"bytes,3,opt,name=requester,proto3" json:"requester,omitempty"`
+	Asset                string               `protobuf:"bytes,4,opt,name=asset,proto3" json:"asset,omitempty"`
+	CounterAsset         string               `protobuf:"bytes,5,opt,name=counterAsset,proto3" json:"counterAsset,omitempty"`
+	Amount               uint64               `protobuf:"varint,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Created              *timestamp.Timestamp `protobuf:"bytes,7,opt,name=created,proto3" json:"created,omitempty"`
+	Expires              *timestamp.Timestamp `protobuf:"bytes,8,opt,name=expires,proto3" json:"expires,omitempty"`
+	Signature            []byte               `protobuf:"bytes,9,opt,name=signature,proto3" json:"signature,omitempty"`
+	AcceptedQuoteID      []byte               `protobuf:"bytes,10,opt,name=acceptedQuoteID,proto3" json:"acceptedQuoteID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
-func (m *OrderSpecificRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OrderSpecificRequest.Marshal(b, m, deterministic)
+
+func (m *QuoteRequest) Reset()         { *m = QuoteRequest{} }
+func (m *QuoteRequest) String() string { return proto.CompactTextString(m) }
+func (*QuoteRequest) ProtoMessage()    {}
+func (*QuoteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{11}
 }
-func (m *OrderSpecificRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OrderSpecificRequest.Merge(m, src)
+
+func (m *QuoteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuoteRequest.Unmarshal(m, b)
 }
-func (m *OrderSpecificRequest) XXX_Size() int {
-	return xxx_messageInfo_OrderSpecificRequest.Size(m)
+func (m *QuoteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuoteRequest.Marshal(b, m, deterministic)
 }
-func (m *OrderSpecificRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_OrderSpecificRequest.DiscardUnknown(m)
+func (m *QuoteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuoteRequest.Merge(m, src)
+}
+func (m *QuoteRequest) XXX_Size() int {
+	return xxx_messageInfo_QuoteRequest.Size(m)
+}
+func (m *QuoteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuoteRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OrderSpecificRequest proto.InternalMessageInfo
+var xxx_messageInfo_QuoteRequest proto.InternalMessageInfo
 
-func (m *OrderSpecificRequest) GetOrderID() []byte {
+func (m *QuoteRequest) GetId() []byte {
 	if m != nil {
-		return m.OrderID
+		return m.Id
 	}
 	return nil
 }
 
-func (m *OrderSpecificRequest) GetChannelID() []byte {
+func (m *QuoteRequest) GetChannelID() []byte {
 	if m != nil {
 		return m.ChannelID
 	}
 	return nil
 }
 
-type ChannelSpecificRequest struct {
-	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *QuoteRequest) GetRequester() string {
+	if m != nil {
+		return m.Requester
+	}
+	return ""
 }
 
-func (m *ChannelSpecificRequest) Reset()         { *m = ChannelSpecificRequest{} }
-func (m *ChannelSpecificRequest) String() string { return proto.CompactTextString(m) }
-func (*ChannelSpecificRequest) ProtoMessage()    {}
-func (*ChannelSpecificRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{11}
+func (m *QuoteRequest) GetAsset() string {
+	if m != nil {
+		return m.Asset
+	}
+	return ""
 }
 
-func (m *ChannelSpecificRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ChannelSpecificRequest.Unmarshal(m, b)
-}
-func (m *ChannelSpecificRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ChannelSpecificRequest.Marshal(b, m, deterministic)
+func (m *QuoteRequest) GetCounterAsset() string {
+	if m != nil {
+		return m.CounterAsset
+	}
+	return ""
 }
-func (m *ChannelSpecificRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChannelSpecificRequest.Merge(m, src)
+
+func (m *QuoteRequest) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
 }
-func (m *ChannelSpecificRequest) XXX_Size() int {
-	return xxx_messageInfo_ChannelSpecificRequest.Size(m)
+
+func (m *QuoteRequest) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
 }
-func (m *ChannelSpecificRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChannelSpecificRequest.DiscardUnknown(m)
+
+func (m *QuoteRequest) GetExpires() *timestamp.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
 }
 
-var xxx_messageInfo_ChannelSpecificRequest proto.InternalMessageInfo
+func (m *QuoteRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
 
-func (m *ChannelSpecificRequest) GetId() []byte {
+func (m *QuoteRequest) GetAcceptedQuoteID() []byte {
 	if m != nil {
-		return m.Id
+		return m.AcceptedQuoteID
 	}
 	return nil
 }
 
-type CreateResponse struct {
-	CreatedOrder         *Order   `protobuf:"bytes,1,opt,name=createdOrder,proto3" json:"createdOrder,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type QuoteRequestList struct {
+	Requests             []*QuoteRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *CreateResponse) Reset()         { *m = CreateResponse{} }
-func (m *CreateResponse) String() string { return proto.CompactTextString(m) }
-func (*CreateResponse) ProtoMessage()    {}
-func (*CreateResponse) Descriptor() ([]byte, []int) {
+func (m *QuoteRequestList) Reset()         { *m = QuoteRequestList{} }
+func (m *QuoteRequestList) String() string { return proto.CompactTextString(m) }
+func (*QuoteRequestList) ProtoMessage()    {}
+func (*QuoteRequestList) Descriptor() ([]byte, []int) {
 	return fileDescriptor_b5e409e9578376a3, []int{12}
 }
 
-func (m *CreateResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateResponse.Unmarshal(m, b)
+func (m *QuoteRequestList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuoteRequestList.Unmarshal(m, b)
 }
-func (m *CreateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateResponse.Marshal(b, m, deterministic)
+func (m *QuoteRequestList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuoteRequestList.Marshal(b, m, deterministic)
 }
-func (m *CreateResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateResponse.Merge(m, src)
+func (m *QuoteRequestList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuoteRequestList.Merge(m, src)
 }
-func (m *CreateResponse) XXX_Size() int {
-	return xxx_messageInfo_CreateResponse.Size(m)
+func (m *QuoteRequestList) XXX_Size() int {
+	return xxx_messageInfo_QuoteRequestList.Size(m)
 }
-func (m *CreateResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateResponse.DiscardUnknown(m)
+func (m *QuoteRequestList) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuoteRequestList.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateResponse proto.InternalMessageInfo
+var xxx_messageInfo_QuoteRequestList proto.InternalMessageInfo
 
-func (m *CreateResponse) GetCreatedOrder() *Order {
+func (m *QuoteRequestList) GetRequests() []*QuoteRequest {
 	if m != nil {
-		return m.CreatedOrder
+		return m.Requests
 	}
 	return nil
 }
 
-type OrderListResponse struct {
-	Orders               []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type Quote struct {
+	Id                   []byte               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RequestID            []byte               `protobuf:"bytes,2,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	ChannelID            []byte               `protobuf:"bytes,3,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Quoter               string               `protobuf:"bytes,4,opt,name=quoter,proto3" json:"quoter,omitempty"`
+	Order                *Order               `protobuf:"bytes,5,opt,name=order,proto3" json:"order,omitempty"`
+	Expires              *timestamp.Timestamp `protobuf:"bytes,6,opt,name=expires,proto3" json:"expires,omitempty"`
+	Signature            []byte               `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *OrderListResponse) Reset()         { *m = OrderListResponse{} }
-func (m *OrderListResponse) String() string { return proto.CompactTextString(m) }
-func (*OrderListResponse) ProtoMessage()    {}
-func (*OrderListResponse) Descriptor() ([]byte, []int) {
+func (m *Quote) Reset()         { *m = Quote{} }
+func (m *Quote) String() string { return proto.CompactTextString(m) }
+func (*Quote) ProtoMessage()    {}
+func (*Quote) Descriptor() ([]byte, []int) {
 	return fileDescriptor_b5e409e9578376a3, []int{13}
 }
 
-func (m *OrderListResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OrderListResponse.Unmarshal(m, b)
+func (m *Quote) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Quote.Unmarshal(m, b)
 }
-func (m *OrderListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OrderListResponse.Marshal(b, m, deterministic)
+func (m *Quote) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Quote.Marshal(b, m, deterministic)
 }
-func (m *OrderListResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OrderListResponse.Merge(m, src)
+func (m *Quote) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Quote.Merge(m, src)
 }
-func (m *OrderListResponse) XXX_Size() int {
-	return xxx_messageInfo_OrderListResponse.Size(m)
+func (m *Quote) XXX_Size() int {
+	return xxx_messageInfo_Quote.Size(m)
 }
-func (m *OrderListResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_OrderListResponse.DiscardUnknown(m)
+func (m *Quote) XXX_DiscardUnknown() {
+	xxx_messageInfo_Quote.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OrderListResponse proto.InternalMessageInfo
+var xxx_messageInfo_Quote proto.InternalMessageInfo
 
-func (m *OrderListResponse) GetOrders() []*Order {
+func (m *Quote) GetId() []byte {
 	if m != nil {
-		return m.Orders
+		return m.Id
 	}
 	return nil
 }
 
-type ChannelListResponse struct {
-	Channels             []*Channel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (m *Quote) GetRequestID() []byte {
+	if m != nil {
+		return m.RequestID
+	}
+	return nil
 }
 
-func (m *ChannelListResponse) Reset()         { *m = ChannelListResponse{} }
-func (m *ChannelListResponse) String() string { return proto.CompactTextString(m) }
-func (*ChannelListResponse) ProtoMessage()    {}
-func (*ChannelListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{14}
+func (m *Quote) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
 }
 
-func (m *ChannelListResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ChannelListResponse.Unmarshal(m, b)
-}
-func (m *ChannelListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ChannelListResponse.Marshal(b, m, deterministic)
-}
-func (m *ChannelListResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChannelListResponse.Merge(m, src)
-}
-func (m *ChannelListResponse) XXX_Size() int {
-	return xxx_messageInfo_ChannelListResponse.Size(m)
+func (m *Quote) GetQuoter() string {
+	if m != nil {
+		return m.Quoter
+	}
+	return ""
 }
-func (m *ChannelListResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChannelListResponse.DiscardUnknown(m)
+
+func (m *Quote) GetOrder() *Order {
+	if m != nil {
+		return m.Order
+	}
+	return nil
 }
 
-var xxx_messageInfo_ChannelListResponse proto.InternalMessageInfo
+func (m *Quote) GetExpires() *timestamp.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
 
-func (m *ChannelListResponse) GetChannels() []*Channel {
+func (m *Quote) GetSignature() []byte {
 	if m != nil {
-		return m.Channels
+		return m.Signature
 	}
 	return nil
 }
 
-type PeerListResponse struct {
-	PeerIDs              []string `protobuf:"bytes,1,rep,name=peerIDs,proto3" json:"peerIDs,omitempty"`
+type QuoteList struct {
+	Quotes               []*Quote `protobuf:"bytes,1,rep,name=quotes,proto3" json:"quotes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PeerListResponse) Reset()         { *m = PeerListResponse{} }
-func (m *PeerListResponse) String() string { return proto.CompactTextString(m) }
-func (*PeerListResponse) ProtoMessage()    {}
-func (*PeerListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{15}
+func (m *QuoteList) Reset()         { *m = QuoteList{} }
+func (m *QuoteList) String() string { return proto.CompactTextString(m) }
+func (*QuoteList) ProtoMessage()    {}
+func (*QuoteList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{14}
 }
 
-func (m *PeerListResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PeerListResponse.Unmarshal(m, b)
+func (m *QuoteList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuoteList.Unmarshal(m, b)
 }
-func (m *PeerListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PeerListResponse.Marshal(b, m, deterministic)
+func (m *QuoteList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuoteList.Marshal(b, m, deterministic)
 }
-func (m *PeerListResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PeerListResponse.Merge(m, src)
+func (m *QuoteList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuoteList.Merge(m, src)
 }
-func (m *PeerListResponse) XXX_Size() int {
-	return xxx_messageInfo_PeerListResponse.Size(m)
+func (m *QuoteList) XXX_Size() int {
+	return xxx_messageInfo_QuoteList.Size(m)
 }
-func (m *PeerListResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_PeerListResponse.DiscardUnknown(m)
+func (m *QuoteList) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuoteList.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PeerListResponse proto.InternalMessageInfo
+var xxx_messageInfo_QuoteList proto.InternalMessageInfo
 
-func (m *PeerListResponse) GetPeerIDs() []string {
+func (m *QuoteList) GetQuotes() []*Quote {
 	if m != nil {
-		return m.PeerIDs
+		return m.Quotes
 	}
 	return nil
 }
 
-type JoinResponse struct {
-	JoinedChannel        *Channel `protobuf:"bytes,1,opt,name=joinedChannel,proto3" json:"joinedChannel,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type Channel struct {
+	Id                   []byte          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Options              *ChannelOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *JoinResponse) Reset()         { *m = JoinResponse{} }
-func (m *JoinResponse) String() string { return proto.CompactTextString(m) }
-func (*JoinResponse) ProtoMessage()    {}
-func (*JoinResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{16}
+func (m *Channel) Reset()         { *m = Channel{} }
+func (m *Channel) String() string { return proto.CompactTextString(m) }
+func (*Channel) ProtoMessage()    {}
+func (*Channel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{15}
 }
 
-func (m *JoinResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_JoinResponse.Unmarshal(m, b)
+func (m *Channel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Channel.Unmarshal(m, b)
 }
-func (m *JoinResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_JoinResponse.Marshal(b, m, deterministic)
+func (m *Channel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Channel.Marshal(b, m, deterministic)
 }
-func (m *JoinResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_JoinResponse.Merge(m, src)
+func (m *Channel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Channel.Merge(m, src)
 }
-func (m *JoinResponse) XXX_Size() int {
-	return xxx_messageInfo_JoinResponse.Size(m)
+func (m *Channel) XXX_Size() int {
+	return xxx_messageInfo_Channel.Size(m)
 }
-func (m *JoinResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_JoinResponse.DiscardUnknown(m)
+func (m *Channel) XXX_DiscardUnknown() {
+	xxx_messageInfo_Channel.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_JoinResponse proto.InternalMessageInfo
+var xxx_messageInfo_Channel proto.InternalMessageInfo
 
-func (m *JoinResponse) GetJoinedChannel() *Channel {
+func (m *Channel) GetId() []byte {
 	if m != nil {
-		return m.JoinedChannel
+		return m.Id
 	}
 	return nil
 }
 
-type Empty struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Channel) GetOptions() *ChannelOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
 }
 
-func (m *Empty) Reset()         { *m = Empty{} }
-func (m *Empty) String() string { return proto.CompactTextString(m) }
-func (*Empty) ProtoMessage()    {}
-func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{17}
+type ChannelList struct {
+	Channels             []*Channel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
-func (m *Empty) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Empty.Unmarshal(m, b)
+func (m *ChannelList) Reset()         { *m = ChannelList{} }
+func (m *ChannelList) String() string { return proto.CompactTextString(m) }
+func (*ChannelList) ProtoMessage()    {}
+func (*ChannelList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{16}
 }
-func (m *Empty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Empty.Marshal(b, m, deterministic)
+
+func (m *ChannelList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelList.Unmarshal(m, b)
 }
-func (m *Empty) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Empty.Merge(m, src)
+func (m *ChannelList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelList.Marshal(b, m, deterministic)
 }
-func (m *Empty) XXX_Size() int {
-	return xxx_messageInfo_Empty.Size(m)
+func (m *ChannelList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelList.Merge(m, src)
 }
-func (m *Empty) XXX_DiscardUnknown() {
-	xxx_messageInfo_Empty.DiscardUnknown(m)
+func (m *ChannelList) XXX_Size() int {
+	return xxx_messageInfo_ChannelList.Size(m)
 }
-
-var xxx_messageInfo_Empty proto.InternalMessageInfo
-
-func init() {
-	proto.RegisterEnum("pb.State", State_name, State_value)
-	proto.RegisterEnum("pb.Operation", Operation_name, Operation_value)
-	proto.RegisterType((*Peer)(nil), "pb.Peer")
-	proto.RegisterType((*Order)(nil), "pb.Order")
-	proto.RegisterType((*OrderList)(nil), "pb.OrderList")
-	proto.RegisterType((*Channel)(nil), "pb.Channel")
-	proto.RegisterType((*ChannelList)(nil), "pb.ChannelList")
-	proto.RegisterType((*Recipient)(nil), "pb.Recipient")
-	proto.RegisterType((*WireMessage)(nil), "pb.WireMessage")
-	proto.RegisterType((*CreateRequest)(nil), "pb.CreateRequest")
-	proto.RegisterType((*JoinRequest)(nil), "pb.JoinRequest")
-	proto.RegisterType((*ChannelOptions)(nil), "pb.ChannelOptions")
-	proto.RegisterType((*OrderSpecificRequest)(nil), "pb.OrderSpecificRequest")
-	proto.RegisterType((*ChannelSpecificRequest)(nil), "pb.ChannelSpecificRequest")
-	proto.RegisterType((*CreateResponse)(nil), "pb.CreateResponse")
-	proto.RegisterType((*OrderListResponse)(nil), "pb.OrderListResponse")
-	proto.RegisterType((*ChannelListResponse)(nil), "pb.ChannelListResponse")
-	proto.RegisterType((*PeerListResponse)(nil), "pb.PeerListResponse")
-	proto.RegisterType((*JoinResponse)(nil), "pb.JoinResponse")
-	proto.RegisterType((*Empty)(nil), "pb.Empty")
+func (m *ChannelList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelList.DiscardUnknown(m)
 }
 
-func init() { proto.RegisterFile("sprawl.proto", fileDescriptor_b5e409e9578376a3) }
+var xxx_messageInfo_ChannelList proto.InternalMessageInfo
 
-var fileDescriptor_b5e409e9578376a3 = []byte{
-	// 886 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xef, 0x6e, 0xe3, 0x44,
-	0x10, 0xc7, 0x8e, 0x9d, 0xc4, 0x93, 0x3f, 0xe7, 0x5b, 0xaa, 0xca, 0x8a, 0x40, 0x17, 0x16, 0x24,
-	0xcc, 0x5d, 0xcf, 0x15, 0x01, 0xee, 0x23, 0xa8, 0xa4, 0x56, 0x38, 0x08, 0x69, 0x71, 0x5b, 0x10,
-	0x1f, 0x10, 0xda, 0xda, 0x73, 0xc5, 0x9c, 0x63, 0x1b, 0x7b, 0x0b, 0xe2, 0x41, 0x78, 0x04, 0x78,
-	0x1e, 0x1e, 0x09, 0xed, 0xae, 0xed, 0xd8, 0x2d, 0xca, 0xf5, 0x9b, 0x67, 0xe6, 0xf7, 0x9b, 0xd9,
-	0x99, 0xfd, 0xcd, 0x26, 0x30, 0x2e, 0xf3, 0x82, 0xfd, 0x91, 0x78, 0x79, 0x91, 0xf1, 0x8c, 0xe8,
-	0xf9, 0xf5, 0xec, 0xc9, 0x4d, 0x96, 0xdd, 0x24, 0x78, 0x2c, 0x3d, 0xd7, 0xb7, 0xaf, 0x8e, 0x79,
-	0xbc, 0xc5, 0x92, 0xb3, 0x6d, 0xae, 0x40, 0xf4, 0x10, 0x8c, 0x73, 0xc4, 0x82, 0x4c, 0x41, 0x8f,
-	0x23, 0x47, 0x9b, 0x6b, 0xae, 0x15, 0xe8, 0x71, 0x44, 0xff, 0xd1, 0xc1, 0x3c, 0x2b, 0xa2, 0x4e,
-	0x64, 0x2c, 0x22, 0xe4, 0x53, 0x18, 0x84, 0x05, 0x32, 0x8e, 0x91, 0xa3, 0xcf, 0x35, 0x77, 0xb4,
-	0x98, 0x79, 0xaa, 0x88, 0x57, 0x17, 0xf1, 0x2e, 0xeb, 0x22, 0x41, 0x0d, 0x25, 0x07, 0x60, 0xb2,
-	0xb2, 0x44, 0xee, 0xf4, 0x64, 0x09, 0x65, 0x10, 0x0a, 0xe3, 0x30, 0xbb, 0x4d, 0x39, 0x16, 0x27,
-	0x32, 0x68, 0xc8, 0x60, 0xc7, 0x47, 0x0e, 0xa1, 0xcf, 0xb6, 0xc2, 0xe1, 0x98, 0x73, 0xcd, 0x35,
-	0x82, 0xca, 0x12, 0x19, 0xf3, 0x22, 0x0e, 0xd1, 0xe9, 0xcf, 0x35, 0x57, 0x0f, 0x94, 0x41, 0x9e,
-	0x80, 0x59, 0x72, 0xc6, 0xd1, 0x19, 0xcc, 0x35, 0x77, 0xba, 0xb0, 0xbc, 0xfc, 0xda, 0xbb, 0x10,
-	0x8e, 0x40, 0xf9, 0xc9, 0x3b, 0x60, 0x95, 0xf1, 0x4d, 0xca, 0xf8, 0x6d, 0x81, 0xce, 0x50, 0x76,
-	0xb5, 0x73, 0x88, 0xa4, 0x69, 0x96, 0x86, 0xe8, 0x58, 0x73, 0xcd, 0x9d, 0x04, 0xca, 0x20, 0x33,
-	0x18, 0x6e, 0x91, 0xb3, 0x88, 0x71, 0xe6, 0x80, 0xa4, 0x34, 0x36, 0xf5, 0xc0, 0x92, 0x73, 0x5a,
-	0xc7, 0x25, 0x27, 0xef, 0x41, 0x3f, 0x13, 0x46, 0xe9, 0x68, 0xf3, 0x9e, 0x3b, 0x52, 0xe5, 0x65,
-	0x38, 0xa8, 0x02, 0x74, 0x05, 0x83, 0xe5, 0x2f, 0x2c, 0x4d, 0x31, 0xb9, 0x37, 0xd9, 0x23, 0x18,
-	0x64, 0x39, 0x8f, 0xb3, 0xb4, 0xac, 0x26, 0x4b, 0x04, 0xbd, 0x42, 0x9f, 0xa9, 0x48, 0x50, 0x43,
-	0xe8, 0x0b, 0x18, 0x55, 0x21, 0x59, 0xfa, 0x43, 0x18, 0x86, 0xca, 0xac, 0x8b, 0x8f, 0x5a, 0xec,
-	0xa0, 0x09, 0xd2, 0xf7, 0xc1, 0x0a, 0x30, 0x8c, 0xf3, 0x18, 0x53, 0x39, 0xdc, 0x1c, 0xb1, 0x78,
-	0x79, 0x5a, 0x1d, 0xa3, 0xb2, 0x68, 0x02, 0xa3, 0x1f, 0xe2, 0x02, 0xbf, 0xc5, 0xb2, 0x64, 0x37,
-	0x72, 0x68, 0x15, 0xbf, 0x41, 0xee, 0x1c, 0xe4, 0x19, 0x58, 0x59, 0x8e, 0x05, 0x13, 0xe7, 0x92,
-	0x27, 0x9f, 0x2e, 0x26, 0xb2, 0xf1, 0xda, 0x19, 0xec, 0xe2, 0x84, 0x80, 0x21, 0xe7, 0xd8, 0x93,
-	0x59, 0xe4, 0x37, 0xfd, 0x4b, 0x83, 0xc9, 0x52, 0x0a, 0x25, 0xc0, 0xdf, 0x6e, 0xb1, 0xe4, 0x6f,
-	0x28, 0xd8, 0x88, 0x49, 0xdf, 0x27, 0xa6, 0xde, 0x5e, 0x31, 0x19, 0xff, 0x2f, 0x26, 0xb3, 0x25,
-	0x26, 0xba, 0x82, 0xd1, 0xd7, 0x59, 0x9c, 0xd6, 0x87, 0x6a, 0xca, 0x6a, 0xfb, 0xca, 0xea, 0xf7,
-	0xcb, 0x52, 0x0f, 0xa6, 0xdd, 0x6b, 0x14, 0x0d, 0x4a, 0xfa, 0x39, 0x8b, 0x8b, 0x2a, 0xdf, 0xce,
-	0x41, 0x37, 0x70, 0x20, 0x55, 0x73, 0x91, 0x63, 0x18, 0xbf, 0x8a, 0xc3, 0xfa, 0x04, 0x0e, 0x0c,
-	0xa4, 0x8c, 0x9a, 0xa1, 0xd4, 0x66, 0x77, 0x60, 0xfa, 0x9d, 0x81, 0x51, 0x17, 0x0e, 0xab, 0xfa,
-	0x77, 0x33, 0xde, 0xd1, 0x20, 0xfd, 0x02, 0xa6, 0xf5, 0x4d, 0x94, 0x79, 0x96, 0x96, 0x48, 0x9e,
-	0xc3, 0xb8, 0x5a, 0x62, 0x79, 0x24, 0x89, 0xed, 0x28, 0xbb, 0x13, 0xa6, 0x2f, 0xe0, 0x71, 0xb3,
-	0x0f, 0x4d, 0x8e, 0x07, 0xec, 0xc5, 0xe7, 0xf0, 0x76, 0x4b, 0xce, 0x0d, 0xf3, 0xc1, 0xb2, 0x3e,
-	0x02, 0x5b, 0x3c, 0x64, 0x1d, 0xb2, 0x03, 0x03, 0xa5, 0x67, 0xc5, 0xb5, 0x82, 0xda, 0xa4, 0x27,
-	0x30, 0x56, 0x37, 0x5b, 0x21, 0x3f, 0x86, 0xc9, 0xaf, 0x59, 0x9c, 0x62, 0x54, 0x25, 0xae, 0xba,
-	0xec, 0xd4, 0xea, 0x22, 0xe8, 0x00, 0x4c, 0x7f, 0x9b, 0xf3, 0x3f, 0x9f, 0xbe, 0x0b, 0xa6, 0x7c,
-	0x61, 0xc8, 0x10, 0x8c, 0xb3, 0x73, 0x7f, 0x63, 0xbf, 0x45, 0x00, 0xfa, 0xeb, 0xb3, 0xe5, 0x37,
-	0xfe, 0xa9, 0xad, 0x3d, 0xfd, 0x09, 0xac, 0x66, 0x11, 0x44, 0x60, 0x19, 0xf8, 0x27, 0x97, 0xbe,
-	0x02, 0x9d, 0xfa, 0x6b, 0xff, 0xd2, 0xb7, 0x35, 0x41, 0x15, 0x04, 0x5b, 0x17, 0xde, 0xab, 0x8d,
-	0xfc, 0xee, 0x11, 0x1b, 0xc6, 0x17, 0x3f, 0x6e, 0x96, 0x3f, 0x07, 0xfe, 0x77, 0x57, 0xfe, 0xc5,
-	0xa5, 0x6d, 0xb4, 0x3c, 0x4b, 0xff, 0xe5, 0xf7, 0xbe, 0x6d, 0x2e, 0xfe, 0xd6, 0x61, 0x2c, 0x27,
-	0xf9, 0x15, 0x4b, 0xa3, 0x04, 0x0b, 0x72, 0x0c, 0x7d, 0x75, 0x83, 0xe4, 0xb1, 0x3c, 0x7d, 0x7b,
-	0xaf, 0x66, 0xa4, 0xed, 0x6a, 0x2e, 0xb8, 0x7f, 0x8a, 0x09, 0x72, 0x24, 0x4e, 0x73, 0x2d, 0x77,
-	0x64, 0x32, 0x93, 0x17, 0x26, 0xdb, 0x25, 0xcf, 0xc0, 0x58, 0x67, 0xe1, 0xeb, 0x87, 0x81, 0x9f,
-	0x43, 0xff, 0x2a, 0x4d, 0x1e, 0x0c, 0x3f, 0x86, 0xe1, 0x0a, 0xb9, 0xfa, 0xdd, 0x79, 0x03, 0x41,
-	0x81, 0x5c, 0x18, 0xaf, 0x90, 0x9f, 0x24, 0x89, 0x34, 0x4b, 0xb2, 0xcb, 0x35, 0x9b, 0x34, 0x28,
-	0xa1, 0x89, 0xc5, 0xbf, 0x5a, 0xb3, 0x83, 0xf5, 0xa4, 0x3e, 0x02, 0x43, 0x88, 0x80, 0x3c, 0x12,
-	0xc8, 0xd6, 0xa2, 0xcf, 0xec, 0x9d, 0xa3, 0x9a, 0x91, 0x07, 0xe6, 0x1a, 0xd9, 0xef, 0x48, 0x66,
-	0x2d, 0x45, 0xec, 0x69, 0xe4, 0x33, 0x80, 0x15, 0xf2, 0xfa, 0xa1, 0xdf, 0x47, 0x6a, 0x4b, 0x8c,
-	0x1c, 0xc1, 0x54, 0xb5, 0x53, 0x39, 0x3a, 0x0d, 0x3d, 0x6a, 0x21, 0x65, 0x4b, 0x21, 0x8c, 0x36,
-	0x59, 0x84, 0x75, 0x3b, 0x1e, 0x8c, 0x14, 0x59, 0xec, 0x41, 0x87, 0x79, 0x20, 0x3e, 0xef, 0x6d,
-	0xc7, 0x07, 0x30, 0xf9, 0x32, 0x61, 0xe1, 0xeb, 0x24, 0x2e, 0xb9, 0xfc, 0x0f, 0x30, 0xac, 0x61,
-	0xad, 0x4e, 0xae, 0xfb, 0xf2, 0x57, 0xfd, 0x93, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0xed, 0x2f,
-	0x3b, 0x90, 0x5c, 0x08, 0x00, 0x00,
+func (m *ChannelList) GetChannels() []*Channel {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// OrderHandlerClient is the client API for OrderHandler service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type OrderHandlerClient interface {
-	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
-	Delete(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error)
-	Lock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error)
-	Unlock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error)
-	GetOrder(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Order, error)
-	GetAllOrders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrderList, error)
+type NetworkChannel struct {
+	Channel              *Channel             `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Peers                uint32               `protobuf:"varint,2,opt,name=peers,proto3" json:"peers,omitempty"`
+	Joined               bool                 `protobuf:"varint,3,opt,name=joined,proto3" json:"joined,omitempty"`
+	LastSeen             *timestamp.Timestamp `protobuf:"bytes,4,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-type orderHandlerClient struct {
-	cc *grpc.ClientConn
+func (m *NetworkChannel) Reset()         { *m = NetworkChannel{} }
+func (m *NetworkChannel) String() string { return proto.CompactTextString(m) }
+func (*NetworkChannel) ProtoMessage()    {}
+func (*NetworkChannel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{17}
 }
 
-func NewOrderHandlerClient(cc *grpc.ClientConn) OrderHandlerClient {
-	return &orderHandlerClient{cc}
+func (m *NetworkChannel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkChannel.Unmarshal(m, b)
+}
+func (m *NetworkChannel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkChannel.Marshal(b, m, deterministic)
+}
+func (m *NetworkChannel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkChannel.Merge(m, src)
+}
+func (m *NetworkChannel) XXX_Size() int {
+	return xxx_messageInfo_NetworkChannel.Size(m)
+}
+func (m *NetworkChannel) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkChannel.DiscardUnknown(m)
 }
 
-func (c *orderHandlerClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
-	out := new(CreateResponse)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Create", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_NetworkChannel proto.InternalMessageInfo
+
+func (m *NetworkChannel) GetChannel() *Channel {
+	if m != nil {
+		return m.Channel
 	}
-	return out, nil
+	return nil
 }
 
-func (c *orderHandlerClient) Delete(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Delete", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *NetworkChannel) GetPeers() uint32 {
+	if m != nil {
+		return m.Peers
 	}
-	return out, nil
+	return 0
 }
 
-func (c *orderHandlerClient) Lock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Lock", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *NetworkChannel) GetJoined() bool {
+	if m != nil {
+		return m.Joined
 	}
-	return out, nil
+	return false
 }
 
-func (c *orderHandlerClient) Unlock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Unlock", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *NetworkChannel) GetLastSeen() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSeen
 	}
-	return out, nil
+	return nil
 }
 
-func (c *orderHandlerClient) GetOrder(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Order, error) {
-	out := new(Order)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/GetOrder", in, out, opts...)
+type NetworkChannelList struct {
+	Channels             []*NetworkChannel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *NetworkChannelList) Reset()         { *m = NetworkChannelList{} }
+func (m *NetworkChannelList) String() string { return proto.CompactTextString(m) }
+func (*NetworkChannelList) ProtoMessage()    {}
+func (*NetworkChannelList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{18}
+}
+
+func (m *NetworkChannelList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkChannelList.Unmarshal(m, b)
+}
+func (m *NetworkChannelList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkChannelList.Marshal(b, m, deterministic)
+}
+func (m *NetworkChannelList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkChannelList.Merge(m, src)
+}
+func (m *NetworkChannelList) XXX_Size() int {
+	return xxx_messageInfo_NetworkChannelList.Size(m)
+}
+func (m *NetworkChannelList) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkChannelList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkChannelList proto.InternalMessageInfo
+
+func (m *NetworkChannelList) GetChannels() []*NetworkChannel {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type DirectoryRecord struct {
+	Channels             []*Channel           `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	Updated              *timestamp.Timestamp `protobuf:"bytes,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	Signature            []byte               `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DirectoryRecord) Reset()         { *m = DirectoryRecord{} }
+func (m *DirectoryRecord) String() string { return proto.CompactTextString(m) }
+func (*DirectoryRecord) ProtoMessage()    {}
+func (*DirectoryRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{19}
+}
+
+func (m *DirectoryRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DirectoryRecord.Unmarshal(m, b)
+}
+func (m *DirectoryRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DirectoryRecord.Marshal(b, m, deterministic)
+}
+func (m *DirectoryRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DirectoryRecord.Merge(m, src)
+}
+func (m *DirectoryRecord) XXX_Size() int {
+	return xxx_messageInfo_DirectoryRecord.Size(m)
+}
+func (m *DirectoryRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_DirectoryRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DirectoryRecord proto.InternalMessageInfo
+
+func (m *DirectoryRecord) GetChannels() []*Channel {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *DirectoryRecord) GetUpdated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Updated
+	}
+	return nil
+}
+
+func (m *DirectoryRecord) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type ChannelStats struct {
+	Id                   []byte               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MeshPeers            []string             `protobuf:"bytes,2,rep,name=meshPeers,proto3" json:"meshPeers,omitempty"`
+	OpenOrders           uint64               `protobuf:"varint,3,opt,name=openOrders,proto3" json:"openOrders,omitempty"`
+	LockedOrders         uint64               `protobuf:"varint,4,opt,name=lockedOrders,proto3" json:"lockedOrders,omitempty"`
+	MessagesReceived     uint64               `protobuf:"varint,5,opt,name=messagesReceived,proto3" json:"messagesReceived,omitempty"`
+	MessagesRejected     uint64               `protobuf:"varint,6,opt,name=messagesRejected,proto3" json:"messagesRejected,omitempty"`
+	LastActivity         *timestamp.Timestamp `protobuf:"bytes,7,opt,name=lastActivity,proto3" json:"lastActivity,omitempty"`
+	LastSync             *timestamp.Timestamp `protobuf:"bytes,8,opt,name=lastSync,proto3" json:"lastSync,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ChannelStats) Reset()         { *m = ChannelStats{} }
+func (m *ChannelStats) String() string { return proto.CompactTextString(m) }
+func (*ChannelStats) ProtoMessage()    {}
+func (*ChannelStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{20}
+}
+
+func (m *ChannelStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelStats.Unmarshal(m, b)
+}
+func (m *ChannelStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelStats.Marshal(b, m, deterministic)
+}
+func (m *ChannelStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelStats.Merge(m, src)
+}
+func (m *ChannelStats) XXX_Size() int {
+	return xxx_messageInfo_ChannelStats.Size(m)
+}
+func (m *ChannelStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelStats proto.InternalMessageInfo
+
+func (m *ChannelStats) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ChannelStats) GetMeshPeers() []string {
+	if m != nil {
+		return m.MeshPeers
+	}
+	return nil
+}
+
+func (m *ChannelStats) GetOpenOrders() uint64 {
+	if m != nil {
+		return m.OpenOrders
+	}
+	return 0
+}
+
+func (m *ChannelStats) GetLockedOrders() uint64 {
+	if m != nil {
+		return m.LockedOrders
+	}
+	return 0
+}
+
+func (m *ChannelStats) GetMessagesReceived() uint64 {
+	if m != nil {
+		return m.MessagesReceived
+	}
+	return 0
+}
+
+func (m *ChannelStats) GetMessagesRejected() uint64 {
+	if m != nil {
+		return m.MessagesRejected
+	}
+	return 0
+}
+
+func (m *ChannelStats) GetLastActivity() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastActivity
+	}
+	return nil
+}
+
+func (m *ChannelStats) GetLastSync() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSync
+	}
+	return nil
+}
+
+type ChannelStatsList struct {
+	Channels             []*ChannelStats `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ChannelStatsList) Reset()         { *m = ChannelStatsList{} }
+func (m *ChannelStatsList) String() string { return proto.CompactTextString(m) }
+func (*ChannelStatsList) ProtoMessage()    {}
+func (*ChannelStatsList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{21}
+}
+
+func (m *ChannelStatsList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelStatsList.Unmarshal(m, b)
+}
+func (m *ChannelStatsList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelStatsList.Marshal(b, m, deterministic)
+}
+func (m *ChannelStatsList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelStatsList.Merge(m, src)
+}
+func (m *ChannelStatsList) XXX_Size() int {
+	return xxx_messageInfo_ChannelStatsList.Size(m)
+}
+func (m *ChannelStatsList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelStatsList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelStatsList proto.InternalMessageInfo
+
+func (m *ChannelStatsList) GetChannels() []*ChannelStats {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type SyncStatus struct {
+	ChannelID            []byte               `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Syncing              bool                 `protobuf:"varint,2,opt,name=syncing,proto3" json:"syncing,omitempty"`
+	Rounds               uint64               `protobuf:"varint,3,opt,name=rounds,proto3" json:"rounds,omitempty"`
+	RequestsSent         uint64               `protobuf:"varint,4,opt,name=requestsSent,proto3" json:"requestsSent,omitempty"`
+	RequestsFailed       uint64               `protobuf:"varint,5,opt,name=requestsFailed,proto3" json:"requestsFailed,omitempty"`
+	LastPeers            []string             `protobuf:"bytes,6,rep,name=lastPeers,proto3" json:"lastPeers,omitempty"`
+	LastRound            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=lastRound,proto3" json:"lastRound,omitempty"`
+	NextRound            *timestamp.Timestamp `protobuf:"bytes,8,opt,name=nextRound,proto3" json:"nextRound,omitempty"`
+	OrdersReceived       uint64               `protobuf:"varint,9,opt,name=ordersReceived,proto3" json:"ordersReceived,omitempty"`
+	TombstonesReceived   uint64               `protobuf:"varint,10,opt,name=tombstonesReceived,proto3" json:"tombstonesReceived,omitempty"`
+	ItemsSent            uint64               `protobuf:"varint,11,opt,name=itemsSent,proto3" json:"itemsSent,omitempty"`
+	LastInSync           *timestamp.Timestamp `protobuf:"bytes,12,opt,name=lastInSync,proto3" json:"lastInSync,omitempty"`
+	LastRepair           *timestamp.Timestamp `protobuf:"bytes,13,opt,name=lastRepair,proto3" json:"lastRepair,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SyncStatus) Reset()         { *m = SyncStatus{} }
+func (m *SyncStatus) String() string { return proto.CompactTextString(m) }
+func (*SyncStatus) ProtoMessage()    {}
+func (*SyncStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{22}
+}
+
+func (m *SyncStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncStatus.Unmarshal(m, b)
+}
+func (m *SyncStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncStatus.Marshal(b, m, deterministic)
+}
+func (m *SyncStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncStatus.Merge(m, src)
+}
+func (m *SyncStatus) XXX_Size() int {
+	return xxx_messageInfo_SyncStatus.Size(m)
+}
+func (m *SyncStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncStatus proto.InternalMessageInfo
+
+func (m *SyncStatus) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *SyncStatus) GetSyncing() bool {
+	if m != nil {
+		return m.Syncing
+	}
+	return false
+}
+
+func (m *SyncStatus) GetRounds() uint64 {
+	if m != nil {
+		return m.Rounds
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetRequestsSent() uint64 {
+	if m != nil {
+		return m.RequestsSent
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetRequestsFailed() uint64 {
+	if m != nil {
+		return m.RequestsFailed
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetLastPeers() []string {
+	if m != nil {
+		return m.LastPeers
+	}
+	return nil
+}
+
+func (m *SyncStatus) GetLastRound() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastRound
+	}
+	return nil
+}
+
+func (m *SyncStatus) GetNextRound() *timestamp.Timestamp {
+	if m != nil {
+		return m.NextRound
+	}
+	return nil
+}
+
+func (m *SyncStatus) GetOrdersReceived() uint64 {
+	if m != nil {
+		return m.OrdersReceived
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetTombstonesReceived() uint64 {
+	if m != nil {
+		return m.TombstonesReceived
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetItemsSent() uint64 {
+	if m != nil {
+		return m.ItemsSent
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetLastInSync() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastInSync
+	}
+	return nil
+}
+
+func (m *SyncStatus) GetLastRepair() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastRepair
+	}
+	return nil
+}
+
+type SyncStatusList struct {
+	Channels             []*SyncStatus `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *SyncStatusList) Reset()         { *m = SyncStatusList{} }
+func (m *SyncStatusList) String() string { return proto.CompactTextString(m) }
+func (*SyncStatusList) ProtoMessage()    {}
+func (*SyncStatusList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{23}
+}
+
+func (m *SyncStatusList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncStatusList.Unmarshal(m, b)
+}
+func (m *SyncStatusList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncStatusList.Marshal(b, m, deterministic)
+}
+func (m *SyncStatusList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncStatusList.Merge(m, src)
+}
+func (m *SyncStatusList) XXX_Size() int {
+	return xxx_messageInfo_SyncStatusList.Size(m)
+}
+func (m *SyncStatusList) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncStatusList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncStatusList proto.InternalMessageInfo
+
+func (m *SyncStatusList) GetChannels() []*SyncStatus {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type Recipient struct {
+	PeerID               []byte   `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Recipient) Reset()         { *m = Recipient{} }
+func (m *Recipient) String() string { return proto.CompactTextString(m) }
+func (*Recipient) ProtoMessage()    {}
+func (*Recipient) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{24}
+}
+
+func (m *Recipient) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Recipient.Unmarshal(m, b)
+}
+func (m *Recipient) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Recipient.Marshal(b, m, deterministic)
+}
+func (m *Recipient) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Recipient.Merge(m, src)
+}
+func (m *Recipient) XXX_Size() int {
+	return xxx_messageInfo_Recipient.Size(m)
+}
+func (m *Recipient) XXX_DiscardUnknown() {
+	xxx_messageInfo_Recipient.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Recipient proto.InternalMessageInfo
+
+func (m *Recipient) GetPeerID() []byte {
+	if m != nil {
+		return m.PeerID
+	}
+	return nil
+}
+
+type WireMessage struct {
+	ChannelID            []byte    `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Operation            Operation `protobuf:"varint,2,opt,name=operation,proto3,enum=pb.Operation" json:"operation,omitempty"`
+	Data                 []byte    `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Version              uint32    `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *WireMessage) Reset()         { *m = WireMessage{} }
+func (m *WireMessage) String() string { return proto.CompactTextString(m) }
+func (*WireMessage) ProtoMessage()    {}
+func (*WireMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{25}
+}
+
+func (m *WireMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WireMessage.Unmarshal(m, b)
+}
+func (m *WireMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WireMessage.Marshal(b, m, deterministic)
+}
+func (m *WireMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WireMessage.Merge(m, src)
+}
+func (m *WireMessage) XXX_Size() int {
+	return xxx_messageInfo_WireMessage.Size(m)
+}
+func (m *WireMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_WireMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WireMessage proto.InternalMessageInfo
+
+func (m *WireMessage) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *WireMessage) GetOperation() Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return Operation_CREATE
+}
+
+func (m *WireMessage) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *WireMessage) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type Handshake struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Protocols            []string `protobuf:"bytes,2,rep,name=protocols,proto3" json:"protocols,omitempty"`
+	Capabilities         []string `protobuf:"bytes,3,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Handshake) Reset()         { *m = Handshake{} }
+func (m *Handshake) String() string { return proto.CompactTextString(m) }
+func (*Handshake) ProtoMessage()    {}
+func (*Handshake) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{26}
+}
+
+func (m *Handshake) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Handshake.Unmarshal(m, b)
+}
+func (m *Handshake) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Handshake.Marshal(b, m, deterministic)
+}
+func (m *Handshake) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Handshake.Merge(m, src)
+}
+func (m *Handshake) XXX_Size() int {
+	return xxx_messageInfo_Handshake.Size(m)
+}
+func (m *Handshake) XXX_DiscardUnknown() {
+	xxx_messageInfo_Handshake.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Handshake proto.InternalMessageInfo
+
+func (m *Handshake) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Handshake) GetProtocols() []string {
+	if m != nil {
+		return m.Protocols
+	}
+	return nil
+}
+
+func (m *Handshake) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+type OrderEvent struct {
+	Sequence             uint64               `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Type                 EventType            `protobuf:"varint,2,opt,name=type,proto3,enum=pb.EventType" json:"type,omitempty"`
+	ChannelID            []byte               `protobuf:"bytes,3,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Order                *Order               `protobuf:"bytes,4,opt,name=order,proto3" json:"order,omitempty"`
+	Local                bool                 `protobuf:"varint,5,opt,name=local,proto3" json:"local,omitempty"`
+	Time                 *timestamp.Timestamp `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`
+	Epoch                uint64               `protobuf:"varint,7,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *OrderEvent) Reset()         { *m = OrderEvent{} }
+func (m *OrderEvent) String() string { return proto.CompactTextString(m) }
+func (*OrderEvent) ProtoMessage()    {}
+func (*OrderEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{27}
+}
+
+func (m *OrderEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderEvent.Unmarshal(m, b)
+}
+func (m *OrderEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderEvent.Marshal(b, m, deterministic)
+}
+func (m *OrderEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderEvent.Merge(m, src)
+}
+func (m *OrderEvent) XXX_Size() int {
+	return xxx_messageInfo_OrderEvent.Size(m)
+}
+func (m *OrderEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderEvent proto.InternalMessageInfo
+
+func (m *OrderEvent) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *OrderEvent) GetType() EventType {
+	if m != nil {
+		return m.Type
+	}
+	return EventType_ORDER_CREATED
+}
+
+func (m *OrderEvent) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *OrderEvent) GetOrder() *Order {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+func (m *OrderEvent) GetLocal() bool {
+	if m != nil {
+		return m.Local
+	}
+	return false
+}
+
+func (m *OrderEvent) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *OrderEvent) GetEpoch() uint64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+type StreamEventsRequest struct {
+	ChannelIDs           [][]byte `protobuf:"bytes,1,rep,name=channelIDs,proto3" json:"channelIDs,omitempty"`
+	FromSequence         uint64   `protobuf:"varint,2,opt,name=fromSequence,proto3" json:"fromSequence,omitempty"`
+	Epoch                uint64   `protobuf:"varint,3,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamEventsRequest) Reset()         { *m = StreamEventsRequest{} }
+func (m *StreamEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamEventsRequest) ProtoMessage()    {}
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{28}
+}
+
+func (m *StreamEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamEventsRequest.Unmarshal(m, b)
+}
+func (m *StreamEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamEventsRequest.Merge(m, src)
+}
+func (m *StreamEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamEventsRequest.Size(m)
+}
+func (m *StreamEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamEventsRequest proto.InternalMessageInfo
+
+func (m *StreamEventsRequest) GetChannelIDs() [][]byte {
+	if m != nil {
+		return m.ChannelIDs
+	}
+	return nil
+}
+
+func (m *StreamEventsRequest) GetFromSequence() uint64 {
+	if m != nil {
+		return m.FromSequence
+	}
+	return 0
+}
+
+func (m *StreamEventsRequest) GetEpoch() uint64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+type WebsocketFilter struct {
+	ChannelID            []byte      `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Operations           []Operation `protobuf:"varint,2,rep,packed,name=operations,proto3,enum=pb.Operation" json:"operations,omitempty"`
+	Assets               []string    `protobuf:"bytes,3,rep,name=assets,proto3" json:"assets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *WebsocketFilter) Reset()         { *m = WebsocketFilter{} }
+func (m *WebsocketFilter) String() string { return proto.CompactTextString(m) }
+func (*WebsocketFilter) ProtoMessage()    {}
+func (*WebsocketFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{29}
+}
+
+func (m *WebsocketFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebsocketFilter.Unmarshal(m, b)
+}
+func (m *WebsocketFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebsocketFilter.Marshal(b, m, deterministic)
+}
+func (m *WebsocketFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebsocketFilter.Merge(m, src)
+}
+func (m *WebsocketFilter) XXX_Size() int {
+	return xxx_messageInfo_WebsocketFilter.Size(m)
+}
+func (m *WebsocketFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebsocketFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebsocketFilter proto.InternalMessageInfo
+
+func (m *WebsocketFilter) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *WebsocketFilter) GetOperations() []Operation {
+	if m != nil {
+		return m.Operations
+	}
+	return nil
+}
+
+func (m *WebsocketFilter) GetAssets() []string {
+	if m != nil {
+		return m.Assets
+	}
+	return nil
+}
+
+type WebsocketRequest struct {
+	Action               WebsocketAction    `protobuf:"varint,1,opt,name=action,proto3,enum=pb.WebsocketAction" json:"action,omitempty"`
+	Filters              []*WebsocketFilter `protobuf:"bytes,2,rep,name=filters,proto3" json:"filters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *WebsocketRequest) Reset()         { *m = WebsocketRequest{} }
+func (m *WebsocketRequest) String() string { return proto.CompactTextString(m) }
+func (*WebsocketRequest) ProtoMessage()    {}
+func (*WebsocketRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{30}
+}
+
+func (m *WebsocketRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebsocketRequest.Unmarshal(m, b)
+}
+func (m *WebsocketRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebsocketRequest.Marshal(b, m, deterministic)
+}
+func (m *WebsocketRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebsocketRequest.Merge(m, src)
+}
+func (m *WebsocketRequest) XXX_Size() int {
+	return xxx_messageInfo_WebsocketRequest.Size(m)
+}
+func (m *WebsocketRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebsocketRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebsocketRequest proto.InternalMessageInfo
+
+func (m *WebsocketRequest) GetAction() WebsocketAction {
+	if m != nil {
+		return m.Action
+	}
+	return WebsocketAction_SUBSCRIBE
+}
+
+func (m *WebsocketRequest) GetFilters() []*WebsocketFilter {
+	if m != nil {
+		return m.Filters
+	}
+	return nil
+}
+
+type WebsocketEvent struct {
+	ChannelID            []byte        `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Operation            Operation     `protobuf:"varint,2,opt,name=operation,proto3,enum=pb.Operation" json:"operation,omitempty"`
+	Order                *Order        `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	QuoteRequest         *QuoteRequest `protobuf:"bytes,4,opt,name=quoteRequest,proto3" json:"quoteRequest,omitempty"`
+	Quote                *Quote        `protobuf:"bytes,5,opt,name=quote,proto3" json:"quote,omitempty"`
+	Data                 []byte        `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *WebsocketEvent) Reset()         { *m = WebsocketEvent{} }
+func (m *WebsocketEvent) String() string { return proto.CompactTextString(m) }
+func (*WebsocketEvent) ProtoMessage()    {}
+func (*WebsocketEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{31}
+}
+
+func (m *WebsocketEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebsocketEvent.Unmarshal(m, b)
+}
+func (m *WebsocketEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebsocketEvent.Marshal(b, m, deterministic)
+}
+func (m *WebsocketEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebsocketEvent.Merge(m, src)
+}
+func (m *WebsocketEvent) XXX_Size() int {
+	return xxx_messageInfo_WebsocketEvent.Size(m)
+}
+func (m *WebsocketEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebsocketEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebsocketEvent proto.InternalMessageInfo
+
+func (m *WebsocketEvent) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *WebsocketEvent) GetOperation() Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return Operation_CREATE
+}
+
+func (m *WebsocketEvent) GetOrder() *Order {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+func (m *WebsocketEvent) GetQuoteRequest() *QuoteRequest {
+	if m != nil {
+		return m.QuoteRequest
+	}
+	return nil
+}
+
+func (m *WebsocketEvent) GetQuote() *Quote {
+	if m != nil {
+		return m.Quote
+	}
+	return nil
+}
+
+func (m *WebsocketEvent) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type WebsocketResponse struct {
+	Subscriptions        []*WebsocketFilter `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	Error                string             `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *WebsocketResponse) Reset()         { *m = WebsocketResponse{} }
+func (m *WebsocketResponse) String() string { return proto.CompactTextString(m) }
+func (*WebsocketResponse) ProtoMessage()    {}
+func (*WebsocketResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{32}
+}
+
+func (m *WebsocketResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebsocketResponse.Unmarshal(m, b)
+}
+func (m *WebsocketResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebsocketResponse.Marshal(b, m, deterministic)
+}
+func (m *WebsocketResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebsocketResponse.Merge(m, src)
+}
+func (m *WebsocketResponse) XXX_Size() int {
+	return xxx_messageInfo_WebsocketResponse.Size(m)
+}
+func (m *WebsocketResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebsocketResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebsocketResponse proto.InternalMessageInfo
+
+func (m *WebsocketResponse) GetSubscriptions() []*WebsocketFilter {
+	if m != nil {
+		return m.Subscriptions
+	}
+	return nil
+}
+
+func (m *WebsocketResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type CreateRequest struct {
+	ChannelID            []byte   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Asset                string   `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
+	CounterAsset         string   `protobuf:"bytes,3,opt,name=counterAsset,proto3" json:"counterAsset,omitempty"`
+	Amount               uint64   `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Price                float32  `protobuf:"fixed32,5,opt,name=price,proto3" json:"price,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
+func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRequest) ProtoMessage()    {}
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{33}
+}
+
+func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
+}
+func (m *CreateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRequest.Merge(m, src)
+}
+func (m *CreateRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRequest.Size(m)
+}
+func (m *CreateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRequest proto.InternalMessageInfo
+
+func (m *CreateRequest) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *CreateRequest) GetAsset() string {
+	if m != nil {
+		return m.Asset
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetCounterAsset() string {
+	if m != nil {
+		return m.CounterAsset
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *CreateRequest) GetPrice() float32 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+type CreateQuoteRequest struct {
+	ChannelID            []byte   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Asset                string   `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
+	CounterAsset         string   `protobuf:"bytes,3,opt,name=counterAsset,proto3" json:"counterAsset,omitempty"`
+	Amount               uint64   `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Ttl                  uint32   `protobuf:"varint,5,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateQuoteRequest) Reset()         { *m = CreateQuoteRequest{} }
+func (m *CreateQuoteRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateQuoteRequest) ProtoMessage()    {}
+func (*CreateQuoteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{34}
+}
+
+func (m *CreateQuoteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateQuoteRequest.Unmarshal(m, b)
+}
+func (m *CreateQuoteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateQuoteRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateQuoteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateQuoteRequest.Merge(m, src)
+}
+func (m *CreateQuoteRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateQuoteRequest.Size(m)
+}
+func (m *CreateQuoteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateQuoteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateQuoteRequest proto.InternalMessageInfo
+
+func (m *CreateQuoteRequest) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *CreateQuoteRequest) GetAsset() string {
+	if m != nil {
+		return m.Asset
+	}
+	return ""
+}
+
+func (m *CreateQuoteRequest) GetCounterAsset() string {
+	if m != nil {
+		return m.CounterAsset
+	}
+	return ""
+}
+
+func (m *CreateQuoteRequest) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *CreateQuoteRequest) GetTtl() uint32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+type SendQuoteRequest struct {
+	RequestID            []byte   `protobuf:"bytes,1,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	Price                float32  `protobuf:"fixed32,2,opt,name=price,proto3" json:"price,omitempty"`
+	Ttl                  uint32   `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendQuoteRequest) Reset()         { *m = SendQuoteRequest{} }
+func (m *SendQuoteRequest) String() string { return proto.CompactTextString(m) }
+func (*SendQuoteRequest) ProtoMessage()    {}
+func (*SendQuoteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{35}
+}
+
+func (m *SendQuoteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendQuoteRequest.Unmarshal(m, b)
+}
+func (m *SendQuoteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendQuoteRequest.Marshal(b, m, deterministic)
+}
+func (m *SendQuoteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendQuoteRequest.Merge(m, src)
+}
+func (m *SendQuoteRequest) XXX_Size() int {
+	return xxx_messageInfo_SendQuoteRequest.Size(m)
+}
+func (m *SendQuoteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendQuoteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendQuoteRequest proto.InternalMessageInfo
+
+func (m *SendQuoteRequest) GetRequestID() []byte {
+	if m != nil {
+		return m.RequestID
+	}
+	return nil
+}
+
+func (m *SendQuoteRequest) GetPrice() float32 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+func (m *SendQuoteRequest) GetTtl() uint32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+type QuoteRequestSpecificRequest struct {
+	RequestID            []byte   `protobuf:"bytes,1,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuoteRequestSpecificRequest) Reset()         { *m = QuoteRequestSpecificRequest{} }
+func (m *QuoteRequestSpecificRequest) String() string { return proto.CompactTextString(m) }
+func (*QuoteRequestSpecificRequest) ProtoMessage()    {}
+func (*QuoteRequestSpecificRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{36}
+}
+
+func (m *QuoteRequestSpecificRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuoteRequestSpecificRequest.Unmarshal(m, b)
+}
+func (m *QuoteRequestSpecificRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuoteRequestSpecificRequest.Marshal(b, m, deterministic)
+}
+func (m *QuoteRequestSpecificRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuoteRequestSpecificRequest.Merge(m, src)
+}
+func (m *QuoteRequestSpecificRequest) XXX_Size() int {
+	return xxx_messageInfo_QuoteRequestSpecificRequest.Size(m)
+}
+func (m *QuoteRequestSpecificRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuoteRequestSpecificRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuoteRequestSpecificRequest proto.InternalMessageInfo
+
+func (m *QuoteRequestSpecificRequest) GetRequestID() []byte {
+	if m != nil {
+		return m.RequestID
+	}
+	return nil
+}
+
+type QuoteSpecificRequest struct {
+	RequestID            []byte   `protobuf:"bytes,1,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	QuoteID              []byte   `protobuf:"bytes,2,opt,name=quoteID,proto3" json:"quoteID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuoteSpecificRequest) Reset()         { *m = QuoteSpecificRequest{} }
+func (m *QuoteSpecificRequest) String() string { return proto.CompactTextString(m) }
+func (*QuoteSpecificRequest) ProtoMessage()    {}
+func (*QuoteSpecificRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{37}
+}
+
+func (m *QuoteSpecificRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuoteSpecificRequest.Unmarshal(m, b)
+}
+func (m *QuoteSpecificRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuoteSpecificRequest.Marshal(b, m, deterministic)
+}
+func (m *QuoteSpecificRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuoteSpecificRequest.Merge(m, src)
+}
+func (m *QuoteSpecificRequest) XXX_Size() int {
+	return xxx_messageInfo_QuoteSpecificRequest.Size(m)
+}
+func (m *QuoteSpecificRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuoteSpecificRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuoteSpecificRequest proto.InternalMessageInfo
+
+func (m *QuoteSpecificRequest) GetRequestID() []byte {
+	if m != nil {
+		return m.RequestID
+	}
+	return nil
+}
+
+func (m *QuoteSpecificRequest) GetQuoteID() []byte {
+	if m != nil {
+		return m.QuoteID
+	}
+	return nil
+}
+
+type JoinRequest struct {
+	Asset                string      `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	CounterAsset         string      `protobuf:"bytes,2,opt,name=counterAsset,proto3" json:"counterAsset,omitempty"`
+	Type                 ChannelType `protobuf:"varint,3,opt,name=type,proto3,enum=pb.ChannelType" json:"type,omitempty"`
+	Members              []string    `protobuf:"bytes,4,rep,name=members,proto3" json:"members,omitempty"`
+	Difficulty           uint32      `protobuf:"varint,5,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *JoinRequest) Reset()         { *m = JoinRequest{} }
+func (m *JoinRequest) String() string { return proto.CompactTextString(m) }
+func (*JoinRequest) ProtoMessage()    {}
+func (*JoinRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{38}
+}
+
+func (m *JoinRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinRequest.Unmarshal(m, b)
+}
+func (m *JoinRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinRequest.Marshal(b, m, deterministic)
+}
+func (m *JoinRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinRequest.Merge(m, src)
+}
+func (m *JoinRequest) XXX_Size() int {
+	return xxx_messageInfo_JoinRequest.Size(m)
+}
+func (m *JoinRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinRequest proto.InternalMessageInfo
+
+func (m *JoinRequest) GetAsset() string {
+	if m != nil {
+		return m.Asset
+	}
+	return ""
+}
+
+func (m *JoinRequest) GetCounterAsset() string {
+	if m != nil {
+		return m.CounterAsset
+	}
+	return ""
+}
+
+func (m *JoinRequest) GetType() ChannelType {
+	if m != nil {
+		return m.Type
+	}
+	return ChannelType_PUBLIC
+}
+
+func (m *JoinRequest) GetMembers() []string {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+func (m *JoinRequest) GetDifficulty() uint32 {
+	if m != nil {
+		return m.Difficulty
+	}
+	return 0
+}
+
+type ChannelOptions struct {
+	AssetPair            string      `protobuf:"bytes,1,opt,name=assetPair,proto3" json:"assetPair,omitempty"`
+	Type                 ChannelType `protobuf:"varint,2,opt,name=type,proto3,enum=pb.ChannelType" json:"type,omitempty"`
+	Members              []string    `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+	Key                  []byte      `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	Difficulty           uint32      `protobuf:"varint,5,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ChannelOptions) Reset()         { *m = ChannelOptions{} }
+func (m *ChannelOptions) String() string { return proto.CompactTextString(m) }
+func (*ChannelOptions) ProtoMessage()    {}
+func (*ChannelOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{39}
+}
+
+func (m *ChannelOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelOptions.Unmarshal(m, b)
+}
+func (m *ChannelOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelOptions.Marshal(b, m, deterministic)
+}
+func (m *ChannelOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelOptions.Merge(m, src)
+}
+func (m *ChannelOptions) XXX_Size() int {
+	return xxx_messageInfo_ChannelOptions.Size(m)
+}
+func (m *ChannelOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelOptions proto.InternalMessageInfo
+
+func (m *ChannelOptions) GetAssetPair() string {
+	if m != nil {
+		return m.AssetPair
+	}
+	return ""
+}
+
+func (m *ChannelOptions) GetType() ChannelType {
+	if m != nil {
+		return m.Type
+	}
+	return ChannelType_PUBLIC
+}
+
+func (m *ChannelOptions) GetMembers() []string {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+func (m *ChannelOptions) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *ChannelOptions) GetDifficulty() uint32 {
+	if m != nil {
+		return m.Difficulty
+	}
+	return 0
+}
+
+type OrderSpecificRequest struct {
+	OrderID              []byte   `protobuf:"bytes,1,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	ChannelID            []byte   `protobuf:"bytes,2,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderSpecificRequest) Reset()         { *m = OrderSpecificRequest{} }
+func (m *OrderSpecificRequest) String() string { return proto.CompactTextString(m) }
+func (*OrderSpecificRequest) ProtoMessage()    {}
+func (*OrderSpecificRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{40}
+}
+
+func (m *OrderSpecificRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderSpecificRequest.Unmarshal(m, b)
+}
+func (m *OrderSpecificRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderSpecificRequest.Marshal(b, m, deterministic)
+}
+func (m *OrderSpecificRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderSpecificRequest.Merge(m, src)
+}
+func (m *OrderSpecificRequest) XXX_Size() int {
+	return xxx_messageInfo_OrderSpecificRequest.Size(m)
+}
+func (m *OrderSpecificRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderSpecificRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderSpecificRequest proto.InternalMessageInfo
+
+func (m *OrderSpecificRequest) GetOrderID() []byte {
+	if m != nil {
+		return m.OrderID
+	}
+	return nil
+}
+
+func (m *OrderSpecificRequest) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+type ChannelSpecificRequest struct {
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChannelSpecificRequest) Reset()         { *m = ChannelSpecificRequest{} }
+func (m *ChannelSpecificRequest) String() string { return proto.CompactTextString(m) }
+func (*ChannelSpecificRequest) ProtoMessage()    {}
+func (*ChannelSpecificRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{41}
+}
+
+func (m *ChannelSpecificRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelSpecificRequest.Unmarshal(m, b)
+}
+func (m *ChannelSpecificRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelSpecificRequest.Marshal(b, m, deterministic)
+}
+func (m *ChannelSpecificRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelSpecificRequest.Merge(m, src)
+}
+func (m *ChannelSpecificRequest) XXX_Size() int {
+	return xxx_messageInfo_ChannelSpecificRequest.Size(m)
+}
+func (m *ChannelSpecificRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelSpecificRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelSpecificRequest proto.InternalMessageInfo
+
+func (m *ChannelSpecificRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type CreateResponse struct {
+	CreatedOrder         *Order   `protobuf:"bytes,1,opt,name=createdOrder,proto3" json:"createdOrder,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateResponse) Reset()         { *m = CreateResponse{} }
+func (m *CreateResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateResponse) ProtoMessage()    {}
+func (*CreateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{42}
+}
+
+func (m *CreateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateResponse.Unmarshal(m, b)
+}
+func (m *CreateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateResponse.Merge(m, src)
+}
+func (m *CreateResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateResponse.Size(m)
+}
+func (m *CreateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateResponse proto.InternalMessageInfo
+
+func (m *CreateResponse) GetCreatedOrder() *Order {
+	if m != nil {
+		return m.CreatedOrder
+	}
+	return nil
+}
+
+type OrderListResponse struct {
+	Orders               []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderListResponse) Reset()         { *m = OrderListResponse{} }
+func (m *OrderListResponse) String() string { return proto.CompactTextString(m) }
+func (*OrderListResponse) ProtoMessage()    {}
+func (*OrderListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{43}
+}
+
+func (m *OrderListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderListResponse.Unmarshal(m, b)
+}
+func (m *OrderListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderListResponse.Marshal(b, m, deterministic)
+}
+func (m *OrderListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderListResponse.Merge(m, src)
+}
+func (m *OrderListResponse) XXX_Size() int {
+	return xxx_messageInfo_OrderListResponse.Size(m)
+}
+func (m *OrderListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderListResponse proto.InternalMessageInfo
+
+func (m *OrderListResponse) GetOrders() []*Order {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+type ChannelListResponse struct {
+	Channels             []*Channel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ChannelListResponse) Reset()         { *m = ChannelListResponse{} }
+func (m *ChannelListResponse) String() string { return proto.CompactTextString(m) }
+func (*ChannelListResponse) ProtoMessage()    {}
+func (*ChannelListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{44}
+}
+
+func (m *ChannelListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelListResponse.Unmarshal(m, b)
+}
+func (m *ChannelListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelListResponse.Marshal(b, m, deterministic)
+}
+func (m *ChannelListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelListResponse.Merge(m, src)
+}
+func (m *ChannelListResponse) XXX_Size() int {
+	return xxx_messageInfo_ChannelListResponse.Size(m)
+}
+func (m *ChannelListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelListResponse proto.InternalMessageInfo
+
+func (m *ChannelListResponse) GetChannels() []*Channel {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type PeerListResponse struct {
+	PeerIDs              []string `protobuf:"bytes,1,rep,name=peerIDs,proto3" json:"peerIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerListResponse) Reset()         { *m = PeerListResponse{} }
+func (m *PeerListResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerListResponse) ProtoMessage()    {}
+func (*PeerListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{45}
+}
+
+func (m *PeerListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerListResponse.Unmarshal(m, b)
+}
+func (m *PeerListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerListResponse.Marshal(b, m, deterministic)
+}
+func (m *PeerListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerListResponse.Merge(m, src)
+}
+func (m *PeerListResponse) XXX_Size() int {
+	return xxx_messageInfo_PeerListResponse.Size(m)
+}
+func (m *PeerListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerListResponse proto.InternalMessageInfo
+
+func (m *PeerListResponse) GetPeerIDs() []string {
+	if m != nil {
+		return m.PeerIDs
+	}
+	return nil
+}
+
+type DirectMessageRequest struct {
+	Recipient            *Peer    `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Payload              []byte   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DirectMessageRequest) Reset()         { *m = DirectMessageRequest{} }
+func (m *DirectMessageRequest) String() string { return proto.CompactTextString(m) }
+func (*DirectMessageRequest) ProtoMessage()    {}
+func (*DirectMessageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{46}
+}
+
+func (m *DirectMessageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DirectMessageRequest.Unmarshal(m, b)
+}
+func (m *DirectMessageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DirectMessageRequest.Marshal(b, m, deterministic)
+}
+func (m *DirectMessageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DirectMessageRequest.Merge(m, src)
+}
+func (m *DirectMessageRequest) XXX_Size() int {
+	return xxx_messageInfo_DirectMessageRequest.Size(m)
+}
+func (m *DirectMessageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DirectMessageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DirectMessageRequest proto.InternalMessageInfo
+
+func (m *DirectMessageRequest) GetRecipient() *Peer {
+	if m != nil {
+		return m.Recipient
+	}
+	return nil
+}
+
+func (m *DirectMessageRequest) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+type DirectMessage struct {
+	From                 string               `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	Payload              []byte               `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Received             *timestamp.Timestamp `protobuf:"bytes,3,opt,name=received,proto3" json:"received,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DirectMessage) Reset()         { *m = DirectMessage{} }
+func (m *DirectMessage) String() string { return proto.CompactTextString(m) }
+func (*DirectMessage) ProtoMessage()    {}
+func (*DirectMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{47}
+}
+
+func (m *DirectMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DirectMessage.Unmarshal(m, b)
+}
+func (m *DirectMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DirectMessage.Marshal(b, m, deterministic)
+}
+func (m *DirectMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DirectMessage.Merge(m, src)
+}
+func (m *DirectMessage) XXX_Size() int {
+	return xxx_messageInfo_DirectMessage.Size(m)
+}
+func (m *DirectMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_DirectMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DirectMessage proto.InternalMessageInfo
+
+func (m *DirectMessage) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *DirectMessage) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *DirectMessage) GetReceived() *timestamp.Timestamp {
+	if m != nil {
+		return m.Received
+	}
+	return nil
+}
+
+type ConnectRequest struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Protected            bool     `protobuf:"varint,2,opt,name=protected,proto3" json:"protected,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConnectRequest) Reset()         { *m = ConnectRequest{} }
+func (m *ConnectRequest) String() string { return proto.CompactTextString(m) }
+func (*ConnectRequest) ProtoMessage()    {}
+func (*ConnectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{48}
+}
+
+func (m *ConnectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnectRequest.Unmarshal(m, b)
+}
+func (m *ConnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnectRequest.Marshal(b, m, deterministic)
+}
+func (m *ConnectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectRequest.Merge(m, src)
+}
+func (m *ConnectRequest) XXX_Size() int {
+	return xxx_messageInfo_ConnectRequest.Size(m)
+}
+func (m *ConnectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectRequest proto.InternalMessageInfo
+
+func (m *ConnectRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ConnectRequest) GetProtected() bool {
+	if m != nil {
+		return m.Protected
+	}
+	return false
+}
+
+type PeerAddressList struct {
+	Addresses            []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerAddressList) Reset()         { *m = PeerAddressList{} }
+func (m *PeerAddressList) String() string { return proto.CompactTextString(m) }
+func (*PeerAddressList) ProtoMessage()    {}
+func (*PeerAddressList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{49}
+}
+
+func (m *PeerAddressList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerAddressList.Unmarshal(m, b)
+}
+func (m *PeerAddressList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerAddressList.Marshal(b, m, deterministic)
+}
+func (m *PeerAddressList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerAddressList.Merge(m, src)
+}
+func (m *PeerAddressList) XXX_Size() int {
+	return xxx_messageInfo_PeerAddressList.Size(m)
+}
+func (m *PeerAddressList) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerAddressList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerAddressList proto.InternalMessageInfo
+
+func (m *PeerAddressList) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+type JoinResponse struct {
+	JoinedChannel        *Channel `protobuf:"bytes,1,opt,name=joinedChannel,proto3" json:"joinedChannel,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JoinResponse) Reset()         { *m = JoinResponse{} }
+func (m *JoinResponse) String() string { return proto.CompactTextString(m) }
+func (*JoinResponse) ProtoMessage()    {}
+func (*JoinResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{50}
+}
+
+func (m *JoinResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinResponse.Unmarshal(m, b)
+}
+func (m *JoinResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinResponse.Marshal(b, m, deterministic)
+}
+func (m *JoinResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinResponse.Merge(m, src)
+}
+func (m *JoinResponse) XXX_Size() int {
+	return xxx_messageInfo_JoinResponse.Size(m)
+}
+func (m *JoinResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinResponse proto.InternalMessageInfo
+
+func (m *JoinResponse) GetJoinedChannel() *Channel {
+	if m != nil {
+		return m.JoinedChannel
+	}
+	return nil
+}
+
+type StorageStats struct {
+	Entries              uint64   `protobuf:"varint,1,opt,name=entries,proto3" json:"entries,omitempty"`
+	Orders               uint64   `protobuf:"varint,2,opt,name=orders,proto3" json:"orders,omitempty"`
+	Channels             uint64   `protobuf:"varint,3,opt,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StorageStats) Reset()         { *m = StorageStats{} }
+func (m *StorageStats) String() string { return proto.CompactTextString(m) }
+func (*StorageStats) ProtoMessage()    {}
+func (*StorageStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{51}
+}
+
+func (m *StorageStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageStats.Unmarshal(m, b)
+}
+func (m *StorageStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageStats.Marshal(b, m, deterministic)
+}
+func (m *StorageStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageStats.Merge(m, src)
+}
+func (m *StorageStats) XXX_Size() int {
+	return xxx_messageInfo_StorageStats.Size(m)
+}
+func (m *StorageStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageStats proto.InternalMessageInfo
+
+func (m *StorageStats) GetEntries() uint64 {
+	if m != nil {
+		return m.Entries
+	}
+	return 0
+}
+
+func (m *StorageStats) GetOrders() uint64 {
+	if m != nil {
+		return m.Orders
+	}
+	return 0
+}
+
+func (m *StorageStats) GetChannels() uint64 {
+	if m != nil {
+		return m.Channels
+	}
+	return 0
+}
+
+type Offender struct {
+	Peer                 string               `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	ChannelID            []byte               `protobuf:"bytes,2,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	DroppedMessages      uint64               `protobuf:"varint,3,opt,name=droppedMessages,proto3" json:"droppedMessages,omitempty"`
+	RejectedOrders       uint64               `protobuf:"varint,4,opt,name=rejectedOrders,proto3" json:"rejectedOrders,omitempty"`
+	LastOffense          *timestamp.Timestamp `protobuf:"bytes,5,opt,name=lastOffense,proto3" json:"lastOffense,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Offender) Reset()         { *m = Offender{} }
+func (m *Offender) String() string { return proto.CompactTextString(m) }
+func (*Offender) ProtoMessage()    {}
+func (*Offender) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{52}
+}
+
+func (m *Offender) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Offender.Unmarshal(m, b)
+}
+func (m *Offender) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Offender.Marshal(b, m, deterministic)
+}
+func (m *Offender) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Offender.Merge(m, src)
+}
+func (m *Offender) XXX_Size() int {
+	return xxx_messageInfo_Offender.Size(m)
+}
+func (m *Offender) XXX_DiscardUnknown() {
+	xxx_messageInfo_Offender.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Offender proto.InternalMessageInfo
+
+func (m *Offender) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+func (m *Offender) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *Offender) GetDroppedMessages() uint64 {
+	if m != nil {
+		return m.DroppedMessages
+	}
+	return 0
+}
+
+func (m *Offender) GetRejectedOrders() uint64 {
+	if m != nil {
+		return m.RejectedOrders
+	}
+	return 0
+}
+
+func (m *Offender) GetLastOffense() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastOffense
+	}
+	return nil
+}
+
+type OffenderList struct {
+	Offenders            []*Offender `protobuf:"bytes,1,rep,name=offenders,proto3" json:"offenders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *OffenderList) Reset()         { *m = OffenderList{} }
+func (m *OffenderList) String() string { return proto.CompactTextString(m) }
+func (*OffenderList) ProtoMessage()    {}
+func (*OffenderList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{53}
+}
+
+func (m *OffenderList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OffenderList.Unmarshal(m, b)
+}
+func (m *OffenderList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OffenderList.Marshal(b, m, deterministic)
+}
+func (m *OffenderList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OffenderList.Merge(m, src)
+}
+func (m *OffenderList) XXX_Size() int {
+	return xxx_messageInfo_OffenderList.Size(m)
+}
+func (m *OffenderList) XXX_DiscardUnknown() {
+	xxx_messageInfo_OffenderList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OffenderList proto.InternalMessageInfo
+
+func (m *OffenderList) GetOffenders() []*Offender {
+	if m != nil {
+		return m.Offenders
+	}
+	return nil
+}
+
+type NodeInfo struct {
+	Id                   string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Addresses            []string      `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	ProtocolVersion      string        `protobuf:"bytes,3,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
+	Topics               []string      `protobuf:"bytes,4,rep,name=topics,proto3" json:"topics,omitempty"`
+	Uptime               uint64        `protobuf:"varint,5,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	Storage              *StorageStats `protobuf:"bytes,6,opt,name=storage,proto3" json:"storage,omitempty"`
+	Capabilities         []string      `protobuf:"bytes,7,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *NodeInfo) Reset()         { *m = NodeInfo{} }
+func (m *NodeInfo) String() string { return proto.CompactTextString(m) }
+func (*NodeInfo) ProtoMessage()    {}
+func (*NodeInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{54}
+}
+
+func (m *NodeInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeInfo.Unmarshal(m, b)
+}
+func (m *NodeInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeInfo.Marshal(b, m, deterministic)
+}
+func (m *NodeInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeInfo.Merge(m, src)
+}
+func (m *NodeInfo) XXX_Size() int {
+	return xxx_messageInfo_NodeInfo.Size(m)
+}
+func (m *NodeInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeInfo proto.InternalMessageInfo
+
+func (m *NodeInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *NodeInfo) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetProtocolVersion() string {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return ""
+}
+
+func (m *NodeInfo) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetUptime() uint64 {
+	if m != nil {
+		return m.Uptime
+	}
+	return 0
+}
+
+func (m *NodeInfo) GetStorage() *StorageStats {
+	if m != nil {
+		return m.Storage
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+type APIKey struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Role                 APIKeyRole           `protobuf:"varint,3,opt,name=role,proto3,enum=pb.APIKeyRole" json:"role,omitempty"`
+	Created              *timestamp.Timestamp `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *APIKey) Reset()         { *m = APIKey{} }
+func (m *APIKey) String() string { return proto.CompactTextString(m) }
+func (*APIKey) ProtoMessage()    {}
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{55}
+}
+
+func (m *APIKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_APIKey.Unmarshal(m, b)
+}
+func (m *APIKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_APIKey.Marshal(b, m, deterministic)
+}
+func (m *APIKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_APIKey.Merge(m, src)
+}
+func (m *APIKey) XXX_Size() int {
+	return xxx_messageInfo_APIKey.Size(m)
+}
+func (m *APIKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_APIKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_APIKey proto.InternalMessageInfo
+
+func (m *APIKey) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *APIKey) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *APIKey) GetRole() APIKeyRole {
+	if m != nil {
+		return m.Role
+	}
+	return APIKeyRole_READ_ONLY
+}
+
+func (m *APIKey) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+type APIKeyList struct {
+	Keys                 []*APIKey `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *APIKeyList) Reset()         { *m = APIKeyList{} }
+func (m *APIKeyList) String() string { return proto.CompactTextString(m) }
+func (*APIKeyList) ProtoMessage()    {}
+func (*APIKeyList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{56}
+}
+
+func (m *APIKeyList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_APIKeyList.Unmarshal(m, b)
+}
+func (m *APIKeyList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_APIKeyList.Marshal(b, m, deterministic)
+}
+func (m *APIKeyList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_APIKeyList.Merge(m, src)
+}
+func (m *APIKeyList) XXX_Size() int {
+	return xxx_messageInfo_APIKeyList.Size(m)
+}
+func (m *APIKeyList) XXX_DiscardUnknown() {
+	xxx_messageInfo_APIKeyList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_APIKeyList proto.InternalMessageInfo
+
+func (m *APIKeyList) GetKeys() []*APIKey {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+type CreateAPIKeyRequest struct {
+	Name                 string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Role                 APIKeyRole `protobuf:"varint,2,opt,name=role,proto3,enum=pb.APIKeyRole" json:"role,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CreateAPIKeyRequest) Reset()         { *m = CreateAPIKeyRequest{} }
+func (m *CreateAPIKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateAPIKeyRequest) ProtoMessage()    {}
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{57}
+}
+
+func (m *CreateAPIKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAPIKeyRequest.Unmarshal(m, b)
+}
+func (m *CreateAPIKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAPIKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateAPIKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAPIKeyRequest.Merge(m, src)
+}
+func (m *CreateAPIKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateAPIKeyRequest.Size(m)
+}
+func (m *CreateAPIKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAPIKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAPIKeyRequest proto.InternalMessageInfo
+
+func (m *CreateAPIKeyRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateAPIKeyRequest) GetRole() APIKeyRole {
+	if m != nil {
+		return m.Role
+	}
+	return APIKeyRole_READ_ONLY
+}
+
+type CreateAPIKeyResponse struct {
+	ApiKey               *APIKey  `protobuf:"bytes,1,opt,name=apiKey,proto3" json:"apiKey,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateAPIKeyResponse) Reset()         { *m = CreateAPIKeyResponse{} }
+func (m *CreateAPIKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateAPIKeyResponse) ProtoMessage()    {}
+func (*CreateAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{58}
+}
+
+func (m *CreateAPIKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAPIKeyResponse.Unmarshal(m, b)
+}
+func (m *CreateAPIKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAPIKeyResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateAPIKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAPIKeyResponse.Merge(m, src)
+}
+func (m *CreateAPIKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateAPIKeyResponse.Size(m)
+}
+func (m *CreateAPIKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAPIKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAPIKeyResponse proto.InternalMessageInfo
+
+func (m *CreateAPIKeyResponse) GetApiKey() *APIKey {
+	if m != nil {
+		return m.ApiKey
+	}
+	return nil
+}
+
+func (m *CreateAPIKeyResponse) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type APIKeySpecificRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *APIKeySpecificRequest) Reset()         { *m = APIKeySpecificRequest{} }
+func (m *APIKeySpecificRequest) String() string { return proto.CompactTextString(m) }
+func (*APIKeySpecificRequest) ProtoMessage()    {}
+func (*APIKeySpecificRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{59}
+}
+
+func (m *APIKeySpecificRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_APIKeySpecificRequest.Unmarshal(m, b)
+}
+func (m *APIKeySpecificRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_APIKeySpecificRequest.Marshal(b, m, deterministic)
+}
+func (m *APIKeySpecificRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_APIKeySpecificRequest.Merge(m, src)
+}
+func (m *APIKeySpecificRequest) XXX_Size() int {
+	return xxx_messageInfo_APIKeySpecificRequest.Size(m)
+}
+func (m *APIKeySpecificRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_APIKeySpecificRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_APIKeySpecificRequest proto.InternalMessageInfo
+
+func (m *APIKeySpecificRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type Empty struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Empty) Reset()         { *m = Empty{} }
+func (m *Empty) String() string { return proto.CompactTextString(m) }
+func (*Empty) ProtoMessage()    {}
+func (*Empty) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{60}
+}
+
+func (m *Empty) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Empty.Unmarshal(m, b)
+}
+func (m *Empty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Empty.Marshal(b, m, deterministic)
+}
+func (m *Empty) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Empty.Merge(m, src)
+}
+func (m *Empty) XXX_Size() int {
+	return xxx_messageInfo_Empty.Size(m)
+}
+func (m *Empty) XXX_DiscardUnknown() {
+	xxx_messageInfo_Empty.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Empty proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("pb.State", State_name, State_value)
+	proto.RegisterEnum("pb.Operation", Operation_name, Operation_value)
+	proto.RegisterEnum("pb.ChannelType", ChannelType_name, ChannelType_value)
+	proto.RegisterEnum("pb.EventType", EventType_name, EventType_value)
+	proto.RegisterEnum("pb.WebsocketAction", WebsocketAction_name, WebsocketAction_value)
+	proto.RegisterEnum("pb.APIKeyRole", APIKeyRole_name, APIKeyRole_value)
+	proto.RegisterType((*Peer)(nil), "pb.Peer")
+	proto.RegisterType((*Order)(nil), "pb.Order")
+	proto.RegisterType((*OrderState)(nil), "pb.OrderState")
+	proto.RegisterType((*OrderList)(nil), "pb.OrderList")
+	proto.RegisterType((*StreamFrame)(nil), "pb.StreamFrame")
+	proto.RegisterType((*Tombstone)(nil), "pb.Tombstone")
+	proto.RegisterType((*SyncBucket)(nil), "pb.SyncBucket")
+	proto.RegisterType((*SyncFingerprints)(nil), "pb.SyncFingerprints")
+	proto.RegisterType((*SyncItem)(nil), "pb.SyncItem")
+	proto.RegisterType((*SyncDigest)(nil), "pb.SyncDigest")
+	proto.RegisterType((*SyncUpdate)(nil), "pb.SyncUpdate")
+	proto.RegisterType((*QuoteRequest)(nil), "pb.QuoteRequest")
+	proto.RegisterType((*QuoteRequestList)(nil), "pb.QuoteRequestList")
+	proto.RegisterType((*Quote)(nil), "pb.Quote")
+	proto.RegisterType((*QuoteList)(nil), "pb.QuoteList")
+	proto.RegisterType((*Channel)(nil), "pb.Channel")
+	proto.RegisterType((*ChannelList)(nil), "pb.ChannelList")
+	proto.RegisterType((*NetworkChannel)(nil), "pb.NetworkChannel")
+	proto.RegisterType((*NetworkChannelList)(nil), "pb.NetworkChannelList")
+	proto.RegisterType((*DirectoryRecord)(nil), "pb.DirectoryRecord")
+	proto.RegisterType((*ChannelStats)(nil), "pb.ChannelStats")
+	proto.RegisterType((*ChannelStatsList)(nil), "pb.ChannelStatsList")
+	proto.RegisterType((*SyncStatus)(nil), "pb.SyncStatus")
+	proto.RegisterType((*SyncStatusList)(nil), "pb.SyncStatusList")
+	proto.RegisterType((*Recipient)(nil), "pb.Recipient")
+	proto.RegisterType((*WireMessage)(nil), "pb.WireMessage")
+	proto.RegisterType((*Handshake)(nil), "pb.Handshake")
+	proto.RegisterType((*OrderEvent)(nil), "pb.OrderEvent")
+	proto.RegisterType((*StreamEventsRequest)(nil), "pb.StreamEventsRequest")
+	proto.RegisterType((*WebsocketFilter)(nil), "pb.WebsocketFilter")
+	proto.RegisterType((*WebsocketRequest)(nil), "pb.WebsocketRequest")
+	proto.RegisterType((*WebsocketEvent)(nil), "pb.WebsocketEvent")
+	proto.RegisterType((*WebsocketResponse)(nil), "pb.WebsocketResponse")
+	proto.RegisterType((*CreateRequest)(nil), "pb.CreateRequest")
+	proto.RegisterType((*CreateQuoteRequest)(nil), "pb.CreateQuoteRequest")
+	proto.RegisterType((*SendQuoteRequest)(nil), "pb.SendQuoteRequest")
+	proto.RegisterType((*QuoteRequestSpecificRequest)(nil), "pb.QuoteRequestSpecificRequest")
+	proto.RegisterType((*QuoteSpecificRequest)(nil), "pb.QuoteSpecificRequest")
+	proto.RegisterType((*JoinRequest)(nil), "pb.JoinRequest")
+	proto.RegisterType((*ChannelOptions)(nil), "pb.ChannelOptions")
+	proto.RegisterType((*OrderSpecificRequest)(nil), "pb.OrderSpecificRequest")
+	proto.RegisterType((*ChannelSpecificRequest)(nil), "pb.ChannelSpecificRequest")
+	proto.RegisterType((*CreateResponse)(nil), "pb.CreateResponse")
+	proto.RegisterType((*OrderListResponse)(nil), "pb.OrderListResponse")
+	proto.RegisterType((*ChannelListResponse)(nil), "pb.ChannelListResponse")
+	proto.RegisterType((*PeerListResponse)(nil), "pb.PeerListResponse")
+	proto.RegisterType((*DirectMessageRequest)(nil), "pb.DirectMessageRequest")
+	proto.RegisterType((*DirectMessage)(nil), "pb.DirectMessage")
+	proto.RegisterType((*ConnectRequest)(nil), "pb.ConnectRequest")
+	proto.RegisterType((*PeerAddressList)(nil), "pb.PeerAddressList")
+	proto.RegisterType((*JoinResponse)(nil), "pb.JoinResponse")
+	proto.RegisterType((*StorageStats)(nil), "pb.StorageStats")
+	proto.RegisterType((*Offender)(nil), "pb.Offender")
+	proto.RegisterType((*OffenderList)(nil), "pb.OffenderList")
+	proto.RegisterType((*NodeInfo)(nil), "pb.NodeInfo")
+	proto.RegisterType((*APIKey)(nil), "pb.APIKey")
+	proto.RegisterType((*APIKeyList)(nil), "pb.APIKeyList")
+	proto.RegisterType((*CreateAPIKeyRequest)(nil), "pb.CreateAPIKeyRequest")
+	proto.RegisterType((*CreateAPIKeyResponse)(nil), "pb.CreateAPIKeyResponse")
+	proto.RegisterType((*APIKeySpecificRequest)(nil), "pb.APIKeySpecificRequest")
+	proto.RegisterType((*Empty)(nil), "pb.Empty")
+}
+
+func init() { proto.RegisterFile("sprawl.proto", fileDescriptor_b5e409e9578376a3) }
+
+var fileDescriptor_b5e409e9578376a3 = []byte{
+	// 3474 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x39, 0x4d, 0x6f, 0xec, 0x46,
+	0x72, 0xe6, 0x7c, 0x4f, 0xcd, 0x87, 0xa8, 0x96, 0x9e, 0x96, 0x3b, 0xeb, 0xd8, 0xda, 0x5e, 0xc4,
+	0xab, 0xc8, 0xb6, 0x64, 0x2b, 0x86, 0xe3, 0x75, 0x9c, 0x75, 0xe6, 0x69, 0x46, 0xf2, 0xd8, 0x7a,
+	0x23, 0x3d, 0x8e, 0xf4, 0xd6, 0x06, 0x02, 0xbc, 0x50, 0x9c, 0x96, 0xcc, 0xd5, 0x0c, 0x49, 0x93,
+	0x94, 0x6c, 0xc1, 0x78, 0x97, 0x1c, 0xf6, 0x98, 0x4b, 0x90, 0x9c, 0x12, 0xe4, 0x94, 0x20, 0xc7,
+	0xfc, 0x90, 0x1c, 0x02, 0xe4, 0x1c, 0x60, 0x11, 0x04, 0x9b, 0x1f, 0x11, 0x20, 0x08, 0xba, 0xba,
+	0x9b, 0x6c, 0x72, 0xf4, 0x31, 0x1b, 0x04, 0x7b, 0x63, 0x7d, 0x74, 0x75, 0x7d, 0x75, 0x75, 0x15,
+	0x1b, 0xda, 0x71, 0x18, 0x39, 0xdf, 0xce, 0x76, 0xc2, 0x28, 0x48, 0x02, 0x52, 0x0a, 0xcf, 0x7b,
+	0x6f, 0x5e, 0x06, 0xc1, 0xe5, 0x8c, 0xed, 0x22, 0xe6, 0xfc, 0xfa, 0x62, 0x37, 0xf1, 0xe6, 0x2c,
+	0x4e, 0x9c, 0x79, 0x28, 0x98, 0x7a, 0xaf, 0x4b, 0x06, 0x27, 0xf4, 0x76, 0x1d, 0xdf, 0x0f, 0x12,
+	0x27, 0xf1, 0x02, 0x3f, 0x16, 0x54, 0xba, 0x01, 0x95, 0x13, 0xc6, 0x22, 0xd2, 0x85, 0x92, 0x37,
+	0xb5, 0x8c, 0x4d, 0x63, 0xab, 0x69, 0x97, 0xbc, 0x29, 0xfd, 0x9f, 0x12, 0x54, 0x8f, 0xa3, 0x69,
+	0x8e, 0xd2, 0xe6, 0x14, 0xf2, 0x01, 0xd4, 0xdd, 0x88, 0x39, 0x09, 0x9b, 0x5a, 0xa5, 0x4d, 0x63,
+	0xab, 0xb5, 0xd7, 0xdb, 0x11, 0x3b, 0xec, 0x28, 0x15, 0x76, 0x4e, 0x95, 0x0a, 0xb6, 0x62, 0x25,
+	0xeb, 0x50, 0x75, 0xe2, 0x98, 0x25, 0x56, 0x19, 0xb7, 0x10, 0x00, 0xa1, 0xd0, 0x76, 0x83, 0x6b,
+	0x3f, 0x61, 0x51, 0x1f, 0x89, 0x15, 0x24, 0xe6, 0x70, 0x64, 0x03, 0x6a, 0xce, 0x9c, 0x23, 0xac,
+	0xea, 0xa6, 0xb1, 0x55, 0xb1, 0x25, 0xc4, 0x25, 0x86, 0x91, 0xe7, 0x32, 0xab, 0xb6, 0x69, 0x6c,
+	0x95, 0x6c, 0x01, 0x90, 0x37, 0xa1, 0x1a, 0x27, 0x4e, 0xc2, 0xac, 0xfa, 0xa6, 0xb1, 0xd5, 0xdd,
+	0x6b, 0xee, 0x84, 0xe7, 0x3b, 0x13, 0x8e, 0xb0, 0x05, 0x9e, 0xbc, 0x0e, 0xcd, 0xd8, 0xbb, 0xf4,
+	0x9d, 0xe4, 0x3a, 0x62, 0x56, 0x03, 0xad, 0xca, 0x10, 0x5c, 0xa8, 0x1f, 0xf8, 0x2e, 0xb3, 0x9a,
+	0x9b, 0xc6, 0x56, 0xc7, 0x16, 0x00, 0xe9, 0x41, 0x63, 0xce, 0x12, 0x67, 0xea, 0x24, 0x8e, 0x05,
+	0xb8, 0x24, 0x85, 0xf9, 0x0a, 0x34, 0xd5, 0x6a, 0xa1, 0x76, 0x02, 0x20, 0x96, 0x74, 0x52, 0x10,
+	0x59, 0x6d, 0x5c, 0xa0, 0x40, 0xf2, 0x16, 0x74, 0x51, 0x91, 0x49, 0xaa, 0x44, 0x07, 0x19, 0x0a,
+	0x58, 0x3a, 0x07, 0x40, 0xff, 0xa3, 0xf2, 0x0b, 0x41, 0x48, 0xcd, 0x2c, 0xdd, 0x63, 0x66, 0x6a,
+	0x48, 0x59, 0x37, 0xc4, 0x82, 0xfa, 0x94, 0xcd, 0x18, 0x8f, 0x1d, 0x77, 0x75, 0xc3, 0x56, 0x20,
+	0xdd, 0x81, 0x26, 0x6e, 0x77, 0xe4, 0xc5, 0x09, 0xf9, 0x31, 0xd4, 0x02, 0x0e, 0xc4, 0x96, 0xb1,
+	0x59, 0xde, 0x6a, 0x09, 0xf1, 0x48, 0xb6, 0x25, 0x81, 0x32, 0x68, 0x4d, 0x92, 0x88, 0x39, 0xf3,
+	0x83, 0xc8, 0x99, 0xeb, 0xfa, 0x55, 0x50, 0x3f, 0x0b, 0xea, 0x11, 0x0b, 0x67, 0xb7, 0xa7, 0x01,
+	0x6a, 0x58, 0xb1, 0x15, 0x28, 0x28, 0xdf, 0x5c, 0xb3, 0x58, 0xa4, 0x42, 0xc3, 0x56, 0x20, 0x21,
+	0x50, 0x41, 0x0f, 0x57, 0xd0, 0x4a, 0xfc, 0xa6, 0xff, 0x6c, 0x40, 0xf3, 0x34, 0x98, 0x9f, 0xc7,
+	0x49, 0xe0, 0xa3, 0xfa, 0xb8, 0xfd, 0x68, 0x20, 0x5d, 0xa1, 0xc0, 0xcc, 0xdc, 0x92, 0x6e, 0xee,
+	0x07, 0x99, 0xb9, 0xe5, 0xc7, 0x53, 0x55, 0xb2, 0x72, 0xdf, 0xa2, 0x58, 0x54, 0x24, 0x67, 0xbc,
+	0xc0, 0xe7, 0x53, 0xa8, 0x5a, 0x48, 0x21, 0x3a, 0x00, 0x98, 0xdc, 0xfa, 0xee, 0xd3, 0x6b, 0xf7,
+	0x8a, 0x61, 0x96, 0x7a, 0xfe, 0x94, 0x7d, 0x87, 0x0a, 0x77, 0x6c, 0x01, 0x90, 0x4d, 0x68, 0x5d,
+	0x78, 0xfe, 0x25, 0x8b, 0xc2, 0xc8, 0xf3, 0x13, 0x54, 0xba, 0x6d, 0xeb, 0x28, 0xfa, 0x09, 0x98,
+	0x5c, 0xca, 0x41, 0x86, 0x8a, 0xc9, 0x16, 0xd4, 0xcf, 0x51, 0xaa, 0x8a, 0x4b, 0x17, 0xc3, 0x9e,
+	0x6e, 0x66, 0x2b, 0x32, 0xfd, 0x1c, 0x1a, 0x1c, 0x3d, 0x4a, 0xd8, 0x7c, 0x21, 0x75, 0xee, 0x76,
+	0x95, 0x95, 0x77, 0x95, 0x96, 0x19, 0x9f, 0x0b, 0x7b, 0x06, 0xde, 0x25, 0x0f, 0x92, 0x95, 0xd7,
+	0xa1, 0x93, 0xee, 0x49, 0x28, 0x54, 0xbd, 0x84, 0xcd, 0x63, 0xab, 0x84, 0xba, 0xb5, 0x95, 0x6e,
+	0x5c, 0x09, 0x5b, 0x90, 0xe8, 0x8d, 0x90, 0x75, 0x16, 0x4e, 0x79, 0x8e, 0x3e, 0x9e, 0x66, 0xe4,
+	0x5d, 0x80, 0x44, 0x85, 0x5f, 0x49, 0xee, 0x70, 0xb6, 0x34, 0x29, 0x6c, 0x8d, 0x81, 0xd7, 0x8a,
+	0x6f, 0x1d, 0x5f, 0x18, 0x51, 0xde, 0x6a, 0xdb, 0x12, 0xa2, 0xbf, 0x2e, 0x41, 0xfb, 0xf9, 0x75,
+	0x90, 0x30, 0x5b, 0xe6, 0x5a, 0xd1, 0x29, 0xaf, 0x43, 0xd3, 0xfd, 0xda, 0xf1, 0x7d, 0x36, 0x1b,
+	0x0d, 0x64, 0x38, 0x32, 0x04, 0xa7, 0xca, 0x24, 0x65, 0x91, 0x2c, 0x60, 0x19, 0x22, 0x2b, 0x6d,
+	0x95, 0x87, 0x4a, 0x5b, 0xf5, 0xc1, 0xd2, 0x56, 0xcb, 0x95, 0x36, 0xad, 0xc4, 0xd6, 0x97, 0x2f,
+	0xb1, 0x1f, 0x40, 0x9d, 0x7d, 0x17, 0x7a, 0x11, 0x8b, 0xad, 0xc6, 0xe3, 0xab, 0x24, 0x6b, 0x3e,
+	0x99, 0x9b, 0xc5, 0x7a, 0xb8, 0x05, 0x2b, 0x8e, 0xeb, 0xb2, 0x30, 0x61, 0x53, 0xf4, 0xdf, 0x68,
+	0x20, 0x0b, 0x60, 0x11, 0x4d, 0xff, 0x14, 0x4c, 0xdd, 0xc3, 0x58, 0x47, 0xde, 0x81, 0x86, 0x74,
+	0x93, 0x0a, 0xb1, 0xc9, 0x63, 0xa7, 0xf3, 0xd9, 0x29, 0x07, 0xfd, 0xb5, 0x01, 0x55, 0x24, 0xdd,
+	0x15, 0x1d, 0xc9, 0x95, 0x45, 0x27, 0x45, 0xe4, 0x63, 0x57, 0x2e, 0xc6, 0x6e, 0x03, 0x6a, 0xdf,
+	0x70, 0xa1, 0x91, 0x0c, 0x8f, 0x84, 0xb2, 0x53, 0x5e, 0xbd, 0xe7, 0x94, 0x6b, 0xee, 0xac, 0xfd,
+	0x1f, 0xdd, 0x59, 0x2f, 0xd6, 0x86, 0x1d, 0x68, 0xa2, 0x85, 0xaa, 0xca, 0xa2, 0x2e, 0xb9, 0xf4,
+	0x17, 0xbe, 0x91, 0x04, 0x7a, 0x08, 0xf5, 0x7d, 0x61, 0xc9, 0x82, 0x4f, 0xde, 0x81, 0x7a, 0x10,
+	0xe2, 0x4d, 0x2e, 0xaf, 0x61, 0xc2, 0x97, 0x4b, 0xee, 0x63, 0x41, 0xb1, 0x15, 0x0b, 0xfd, 0x10,
+	0x5a, 0x92, 0x84, 0x5b, 0xff, 0x14, 0x1a, 0xd2, 0x43, 0x6a, 0xf3, 0x96, 0xb6, 0xda, 0x4e, 0x89,
+	0xf4, 0xef, 0x0c, 0xe8, 0x8e, 0x59, 0xf2, 0x6d, 0x10, 0x5d, 0x29, 0x45, 0x7e, 0x1f, 0xea, 0x92,
+	0x8c, 0xda, 0x14, 0x96, 0x2a, 0x1a, 0x5e, 0xcf, 0x8c, 0x45, 0x42, 0xbb, 0x8e, 0x2d, 0x00, 0x1e,
+	0x8d, 0x5f, 0x06, 0x9e, 0x9f, 0x56, 0x19, 0x09, 0x91, 0x0f, 0xa1, 0x31, 0x73, 0xe2, 0x64, 0xc2,
+	0x98, 0x6f, 0x55, 0x1e, 0xf5, 0x76, 0xca, 0x4b, 0x07, 0x40, 0xf2, 0xea, 0xa1, 0x79, 0x3b, 0x0b,
+	0xe6, 0xa1, 0x73, 0xf2, 0x9c, 0x9a, 0x95, 0x7f, 0x69, 0xc0, 0xca, 0xc0, 0x8b, 0x98, 0x9b, 0x04,
+	0xd1, 0xad, 0xcd, 0xdc, 0x20, 0x9a, 0x2e, 0xed, 0x22, 0x9e, 0x27, 0xd7, 0xe1, 0x74, 0xd9, 0x7e,
+	0x48, 0xb2, 0xe6, 0xf3, 0xa4, 0x5c, 0xcc, 0x93, 0x7f, 0x2d, 0x41, 0x5b, 0xee, 0xc4, 0x6f, 0xf5,
+	0xf8, 0xae, 0x13, 0x31, 0x67, 0xf1, 0xd7, 0x27, 0xd2, 0xc3, 0x65, 0x5e, 0x91, 0x52, 0x04, 0x79,
+	0x03, 0x20, 0x08, 0x99, 0x7f, 0x2c, 0x8a, 0x6b, 0x19, 0x6b, 0x8b, 0x86, 0xe1, 0xb5, 0x69, 0x16,
+	0xb8, 0x57, 0x6c, 0x2a, 0x39, 0x2a, 0xc8, 0x91, 0xc3, 0x91, 0x6d, 0x30, 0xe7, 0x2c, 0x8e, 0x9d,
+	0x4b, 0x16, 0xdb, 0xcc, 0x65, 0xde, 0x0d, 0x9b, 0xca, 0x06, 0x6c, 0x01, 0x9f, 0xe7, 0xfd, 0x25,
+	0x73, 0xb9, 0x2f, 0x6a, 0x45, 0x5e, 0x81, 0x27, 0x3f, 0x87, 0x36, 0x8f, 0x5e, 0xdf, 0x4d, 0xbc,
+	0x1b, 0x2f, 0xb9, 0x5d, 0xa2, 0xc0, 0xe5, 0xf8, 0xd3, 0x4c, 0xb9, 0xf5, 0xdd, 0x25, 0xca, 0x5c,
+	0xca, 0xcb, 0xeb, 0x93, 0xee, 0x51, 0x55, 0x9f, 0x0a, 0x31, 0x36, 0xb5, 0x18, 0x23, 0x9f, 0x96,
+	0x25, 0xff, 0x54, 0x11, 0xb7, 0x17, 0xc7, 0x5f, 0xc7, 0xf9, 0xb2, 0x63, 0x14, 0xcb, 0x8e, 0x05,
+	0xf5, 0xf8, 0xd6, 0x77, 0x3d, 0xff, 0x12, 0xb3, 0xa2, 0x61, 0x2b, 0x90, 0x1f, 0x81, 0x28, 0xb8,
+	0xf6, 0xa7, 0x2a, 0x30, 0x12, 0xe2, 0x41, 0x51, 0xa5, 0x70, 0xc2, 0xfc, 0x44, 0x05, 0x45, 0xc7,
+	0xf1, 0xe6, 0x51, 0xc1, 0x07, 0x8e, 0x37, 0x4b, 0x43, 0x52, 0xc0, 0x72, 0xdd, 0xb8, 0xe1, 0x22,
+	0x3d, 0x6a, 0x22, 0x3d, 0x52, 0x04, 0xf9, 0x48, 0x50, 0x6d, 0xbe, 0xef, 0x12, 0xfe, 0xcf, 0x98,
+	0xf9, 0x4a, 0x9f, 0x7d, 0x27, 0x57, 0x3e, 0xee, 0xfd, 0x8c, 0x99, 0x6b, 0x2e, 0xae, 0xf4, 0x34,
+	0x99, 0x9a, 0x42, 0xf3, 0x3c, 0x96, 0xec, 0x00, 0xc9, 0xee, 0xf3, 0x94, 0x17, 0x90, 0xf7, 0x0e,
+	0x0a, 0xb7, 0x14, 0x5b, 0x0b, 0x74, 0x99, 0x68, 0xc1, 0x33, 0x04, 0xf9, 0x18, 0x80, 0x2b, 0x3f,
+	0xf2, 0x31, 0x5d, 0xda, 0x8f, 0x2a, 0xac, 0x71, 0xab, 0xb5, 0x36, 0x0b, 0x1d, 0x2f, 0xb2, 0x3a,
+	0xcb, 0xad, 0x15, 0xdc, 0xf4, 0x13, 0xe8, 0x66, 0x99, 0x82, 0xa9, 0xb6, 0xbd, 0x90, 0x6a, 0x69,
+	0xf3, 0x26, 0xb8, 0xb4, 0x44, 0xfb, 0x09, 0x34, 0x6d, 0xe6, 0x7a, 0xa1, 0xc7, 0x4d, 0xd8, 0x80,
+	0x5a, 0xc8, 0xb4, 0x96, 0x57, 0x42, 0xf4, 0x57, 0x06, 0xb4, 0x7e, 0xe1, 0x45, 0xec, 0x99, 0x38,
+	0x60, 0x8f, 0xa4, 0xe3, 0xdb, 0xd0, 0x0c, 0x42, 0x16, 0xe1, 0xe8, 0x27, 0x67, 0x06, 0x6c, 0xa3,
+	0x8e, 0x15, 0xd2, 0xce, 0xe8, 0x69, 0x23, 0x5e, 0xce, 0x1a, 0x71, 0x9e, 0xcf, 0x37, 0x2c, 0x8a,
+	0xf9, 0xf2, 0x0a, 0x16, 0x74, 0x05, 0xd2, 0x4b, 0x68, 0x7e, 0xe6, 0xf8, 0xd3, 0xf8, 0x6b, 0xe7,
+	0x8a, 0xe9, 0x6c, 0x62, 0x96, 0x54, 0x20, 0xd7, 0x0f, 0x9d, 0xe6, 0x06, 0xb3, 0xb4, 0x62, 0xa5,
+	0x08, 0xec, 0x96, 0x9c, 0xd0, 0x39, 0xf7, 0x66, 0x5e, 0xe2, 0xb1, 0x18, 0xdb, 0xb7, 0xa6, 0x9d,
+	0xc3, 0xd1, 0xdf, 0x18, 0x72, 0x24, 0x1a, 0xde, 0x70, 0xc7, 0xf4, 0xa0, 0x11, 0xf3, 0xac, 0xe7,
+	0xad, 0xac, 0x18, 0x3c, 0x52, 0x98, 0xfc, 0x18, 0x2a, 0xc9, 0x6d, 0xc8, 0x74, 0x4b, 0x71, 0xd1,
+	0xe9, 0x6d, 0xc8, 0x6c, 0x24, 0x3d, 0xd2, 0x35, 0x3c, 0x3a, 0x03, 0xac, 0x43, 0x75, 0x16, 0xb8,
+	0xce, 0x0c, 0x0f, 0x60, 0xc3, 0x16, 0x00, 0xd9, 0x81, 0x0a, 0x1f, 0xbf, 0x97, 0x68, 0x18, 0x90,
+	0x8f, 0x4b, 0x61, 0x61, 0xe0, 0x7e, 0x8d, 0xa7, 0xb0, 0x62, 0x0b, 0x80, 0x06, 0xb0, 0x26, 0x66,
+	0x2b, 0xd4, 0x39, 0x56, 0x3d, 0xeb, 0x1b, 0x00, 0xa9, 0x82, 0x22, 0x89, 0xda, 0xb6, 0x86, 0xe1,
+	0x3e, 0xbc, 0x88, 0x82, 0xf9, 0x44, 0x39, 0x45, 0x0c, 0x5e, 0x39, 0x5c, 0xb6, 0x61, 0x59, 0xdf,
+	0xf0, 0x06, 0x56, 0x7e, 0xc1, 0xce, 0x63, 0x5e, 0xfe, 0x93, 0x03, 0x6f, 0x96, 0x88, 0x19, 0xe7,
+	0x81, 0x74, 0x7a, 0x17, 0x20, 0x4d, 0x17, 0x11, 0xcd, 0x85, 0x7c, 0xd2, 0x18, 0xb0, 0xcf, 0x8d,
+	0x63, 0x96, 0xa8, 0xb8, 0x4a, 0x88, 0xfa, 0x60, 0xa6, 0xfb, 0x2a, 0x2b, 0xdf, 0x86, 0x9a, 0xe3,
+	0x26, 0x2a, 0x81, 0xba, 0x7b, 0x6b, 0x5c, 0x6c, 0xca, 0xd5, 0x47, 0x92, 0x2d, 0x59, 0xc8, 0xbb,
+	0x50, 0xbf, 0x40, 0x7d, 0xd5, 0x6c, 0x90, 0xe7, 0x16, 0xb6, 0xd8, 0x8a, 0x87, 0xfe, 0x97, 0x01,
+	0xdd, 0x94, 0x28, 0xb2, 0xe8, 0xff, 0xf1, 0xd8, 0xa4, 0x39, 0x53, 0xbe, 0xb7, 0xa3, 0x6c, 0x7f,
+	0xa3, 0xb5, 0xbe, 0x32, 0xb7, 0x16, 0x5b, 0xe2, 0x1c, 0x17, 0x17, 0x8b, 0xb0, 0xde, 0xa8, 0x0a,
+	0x76, 0x81, 0x4f, 0x8f, 0x6b, 0x4d, 0x9b, 0x9b, 0xa7, 0xb0, 0xaa, 0x79, 0x36, 0x0e, 0x03, 0x3f,
+	0x66, 0xe4, 0x67, 0xd0, 0x89, 0xaf, 0xcf, 0x63, 0x37, 0xf2, 0x64, 0xe3, 0x68, 0xdc, 0xef, 0xb3,
+	0x3c, 0x27, 0xe6, 0x4d, 0x14, 0x05, 0x11, 0x3a, 0xa1, 0x69, 0x0b, 0x80, 0xfe, 0xb5, 0x01, 0x9d,
+	0x7d, 0x9c, 0x3e, 0x94, 0xb2, 0x0f, 0xbb, 0x33, 0x9d, 0x94, 0x4a, 0x0f, 0x4d, 0x4a, 0xe5, 0x07,
+	0x27, 0xa5, 0xca, 0xdd, 0x3f, 0x81, 0xaa, 0xda, 0x4f, 0x20, 0xfa, 0x37, 0x06, 0x10, 0xa1, 0x57,
+	0x6e, 0xe8, 0xfb, 0x5d, 0x2b, 0x67, 0x42, 0x39, 0x49, 0x44, 0x85, 0xe8, 0xd8, 0xfc, 0x93, 0x7e,
+	0x09, 0xe6, 0x84, 0xf9, 0xd3, 0xa2, 0x56, 0xd9, 0x70, 0x63, 0x14, 0x87, 0x9b, 0xd4, 0xc0, 0x92,
+	0x66, 0xa0, 0x92, 0x5c, 0xce, 0x24, 0xff, 0x31, 0xfc, 0x48, 0x97, 0x3a, 0x09, 0x99, 0xeb, 0x5d,
+	0x78, 0xee, 0x52, 0x9b, 0xd0, 0x31, 0xac, 0xe3, 0xe2, 0xdf, 0x6a, 0x15, 0xaf, 0xf5, 0xdf, 0xc8,
+	0x99, 0x50, 0xcc, 0x64, 0x0a, 0xa4, 0xff, 0x68, 0x40, 0xeb, 0xf3, 0xc0, 0xf3, 0x95, 0x9c, 0xd4,
+	0xb5, 0xc6, 0x43, 0xae, 0x2d, 0xdd, 0xe1, 0xda, 0x9f, 0xc8, 0x42, 0x5e, 0xc6, 0xb3, 0xb7, 0xa2,
+	0x75, 0x67, 0x5a, 0x29, 0xb7, 0xa0, 0x3e, 0x67, 0xf3, 0x73, 0xd1, 0xc9, 0xf2, 0xfa, 0xa2, 0x40,
+	0x5e, 0x32, 0xa7, 0xde, 0xc5, 0x85, 0xe7, 0x5e, 0xcf, 0x92, 0x5b, 0x19, 0x08, 0x0d, 0x43, 0xff,
+	0xde, 0x80, 0x6e, 0x7e, 0x64, 0xe2, 0x36, 0xa3, 0x7a, 0x27, 0xfc, 0xd6, 0x17, 0xfa, 0x66, 0x88,
+	0x54, 0x9f, 0xd2, 0x92, 0xfa, 0x94, 0xf3, 0xfa, 0x98, 0x50, 0xbe, 0x62, 0xb7, 0xf2, 0x0f, 0x17,
+	0xff, 0x7c, 0x54, 0xc3, 0x31, 0xac, 0x8b, 0xdf, 0x80, 0x85, 0xd0, 0xdc, 0xff, 0x2b, 0xec, 0xc1,
+	0x5f, 0x19, 0x74, 0x0b, 0x36, 0x54, 0x7b, 0x5b, 0x90, 0x58, 0x18, 0x31, 0xe8, 0xa7, 0xd0, 0x55,
+	0x67, 0x5b, 0xd6, 0x8f, 0x77, 0xa1, 0x2d, 0xff, 0x35, 0xa0, 0x4a, 0x96, 0x91, 0x15, 0x24, 0x44,
+	0xd8, 0x39, 0x32, 0xfd, 0x10, 0x56, 0xd3, 0x5f, 0x8a, 0xa9, 0x8c, 0x25, 0x7e, 0x2d, 0xfe, 0x1c,
+	0xd6, 0xb4, 0x61, 0x2e, 0x5d, 0xb9, 0xf4, 0xcc, 0xfa, 0x0e, 0x98, 0xbc, 0xcf, 0xcd, 0x2d, 0xb6,
+	0xa0, 0x2e, 0xfa, 0x26, 0xb1, 0xb6, 0x69, 0x2b, 0x90, 0x7e, 0x09, 0xeb, 0x62, 0xf4, 0x93, 0x8d,
+	0x94, 0x72, 0xc7, 0x5b, 0x3c, 0xf7, 0x65, 0x13, 0x26, 0x2d, 0x6d, 0xf0, 0xfd, 0xb8, 0x68, 0x3b,
+	0x23, 0xa1, 0x64, 0xe7, 0x76, 0x16, 0x38, 0x53, 0x75, 0x0a, 0x24, 0x48, 0xaf, 0xa1, 0x93, 0x93,
+	0xcc, 0x0b, 0x35, 0xbf, 0x8c, 0x65, 0x56, 0xe1, 0xf7, 0xfd, 0xcb, 0xf9, 0xa0, 0x13, 0xa9, 0xfe,
+	0xf7, 0xf1, 0xbf, 0x97, 0x29, 0x2f, 0xfd, 0x0c, 0xba, 0xfb, 0x81, 0xef, 0x33, 0x37, 0xd1, 0x72,
+	0xc5, 0x99, 0x4e, 0x23, 0x16, 0xc7, 0xaa, 0x29, 0x93, 0xa0, 0x6a, 0xca, 0xc4, 0xc4, 0x26, 0xe6,
+	0x94, 0x0c, 0x41, 0x77, 0x61, 0x85, 0x5b, 0xdb, 0x17, 0xcc, 0xd8, 0xc6, 0xf2, 0xd3, 0x21, 0x40,
+	0xa6, 0x3c, 0x99, 0x21, 0x68, 0x1f, 0xda, 0xe2, 0xd8, 0x4b, 0xaf, 0xbf, 0x0f, 0x1d, 0x31, 0xdf,
+	0xef, 0xdf, 0xff, 0xc3, 0x20, 0xcf, 0x41, 0xff, 0x0c, 0xda, 0x93, 0x24, 0x88, 0x9c, 0x4b, 0x26,
+	0x06, 0x5f, 0x0b, 0xea, 0xcc, 0x4f, 0x22, 0x8f, 0xc5, 0xb2, 0xc9, 0x53, 0x20, 0xaf, 0xba, 0x32,
+	0x93, 0x44, 0xa3, 0x23, 0x21, 0xde, 0x17, 0xa6, 0x79, 0x22, 0xba, 0x9c, 0x2c, 0x35, 0xfe, 0xc5,
+	0x80, 0xc6, 0xf1, 0xc5, 0x05, 0xf3, 0xf9, 0x75, 0x4c, 0xa0, 0xc2, 0x93, 0x40, 0x85, 0x83, 0x7f,
+	0x3f, 0xf2, 0x1f, 0x70, 0x0b, 0x56, 0xa6, 0x51, 0x10, 0x86, 0x6c, 0x2a, 0x43, 0xaa, 0x76, 0x28,
+	0xa2, 0xc5, 0xa0, 0x26, 0x26, 0xde, 0xdc, 0x8c, 0x5d, 0xc0, 0x92, 0x4f, 0xa0, 0xc5, 0xc7, 0x06,
+	0xd4, 0x29, 0x56, 0x57, 0xfc, 0x43, 0x71, 0xd6, 0xd9, 0xe9, 0xc7, 0xd0, 0x56, 0xd6, 0xc8, 0x21,
+	0xa3, 0x19, 0x48, 0x58, 0x9d, 0x11, 0xfc, 0x0d, 0xab, 0x98, 0xec, 0x8c, 0x4c, 0xff, 0xc3, 0x80,
+	0xc6, 0x38, 0x98, 0xb2, 0x91, 0x7f, 0x11, 0x14, 0x5f, 0x7f, 0xf2, 0x61, 0x2e, 0x15, 0xc2, 0xcc,
+	0xdd, 0xa0, 0x3a, 0xf7, 0x17, 0xb2, 0xd9, 0x17, 0xd7, 0x62, 0x11, 0xcd, 0x63, 0x94, 0x04, 0xa1,
+	0xe7, 0xaa, 0xc2, 0x2c, 0x21, 0x8e, 0xbf, 0x0e, 0xb1, 0x53, 0x96, 0x6f, 0x3a, 0x02, 0x22, 0xdb,
+	0x50, 0x8f, 0x45, 0xf4, 0xad, 0x5a, 0xd6, 0x1c, 0xe9, 0x09, 0x61, 0x2b, 0x86, 0x85, 0x91, 0xa1,
+	0x7e, 0xc7, 0xc8, 0xf0, 0x2b, 0x03, 0x6a, 0xfd, 0x93, 0xd1, 0x17, 0xec, 0x76, 0xc1, 0x44, 0x02,
+	0x15, 0xdf, 0x99, 0x33, 0x79, 0xeb, 0xe0, 0x37, 0xa1, 0x50, 0x89, 0x82, 0x99, 0xba, 0x6d, 0x70,
+	0x40, 0x13, 0xab, 0xed, 0x60, 0xc6, 0x6c, 0xa4, 0xe9, 0xff, 0x66, 0x2b, 0x4b, 0xff, 0x9b, 0xa5,
+	0xef, 0x00, 0x08, 0x49, 0x18, 0xa7, 0x37, 0xa0, 0x72, 0xc5, 0x6e, 0x55, 0x88, 0x40, 0xdb, 0x07,
+	0xf1, 0xf4, 0x19, 0xac, 0x89, 0xd2, 0x2b, 0xb1, 0xd9, 0x03, 0x09, 0xaa, 0x6c, 0xdc, 0xa1, 0x72,
+	0xe9, 0x7e, 0x95, 0xe9, 0x11, 0xac, 0xe7, 0xc5, 0xc9, 0xe3, 0x49, 0xa1, 0xe6, 0x84, 0xde, 0x17,
+	0xec, 0x56, 0x9e, 0x4b, 0x5d, 0x11, 0x49, 0x51, 0x37, 0x96, 0xf0, 0x12, 0xff, 0xa4, 0x3f, 0x85,
+	0x27, 0x82, 0xe7, 0xfe, 0x0b, 0x44, 0x3c, 0x21, 0xd6, 0xa1, 0x3a, 0x9c, 0x87, 0xc9, 0xed, 0xf6,
+	0xef, 0x41, 0x55, 0xbc, 0x62, 0x35, 0xa0, 0x72, 0x7c, 0x32, 0x1c, 0x9b, 0xaf, 0x11, 0x80, 0xda,
+	0xd1, 0xf1, 0xfe, 0x17, 0xc3, 0x81, 0x69, 0x6c, 0xff, 0xbb, 0x01, 0xcd, 0xb4, 0xa1, 0xe6, 0x94,
+	0x7d, 0x7b, 0xd8, 0x3f, 0x1d, 0x0a, 0xae, 0xc1, 0xf0, 0x68, 0x78, 0x3a, 0x34, 0x0d, 0xbe, 0x96,
+	0xaf, 0x30, 0x4b, 0x1c, 0x7b, 0x36, 0xc6, 0xef, 0x32, 0x31, 0xa1, 0x3d, 0xf9, 0x6a, 0xbc, 0xff,
+	0xd2, 0x1e, 0x3e, 0x3f, 0x1b, 0x4e, 0x4e, 0xcd, 0x8a, 0x86, 0xd9, 0x1f, 0x8e, 0x5e, 0x0c, 0xcd,
+	0x2a, 0x21, 0xd0, 0xdd, 0xff, 0xac, 0x3f, 0x1e, 0x0f, 0x8f, 0x5e, 0x8e, 0xc6, 0x2f, 0x46, 0xa7,
+	0x43, 0xb3, 0xc6, 0x71, 0x83, 0x91, 0x3d, 0xdc, 0x3f, 0x7d, 0xf9, 0x6c, 0x38, 0x99, 0xf4, 0x0f,
+	0x87, 0x66, 0x9d, 0xac, 0x42, 0xe7, 0xf9, 0xd9, 0xf1, 0xe9, 0x30, 0x15, 0xd6, 0x20, 0x4d, 0xa8,
+	0x22, 0xca, 0x6c, 0x72, 0xb9, 0x82, 0xda, 0xdf, 0xdf, 0x1f, 0x9e, 0x9c, 0x9a, 0x40, 0x9e, 0xc0,
+	0x2a, 0xee, 0x74, 0x30, 0x1a, 0x1f, 0x0e, 0xed, 0x13, 0x7b, 0x34, 0x3e, 0x9d, 0x98, 0x2d, 0xb2,
+	0x02, 0x2d, 0x44, 0x0f, 0x46, 0x87, 0x5c, 0x48, 0x7b, 0xfb, 0x2d, 0x68, 0x69, 0x3d, 0x02, 0x57,
+	0xff, 0xe4, 0xec, 0xe9, 0xd1, 0x68, 0xdf, 0x7c, 0x8d, 0xb4, 0xa0, 0x7e, 0x62, 0x8f, 0x5e, 0x70,
+	0x6b, 0x8d, 0x6d, 0x0f, 0x9a, 0xe9, 0x90, 0xca, 0x95, 0x39, 0xb6, 0x07, 0x43, 0xfb, 0xa5, 0x70,
+	0xc6, 0xc0, 0x7c, 0x2d, 0x43, 0x09, 0x9f, 0x0c, 0x4c, 0x83, 0x2b, 0x25, 0x50, 0xd2, 0x99, 0x25,
+	0x6e, 0x98, 0xc0, 0x08, 0x17, 0x0d, 0x07, 0xc2, 0x49, 0x02, 0xc7, 0xf5, 0x1a, 0x0e, 0xcc, 0xca,
+	0xf6, 0xfb, 0xb0, 0x52, 0x18, 0xa9, 0x48, 0x07, 0x9a, 0x93, 0xb3, 0xa7, 0x93, 0x7d, 0x7b, 0xf4,
+	0x94, 0xbb, 0x7e, 0x05, 0x5a, 0x67, 0xe3, 0x0c, 0x61, 0x6c, 0xef, 0x01, 0x64, 0x89, 0xc5, 0xb9,
+	0xed, 0x61, 0x7f, 0xf0, 0xf2, 0x78, 0x7c, 0xf4, 0x95, 0x08, 0xd4, 0xa9, 0xdd, 0x1f, 0x0c, 0x6d,
+	0xd3, 0xe0, 0x3e, 0xeb, 0x0f, 0x9e, 0x8d, 0xc6, 0x66, 0x69, 0xef, 0x37, 0x0d, 0x68, 0x63, 0xa1,
+	0xe3, 0x3f, 0x08, 0x66, 0x2c, 0x22, 0x07, 0x50, 0x13, 0x99, 0x48, 0x56, 0xf1, 0x0e, 0xd0, 0x67,
+	0x87, 0x1e, 0xd1, 0x51, 0x22, 0x45, 0xe9, 0x93, 0xbf, 0xf8, 0xb7, 0xff, 0xfc, 0xab, 0xd2, 0x0a,
+	0x85, 0xdd, 0x9b, 0xf7, 0x77, 0x45, 0x81, 0xff, 0xd8, 0xd8, 0x26, 0x7f, 0x0e, 0xb5, 0x01, 0x3e,
+	0x4f, 0x11, 0x2b, 0xed, 0x1f, 0x0a, 0xe9, 0xd8, 0xc3, 0xce, 0x02, 0x13, 0x90, 0xbe, 0x8f, 0x52,
+	0xde, 0xde, 0xfe, 0x03, 0x2e, 0x45, 0x5d, 0x06, 0xbb, 0xdf, 0xa7, 0x85, 0xfd, 0x95, 0x14, 0xbd,
+	0xfb, 0xbd, 0x6c, 0xa2, 0x5e, 0x11, 0x17, 0x2a, 0x47, 0x81, 0x7b, 0xb5, 0x9c, 0xfc, 0x0f, 0x51,
+	0xfe, 0x7b, 0x74, 0x67, 0x69, 0xf9, 0xbb, 0xfc, 0x5f, 0x2b, 0xb9, 0x84, 0xda, 0x99, 0x3f, 0x5b,
+	0x7a, 0x9b, 0x8f, 0x70, 0x9b, 0x3d, 0xfa, 0xde, 0xf2, 0xdb, 0x5c, 0x0b, 0xf1, 0xe7, 0xd0, 0x38,
+	0x64, 0x09, 0xca, 0x7f, 0x6c, 0x2b, 0xa4, 0x28, 0x8f, 0x91, 0xdf, 0xc2, 0x63, 0x9f, 0x40, 0xfb,
+	0x90, 0x25, 0xfd, 0xd9, 0x4c, 0x5e, 0x6d, 0x99, 0xe2, 0xbd, 0x4e, 0x2a, 0x98, 0x97, 0x3f, 0x4a,
+	0x50, 0x78, 0x9b, 0x68, 0x41, 0x25, 0x5f, 0x42, 0x5b, 0xaa, 0x21, 0x9e, 0x80, 0x36, 0xb2, 0x64,
+	0xd0, 0xe7, 0x9a, 0xde, 0xc2, 0xb4, 0x4c, 0xdf, 0x40, 0x69, 0x16, 0x5d, 0xe3, 0xd2, 0xc4, 0xbb,
+	0xc9, 0xae, 0xfa, 0x1d, 0xca, 0x73, 0xe5, 0x04, 0xcc, 0x43, 0x96, 0xe8, 0x4b, 0x72, 0xba, 0xad,
+	0x17, 0x05, 0xa2, 0x8a, 0x3f, 0x42, 0xa1, 0x4f, 0xc8, 0x5d, 0x42, 0xc9, 0x4b, 0x68, 0xa6, 0x53,
+	0x1c, 0xc1, 0xf5, 0xc5, 0xa1, 0xae, 0x97, 0x4d, 0xe9, 0xca, 0x95, 0xf4, 0xad, 0x3b, 0x44, 0xed,
+	0x7e, 0x9f, 0x8e, 0x53, 0xaf, 0x24, 0x8d, 0xab, 0x7c, 0x05, 0x4d, 0xa5, 0x72, 0x4c, 0xde, 0x2c,
+	0x2a, 0x58, 0x0c, 0x5b, 0x27, 0x65, 0x40, 0xd5, 0x77, 0x70, 0xbf, 0x2d, 0xb2, 0xe4, 0x7e, 0x24,
+	0x86, 0x56, 0x1f, 0xdf, 0xf2, 0x84, 0x3d, 0x56, 0x2a, 0xed, 0x81, 0xf4, 0xf8, 0x14, 0xf7, 0xf8,
+	0x19, 0xfd, 0xa3, 0xe5, 0xf6, 0xd8, 0xfd, 0x5e, 0x4e, 0x86, 0xaf, 0x76, 0xc5, 0xb3, 0x21, 0x79,
+	0x06, 0x6d, 0xfd, 0x17, 0x17, 0xf9, 0x81, 0xb8, 0xe7, 0x17, 0x7e, 0x7a, 0xf5, 0xba, 0xe9, 0xa6,
+	0x88, 0xcf, 0xe7, 0x0e, 0x43, 0xd6, 0xf7, 0x8c, 0xbd, 0x7f, 0xa8, 0xa4, 0x73, 0x9c, 0x2a, 0x35,
+	0x4f, 0xa1, 0xc2, 0x7b, 0x51, 0x82, 0x33, 0x9a, 0x36, 0x8c, 0xf6, 0xcc, 0x0c, 0x21, 0x8b, 0xcc,
+	0x0f, 0x50, 0xe6, 0x2a, 0x6d, 0xeb, 0xc9, 0xce, 0xe3, 0x30, 0x82, 0xea, 0x11, 0x73, 0x6e, 0x18,
+	0xe9, 0xe9, 0xcf, 0x02, 0xf7, 0x1f, 0xd0, 0x1f, 0xa2, 0xa0, 0xb5, 0xed, 0xd5, 0xfc, 0xa9, 0xf1,
+	0xa6, 0xaf, 0xc8, 0x09, 0xc0, 0x21, 0x4b, 0xa4, 0x88, 0x07, 0xe5, 0xe9, 0xdd, 0xb1, 0x92, 0x48,
+	0xee, 0x90, 0xf8, 0x14, 0xba, 0xe2, 0xbc, 0x49, 0xde, 0x5c, 0x56, 0xeb, 0x93, 0x29, 0x66, 0xc5,
+	0x3a, 0x0a, 0xea, 0x92, 0x9c, 0x8d, 0xe4, 0x05, 0xac, 0x71, 0x6a, 0xfe, 0x61, 0x2c, 0x27, 0x68,
+	0x63, 0xf1, 0xe1, 0x0c, 0xe5, 0xbd, 0x8e, 0xf2, 0x36, 0xc8, 0x3a, 0x97, 0xe7, 0x0b, 0x7a, 0x26,
+	0x77, 0x0c, 0x2b, 0x99, 0xb5, 0xa2, 0x91, 0x2f, 0x1e, 0xb9, 0xe2, 0x63, 0x0c, 0xed, 0xa1, 0xc4,
+	0x75, 0x42, 0xb8, 0xc4, 0x98, 0xa3, 0x33, 0x79, 0x07, 0xd0, 0x39, 0x64, 0x89, 0xf6, 0xf8, 0xa2,
+	0x49, 0x23, 0xf9, 0xff, 0xe8, 0x28, 0x6b, 0x03, 0x65, 0x99, 0xa4, 0x9b, 0xc9, 0xe2, 0xcf, 0x2f,
+	0x7b, 0x7f, 0x5b, 0x85, 0x16, 0x6f, 0x7a, 0x55, 0x92, 0xf4, 0xa1, 0x25, 0x7c, 0x28, 0x1e, 0x46,
+	0x8a, 0x3a, 0x16, 0xc7, 0x48, 0xba, 0x8a, 0x72, 0x5b, 0xa4, 0xc9, 0xe5, 0x8a, 0x17, 0xcd, 0x03,
+	0xe8, 0x3c, 0x9d, 0x39, 0xee, 0xd5, 0xcc, 0x13, 0xcf, 0x2b, 0x24, 0x9d, 0x12, 0xf5, 0xcc, 0xd8,
+	0xc4, 0x85, 0x3d, 0x6a, 0xa5, 0x0b, 0x31, 0x88, 0xbb, 0xe7, 0x6a, 0x29, 0xf9, 0x08, 0x55, 0x49,
+	0x3b, 0x72, 0x4d, 0x15, 0x6c, 0xe1, 0x15, 0x81, 0x9a, 0x28, 0x09, 0x48, 0x03, 0x1d, 0x1f, 0x4c,
+	0x19, 0x79, 0x0a, 0x2d, 0x39, 0xf0, 0xe1, 0xfe, 0xe2, 0x1a, 0xcd, 0x4d, 0x80, 0xba, 0x26, 0x32,
+	0x11, 0x68, 0x66, 0x02, 0xcf, 0xf4, 0x3f, 0x81, 0xee, 0xc0, 0x8b, 0x5d, 0x4d, 0xcc, 0x9d, 0x66,
+	0x48, 0xbf, 0x6e, 0x77, 0xf3, 0x66, 0x90, 0x13, 0x58, 0x3d, 0x64, 0xc9, 0x89, 0x9a, 0x1c, 0x17,
+	0xbc, 0xb9, 0xa6, 0x84, 0x69, 0xb3, 0x64, 0xbe, 0xc6, 0x0a, 0x61, 0xe9, 0xec, 0x49, 0x9e, 0xc3,
+	0x2a, 0x2f, 0xaa, 0xf9, 0x01, 0x1a, 0x6b, 0xd3, 0x5d, 0xd3, 0xba, 0xae, 0x63, 0xee, 0x34, 0xab,
+	0xe7, 0x47, 0x6e, 0xe3, 0x33, 0x78, 0x22, 0x9f, 0x8d, 0x72, 0x22, 0x72, 0x8a, 0xae, 0x2e, 0xec,
+	0x90, 0x3f, 0x39, 0x4a, 0xde, 0x7b, 0x06, 0x79, 0x0e, 0x4f, 0x0e, 0x59, 0x62, 0x3b, 0xbc, 0xec,
+	0xce, 0xbd, 0x44, 0xcd, 0x58, 0x39, 0x71, 0xa6, 0x3e, 0x7d, 0x2d, 0x1a, 0x2d, 0x32, 0x33, 0x9d,
+	0xc9, 0xf6, 0xfe, 0xdb, 0x80, 0x8e, 0x68, 0xb2, 0x54, 0x82, 0x7e, 0x05, 0x6d, 0xbd, 0x75, 0x17,
+	0x75, 0xf2, 0x8e, 0xd9, 0xa0, 0x67, 0x2d, 0x12, 0x64, 0xce, 0xca, 0x98, 0xd1, 0x16, 0xdf, 0xd1,
+	0x09, 0x3d, 0x3e, 0x61, 0x70, 0x77, 0x7c, 0x8a, 0x67, 0xaa, 0x3f, 0x9b, 0x09, 0xfe, 0x9c, 0xde,
+	0xda, 0x1c, 0x81, 0x5a, 0xaf, 0xa1, 0x8c, 0x0e, 0xd1, 0x65, 0x90, 0x31, 0xbf, 0xb2, 0x6f, 0x82,
+	0x2b, 0xa5, 0xdb, 0x0f, 0xb3, 0x45, 0x0f, 0xd4, 0x48, 0x0b, 0x45, 0x91, 0x6d, 0x53, 0x13, 0x85,
+	0x49, 0x74, 0x5e, 0xc3, 0x01, 0xea, 0x0f, 0xff, 0x77, 0x00, 0x6a, 0x0d, 0x10, 0x4b, 0xe4, 0x28,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// OrderHandlerClient is the client API for OrderHandler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OrderHandlerClient interface {
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	Delete(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error)
+	Lock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error)
+	Unlock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetOrder(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Order, error)
+	GetAllOrders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrderList, error)
+	RequestQuote(ctx context.Context, in *CreateQuoteRequest, opts ...grpc.CallOption) (*QuoteRequest, error)
+	GetQuoteRequests(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*QuoteRequestList, error)
+	SendQuote(ctx context.Context, in *SendQuoteRequest, opts ...grpc.CallOption) (*Quote, error)
+	GetQuotes(ctx context.Context, in *QuoteRequestSpecificRequest, opts ...grpc.CallOption) (*QuoteList, error)
+	AcceptQuote(ctx context.Context, in *QuoteSpecificRequest, opts ...grpc.CallOption) (*Order, error)
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (OrderHandler_StreamEventsClient, error)
+}
+
+type orderHandlerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOrderHandlerClient(cc *grpc.ClientConn) OrderHandlerClient {
+	return &orderHandlerClient{cc}
+}
+
+func (c *orderHandlerClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) Delete(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) Lock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Lock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) Unlock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Unlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) GetOrder(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/GetOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) GetAllOrders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrderList, error) {
+	out := new(OrderList)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/GetAllOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) RequestQuote(ctx context.Context, in *CreateQuoteRequest, opts ...grpc.CallOption) (*QuoteRequest, error) {
+	out := new(QuoteRequest)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/RequestQuote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) GetQuoteRequests(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*QuoteRequestList, error) {
+	out := new(QuoteRequestList)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/GetQuoteRequests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) SendQuote(ctx context.Context, in *SendQuoteRequest, opts ...grpc.CallOption) (*Quote, error) {
+	out := new(Quote)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/SendQuote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) GetQuotes(ctx context.Context, in *QuoteRequestSpecificRequest, opts ...grpc.CallOption) (*QuoteList, error) {
+	out := new(QuoteList)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/GetQuotes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) AcceptQuote(ctx context.Context, in *QuoteSpecificRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/AcceptQuote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (OrderHandler_StreamEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_OrderHandler_serviceDesc.Streams[0], "/pb.OrderHandler/StreamEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &orderHandlerStreamEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type OrderHandler_StreamEventsClient interface {
+	Recv() (*OrderEvent, error)
+	grpc.ClientStream
+}
+
+type orderHandlerStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *orderHandlerStreamEventsClient) Recv() (*OrderEvent, error) {
+	m := new(OrderEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// OrderHandlerServer is the server API for OrderHandler service.
+type OrderHandlerServer interface {
+	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	Delete(context.Context, *OrderSpecificRequest) (*Empty, error)
+	Lock(context.Context, *OrderSpecificRequest) (*Empty, error)
+	Unlock(context.Context, *OrderSpecificRequest) (*Empty, error)
+	GetOrder(context.Context, *OrderSpecificRequest) (*Order, error)
+	GetAllOrders(context.Context, *Empty) (*OrderList, error)
+	RequestQuote(context.Context, *CreateQuoteRequest) (*QuoteRequest, error)
+	GetQuoteRequests(context.Context, *Empty) (*QuoteRequestList, error)
+	SendQuote(context.Context, *SendQuoteRequest) (*Quote, error)
+	GetQuotes(context.Context, *QuoteRequestSpecificRequest) (*QuoteList, error)
+	AcceptQuote(context.Context, *QuoteSpecificRequest) (*Order, error)
+	StreamEvents(*StreamEventsRequest, OrderHandler_StreamEventsServer) error
+}
+
+// UnimplementedOrderHandlerServer can be embedded to have forward compatible implementations.
+type UnimplementedOrderHandlerServer struct {
+}
+
+func (*UnimplementedOrderHandlerServer) Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (*UnimplementedOrderHandlerServer) Delete(ctx context.Context, req *OrderSpecificRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (*UnimplementedOrderHandlerServer) Lock(ctx context.Context, req *OrderSpecificRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lock not implemented")
+}
+func (*UnimplementedOrderHandlerServer) Unlock(ctx context.Context, req *OrderSpecificRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unlock not implemented")
+}
+func (*UnimplementedOrderHandlerServer) GetOrder(ctx context.Context, req *OrderSpecificRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (*UnimplementedOrderHandlerServer) GetAllOrders(ctx context.Context, req *Empty) (*OrderList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllOrders not implemented")
+}
+func (*UnimplementedOrderHandlerServer) RequestQuote(ctx context.Context, req *CreateQuoteRequest) (*QuoteRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestQuote not implemented")
+}
+func (*UnimplementedOrderHandlerServer) GetQuoteRequests(ctx context.Context, req *Empty) (*QuoteRequestList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuoteRequests not implemented")
+}
+func (*UnimplementedOrderHandlerServer) SendQuote(ctx context.Context, req *SendQuoteRequest) (*Quote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendQuote not implemented")
+}
+func (*UnimplementedOrderHandlerServer) GetQuotes(ctx context.Context, req *QuoteRequestSpecificRequest) (*QuoteList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuotes not implemented")
+}
+func (*UnimplementedOrderHandlerServer) AcceptQuote(ctx context.Context, req *QuoteSpecificRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptQuote not implemented")
+}
+func (*UnimplementedOrderHandlerServer) StreamEvents(req *StreamEventsRequest, srv OrderHandler_StreamEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+
+func RegisterOrderHandlerServer(s *grpc.Server, srv OrderHandlerServer) {
+	s.RegisterService(&_OrderHandler_serviceDesc, srv)
+}
+
+func _OrderHandler_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).Delete(ctx, req.(*OrderSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).Lock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/Lock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).Lock(ctx, req.(*OrderSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).Unlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/Unlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).Unlock(ctx, req.(*OrderSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/GetOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).GetOrder(ctx, req.(*OrderSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_GetAllOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).GetAllOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/GetAllOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).GetAllOrders(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_RequestQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).RequestQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/RequestQuote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).RequestQuote(ctx, req.(*CreateQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_GetQuoteRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).GetQuoteRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/GetQuoteRequests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).GetQuoteRequests(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_SendQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).SendQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/SendQuote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).SendQuote(ctx, req.(*SendQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_GetQuotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteRequestSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).GetQuotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/GetQuotes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).GetQuotes(ctx, req.(*QuoteRequestSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_AcceptQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).AcceptQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/AcceptQuote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).AcceptQuote(ctx, req.(*QuoteSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrderHandlerServer).StreamEvents(m, &orderHandlerStreamEventsServer{stream})
+}
+
+type OrderHandler_StreamEventsServer interface {
+	Send(*OrderEvent) error
+	grpc.ServerStream
+}
+
+type orderHandlerStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *orderHandlerStreamEventsServer) Send(m *OrderEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _OrderHandler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.OrderHandler",
+	HandlerType: (*OrderHandlerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _OrderHandler_Create_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _OrderHandler_Delete_Handler,
+		},
+		{
+			MethodName: "Lock",
+			Handler:    _OrderHandler_Lock_Handler,
+		},
+		{
+			MethodName: "Unlock",
+			Handler:    _OrderHandler_Unlock_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _OrderHandler_GetOrder_Handler,
+		},
+		{
+			MethodName: "GetAllOrders",
+			Handler:    _OrderHandler_GetAllOrders_Handler,
+		},
+		{
+			MethodName: "RequestQuote",
+			Handler:    _OrderHandler_RequestQuote_Handler,
+		},
+		{
+			MethodName: "GetQuoteRequests",
+			Handler:    _OrderHandler_GetQuoteRequests_Handler,
+		},
+		{
+			MethodName: "SendQuote",
+			Handler:    _OrderHandler_SendQuote_Handler,
+		},
+		{
+			MethodName: "GetQuotes",
+			Handler:    _OrderHandler_GetQuotes_Handler,
+		},
+		{
+			MethodName: "AcceptQuote",
+			Handler:    _OrderHandler_AcceptQuote_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _OrderHandler_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "sprawl.proto",
+}
+
+// ChannelHandlerClient is the client API for ChannelHandler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ChannelHandlerClient interface {
+	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
+	Leave(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetChannel(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Channel, error)
+	GetAllChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelList, error)
+	ListNetworkChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NetworkChannelList, error)
+	GetChannelStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelStatsList, error)
+	GetSyncStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SyncStatusList, error)
+}
+
+type channelHandlerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChannelHandlerClient(cc *grpc.ClientConn) ChannelHandlerClient {
+	return &channelHandlerClient{cc}
+}
+
+func (c *channelHandlerClient) Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
+	out := new(JoinResponse)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/Join", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelHandlerClient) Leave(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/Leave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelHandlerClient) GetChannel(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Channel, error) {
+	out := new(Channel)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetChannel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelHandlerClient) GetAllChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelList, error) {
+	out := new(ChannelList)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetAllChannels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelHandlerClient) ListNetworkChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NetworkChannelList, error) {
+	out := new(NetworkChannelList)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/ListNetworkChannels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelHandlerClient) GetChannelStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelStatsList, error) {
+	out := new(ChannelStatsList)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetChannelStats", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *orderHandlerClient) GetAllOrders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrderList, error) {
-	out := new(OrderList)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/GetAllOrders", in, out, opts...)
-	if err != nil {
+func (c *channelHandlerClient) GetSyncStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SyncStatusList, error) {
+	out := new(SyncStatusList)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetSyncStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChannelHandlerServer is the server API for ChannelHandler service.
+type ChannelHandlerServer interface {
+	Join(context.Context, *JoinRequest) (*JoinResponse, error)
+	Leave(context.Context, *ChannelSpecificRequest) (*Empty, error)
+	GetChannel(context.Context, *ChannelSpecificRequest) (*Channel, error)
+	GetAllChannels(context.Context, *Empty) (*ChannelList, error)
+	ListNetworkChannels(context.Context, *Empty) (*NetworkChannelList, error)
+	GetChannelStats(context.Context, *Empty) (*ChannelStatsList, error)
+	GetSyncStatus(context.Context, *Empty) (*SyncStatusList, error)
+}
+
+// UnimplementedChannelHandlerServer can be embedded to have forward compatible implementations.
+type UnimplementedChannelHandlerServer struct {
+}
+
+func (*UnimplementedChannelHandlerServer) Join(ctx context.Context, req *JoinRequest) (*JoinResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
+}
+func (*UnimplementedChannelHandlerServer) Leave(ctx context.Context, req *ChannelSpecificRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
+}
+func (*UnimplementedChannelHandlerServer) GetChannel(ctx context.Context, req *ChannelSpecificRequest) (*Channel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChannel not implemented")
+}
+func (*UnimplementedChannelHandlerServer) GetAllChannels(ctx context.Context, req *Empty) (*ChannelList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllChannels not implemented")
+}
+func (*UnimplementedChannelHandlerServer) ListNetworkChannels(ctx context.Context, req *Empty) (*NetworkChannelList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNetworkChannels not implemented")
+}
+func (*UnimplementedChannelHandlerServer) GetChannelStats(ctx context.Context, req *Empty) (*ChannelStatsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChannelStats not implemented")
+}
+func (*UnimplementedChannelHandlerServer) GetSyncStatus(ctx context.Context, req *Empty) (*SyncStatusList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSyncStatus not implemented")
+}
+
+func RegisterChannelHandlerServer(s *grpc.Server, srv ChannelHandlerServer) {
+	s.RegisterService(&_ChannelHandler_serviceDesc, srv)
+}
+
+func _ChannelHandler_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).Join(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/Join",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).Join(ctx, req.(*JoinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelHandler_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelSpecificRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// OrderHandlerServer is the server API for OrderHandler service.
-type OrderHandlerServer interface {
-	Create(context.Context, *CreateRequest) (*CreateResponse, error)
-	Delete(context.Context, *OrderSpecificRequest) (*Empty, error)
-	Lock(context.Context, *OrderSpecificRequest) (*Empty, error)
-	Unlock(context.Context, *OrderSpecificRequest) (*Empty, error)
-	GetOrder(context.Context, *OrderSpecificRequest) (*Order, error)
-	GetAllOrders(context.Context, *Empty) (*OrderList, error)
-}
-
-// UnimplementedOrderHandlerServer can be embedded to have forward compatible implementations.
-type UnimplementedOrderHandlerServer struct {
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).Leave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/Leave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).Leave(ctx, req.(*ChannelSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (*UnimplementedOrderHandlerServer) Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
-}
-func (*UnimplementedOrderHandlerServer) Delete(ctx context.Context, req *OrderSpecificRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
-}
-func (*UnimplementedOrderHandlerServer) Lock(ctx context.Context, req *OrderSpecificRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Lock not implemented")
-}
-func (*UnimplementedOrderHandlerServer) Unlock(ctx context.Context, req *OrderSpecificRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Unlock not implemented")
-}
-func (*UnimplementedOrderHandlerServer) GetOrder(ctx context.Context, req *OrderSpecificRequest) (*Order, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
-}
-func (*UnimplementedOrderHandlerServer) GetAllOrders(ctx context.Context, req *Empty) (*OrderList, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllOrders not implemented")
+func _ChannelHandler_GetChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).GetChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/GetChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).GetChannel(ctx, req.(*ChannelSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterOrderHandlerServer(s *grpc.Server, srv OrderHandlerServer) {
-	s.RegisterService(&_OrderHandler_serviceDesc, srv)
+func _ChannelHandler_GetAllChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).GetAllChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/GetAllChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).GetAllChannels(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRequest)
+func _ChannelHandler_ListNetworkChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).Create(ctx, in)
+		return srv.(ChannelHandlerServer).ListNetworkChannels(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/Create",
+		FullMethod: "/pb.ChannelHandler/ListNetworkChannels",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).Create(ctx, req.(*CreateRequest))
+		return srv.(ChannelHandlerServer).ListNetworkChannels(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrderSpecificRequest)
+func _ChannelHandler_GetChannelStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).Delete(ctx, in)
+		return srv.(ChannelHandlerServer).GetChannelStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/Delete",
+		FullMethod: "/pb.ChannelHandler/GetChannelStats",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).Delete(ctx, req.(*OrderSpecificRequest))
+		return srv.(ChannelHandlerServer).GetChannelStats(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrderSpecificRequest)
+func _ChannelHandler_GetSyncStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).Lock(ctx, in)
+		return srv.(ChannelHandlerServer).GetSyncStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/Lock",
+		FullMethod: "/pb.ChannelHandler/GetSyncStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).Lock(ctx, req.(*OrderSpecificRequest))
+		return srv.(ChannelHandlerServer).GetSyncStatus(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrderSpecificRequest)
+var _ChannelHandler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.ChannelHandler",
+	HandlerType: (*ChannelHandlerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Join",
+			Handler:    _ChannelHandler_Join_Handler,
+		},
+		{
+			MethodName: "Leave",
+			Handler:    _ChannelHandler_Leave_Handler,
+		},
+		{
+			MethodName: "GetChannel",
+			Handler:    _ChannelHandler_GetChannel_Handler,
+		},
+		{
+			MethodName: "GetAllChannels",
+			Handler:    _ChannelHandler_GetAllChannels_Handler,
+		},
+		{
+			MethodName: "ListNetworkChannels",
+			Handler:    _ChannelHandler_ListNetworkChannels_Handler,
+		},
+		{
+			MethodName: "GetChannelStats",
+			Handler:    _ChannelHandler_GetChannelStats_Handler,
+		},
+		{
+			MethodName: "GetSyncStatus",
+			Handler:    _ChannelHandler_GetSyncStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sprawl.proto",
+}
+
+// NodeHandlerClient is the client API for NodeHandler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NodeHandlerClient interface {
+	GetAllPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerListResponse, error)
+	BlacklistPeer(ctx context.Context, in *Peer, opts ...grpc.CallOption) (*Empty, error)
+	GetNodeInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NodeInfo, error)
+	ConnectPeer(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*Empty, error)
+	DisconnectPeer(ctx context.Context, in *Peer, opts ...grpc.CallOption) (*Empty, error)
+	GetProtectedPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerAddressList, error)
+	SendDirectMessage(ctx context.Context, in *DirectMessageRequest, opts ...grpc.CallOption) (*Empty, error)
+	ReceiveDirectMessages(ctx context.Context, in *Empty, opts ...grpc.CallOption) (NodeHandler_ReceiveDirectMessagesClient, error)
+	GetRateLimitOffenders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OffenderList, error)
+}
+
+type nodeHandlerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNodeHandlerClient(cc *grpc.ClientConn) NodeHandlerClient {
+	return &nodeHandlerClient{cc}
+}
+
+func (c *nodeHandlerClient) GetAllPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerListResponse, error) {
+	out := new(PeerListResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/GetAllPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeHandlerClient) BlacklistPeer(ctx context.Context, in *Peer, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/BlacklistPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeHandlerClient) GetNodeInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NodeInfo, error) {
+	out := new(NodeInfo)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/GetNodeInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeHandlerClient) ConnectPeer(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/ConnectPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeHandlerClient) DisconnectPeer(ctx context.Context, in *Peer, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/DisconnectPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeHandlerClient) GetProtectedPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerAddressList, error) {
+	out := new(PeerAddressList)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/GetProtectedPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeHandlerClient) SendDirectMessage(ctx context.Context, in *DirectMessageRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/SendDirectMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeHandlerClient) ReceiveDirectMessages(ctx context.Context, in *Empty, opts ...grpc.CallOption) (NodeHandler_ReceiveDirectMessagesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NodeHandler_serviceDesc.Streams[0], "/pb.NodeHandler/ReceiveDirectMessages", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeHandlerReceiveDirectMessagesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NodeHandler_ReceiveDirectMessagesClient interface {
+	Recv() (*DirectMessage, error)
+	grpc.ClientStream
+}
+
+type nodeHandlerReceiveDirectMessagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeHandlerReceiveDirectMessagesClient) Recv() (*DirectMessage, error) {
+	m := new(DirectMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *nodeHandlerClient) GetRateLimitOffenders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OffenderList, error) {
+	out := new(OffenderList)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/GetRateLimitOffenders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NodeHandlerServer is the server API for NodeHandler service.
+type NodeHandlerServer interface {
+	GetAllPeers(context.Context, *Empty) (*PeerListResponse, error)
+	BlacklistPeer(context.Context, *Peer) (*Empty, error)
+	GetNodeInfo(context.Context, *Empty) (*NodeInfo, error)
+	ConnectPeer(context.Context, *ConnectRequest) (*Empty, error)
+	DisconnectPeer(context.Context, *Peer) (*Empty, error)
+	GetProtectedPeers(context.Context, *Empty) (*PeerAddressList, error)
+	SendDirectMessage(context.Context, *DirectMessageRequest) (*Empty, error)
+	ReceiveDirectMessages(*Empty, NodeHandler_ReceiveDirectMessagesServer) error
+	GetRateLimitOffenders(context.Context, *Empty) (*OffenderList, error)
+}
+
+// UnimplementedNodeHandlerServer can be embedded to have forward compatible implementations.
+type UnimplementedNodeHandlerServer struct {
+}
+
+func (*UnimplementedNodeHandlerServer) GetAllPeers(ctx context.Context, req *Empty) (*PeerListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllPeers not implemented")
+}
+func (*UnimplementedNodeHandlerServer) BlacklistPeer(ctx context.Context, req *Peer) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlacklistPeer not implemented")
+}
+func (*UnimplementedNodeHandlerServer) GetNodeInfo(ctx context.Context, req *Empty) (*NodeInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeInfo not implemented")
+}
+func (*UnimplementedNodeHandlerServer) ConnectPeer(ctx context.Context, req *ConnectRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConnectPeer not implemented")
+}
+func (*UnimplementedNodeHandlerServer) DisconnectPeer(ctx context.Context, req *Peer) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisconnectPeer not implemented")
+}
+func (*UnimplementedNodeHandlerServer) GetProtectedPeers(ctx context.Context, req *Empty) (*PeerAddressList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProtectedPeers not implemented")
+}
+func (*UnimplementedNodeHandlerServer) SendDirectMessage(ctx context.Context, req *DirectMessageRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendDirectMessage not implemented")
+}
+func (*UnimplementedNodeHandlerServer) ReceiveDirectMessages(req *Empty, srv NodeHandler_ReceiveDirectMessagesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReceiveDirectMessages not implemented")
+}
+func (*UnimplementedNodeHandlerServer) GetRateLimitOffenders(ctx context.Context, req *Empty) (*OffenderList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRateLimitOffenders not implemented")
+}
+
+func RegisterNodeHandlerServer(s *grpc.Server, srv NodeHandlerServer) {
+	s.RegisterService(&_NodeHandler_serviceDesc, srv)
+}
+
+func _NodeHandler_GetAllPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).Unlock(ctx, in)
+		return srv.(NodeHandlerServer).GetAllPeers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/Unlock",
+		FullMethod: "/pb.NodeHandler/GetAllPeers",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).Unlock(ctx, req.(*OrderSpecificRequest))
+		return srv.(NodeHandlerServer).GetAllPeers(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrderSpecificRequest)
+func _NodeHandler_BlacklistPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Peer)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).GetOrder(ctx, in)
+		return srv.(NodeHandlerServer).BlacklistPeer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/GetOrder",
+		FullMethod: "/pb.NodeHandler/BlacklistPeer",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).GetOrder(ctx, req.(*OrderSpecificRequest))
+		return srv.(NodeHandlerServer).BlacklistPeer(ctx, req.(*Peer))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_GetAllOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _NodeHandler_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).GetAllOrders(ctx, in)
+		return srv.(NodeHandlerServer).GetNodeInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/GetAllOrders",
+		FullMethod: "/pb.NodeHandler/GetNodeInfo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).GetAllOrders(ctx, req.(*Empty))
+		return srv.(NodeHandlerServer).GetNodeInfo(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _OrderHandler_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.OrderHandler",
-	HandlerType: (*OrderHandlerServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Create",
-			Handler:    _OrderHandler_Create_Handler,
-		},
-		{
-			MethodName: "Delete",
-			Handler:    _OrderHandler_Delete_Handler,
-		},
-		{
-			MethodName: "Lock",
-			Handler:    _OrderHandler_Lock_Handler,
-		},
-		{
-			MethodName: "Unlock",
-			Handler:    _OrderHandler_Unlock_Handler,
-		},
-		{
-			MethodName: "GetOrder",
-			Handler:    _OrderHandler_GetOrder_Handler,
-		},
-		{
-			MethodName: "GetAllOrders",
-			Handler:    _OrderHandler_GetAllOrders_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "sprawl.proto",
-}
-
-// ChannelHandlerClient is the client API for ChannelHandler service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ChannelHandlerClient interface {
-	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
-	Leave(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Empty, error)
-	GetChannel(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Channel, error)
-	GetAllChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelList, error)
-}
-
-type channelHandlerClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewChannelHandlerClient(cc *grpc.ClientConn) ChannelHandlerClient {
-	return &channelHandlerClient{cc}
-}
-
-func (c *channelHandlerClient) Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
-	out := new(JoinResponse)
-	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/Join", in, out, opts...)
-	if err != nil {
+func _NodeHandler_ConnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *channelHandlerClient) Leave(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/Leave", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(NodeHandlerServer).ConnectPeer(ctx, in)
 	}
-	return out, nil
-}
-
-func (c *channelHandlerClient) GetChannel(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Channel, error) {
-	out := new(Channel)
-	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetChannel", in, out, opts...)
-	if err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeHandler/ConnectPeer",
 	}
-	return out, nil
-}
-
-func (c *channelHandlerClient) GetAllChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelList, error) {
-	out := new(ChannelList)
-	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetAllChannels", in, out, opts...)
-	if err != nil {
-		return nil, err
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeHandlerServer).ConnectPeer(ctx, req.(*ConnectRequest))
 	}
-	return out, nil
-}
-
-// ChannelHandlerServer is the server API for ChannelHandler service.
-type ChannelHandlerServer interface {
-	Join(context.Context, *JoinRequest) (*JoinResponse, error)
-	Leave(context.Context, *ChannelSpecificRequest) (*Empty, error)
-	GetChannel(context.Context, *ChannelSpecificRequest) (*Channel, error)
-	GetAllChannels(context.Context, *Empty) (*ChannelList, error)
-}
-
-// UnimplementedChannelHandlerServer can be embedded to have forward compatible implementations.
-type UnimplementedChannelHandlerServer struct {
-}
-
-func (*UnimplementedChannelHandlerServer) Join(ctx context.Context, req *JoinRequest) (*JoinResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
-}
-func (*UnimplementedChannelHandlerServer) Leave(ctx context.Context, req *ChannelSpecificRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
-}
-func (*UnimplementedChannelHandlerServer) GetChannel(ctx context.Context, req *ChannelSpecificRequest) (*Channel, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetChannel not implemented")
-}
-func (*UnimplementedChannelHandlerServer) GetAllChannels(ctx context.Context, req *Empty) (*ChannelList, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllChannels not implemented")
-}
-
-func RegisterChannelHandlerServer(s *grpc.Server, srv ChannelHandlerServer) {
-	s.RegisterService(&_ChannelHandler_serviceDesc, srv)
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ChannelHandler_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(JoinRequest)
+func _NodeHandler_DisconnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Peer)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChannelHandlerServer).Join(ctx, in)
+		return srv.(NodeHandlerServer).DisconnectPeer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ChannelHandler/Join",
+		FullMethod: "/pb.NodeHandler/DisconnectPeer",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChannelHandlerServer).Join(ctx, req.(*JoinRequest))
+		return srv.(NodeHandlerServer).DisconnectPeer(ctx, req.(*Peer))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChannelHandler_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChannelSpecificRequest)
+func _NodeHandler_GetProtectedPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChannelHandlerServer).Leave(ctx, in)
+		return srv.(NodeHandlerServer).GetProtectedPeers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ChannelHandler/Leave",
+		FullMethod: "/pb.NodeHandler/GetProtectedPeers",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChannelHandlerServer).Leave(ctx, req.(*ChannelSpecificRequest))
+		return srv.(NodeHandlerServer).GetProtectedPeers(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChannelHandler_GetChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChannelSpecificRequest)
+func _NodeHandler_SendDirectMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DirectMessageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChannelHandlerServer).GetChannel(ctx, in)
+		return srv.(NodeHandlerServer).SendDirectMessage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ChannelHandler/GetChannel",
+		FullMethod: "/pb.NodeHandler/SendDirectMessage",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChannelHandlerServer).GetChannel(ctx, req.(*ChannelSpecificRequest))
+		return srv.(NodeHandlerServer).SendDirectMessage(ctx, req.(*DirectMessageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChannelHandler_GetAllChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _NodeHandler_ReceiveDirectMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeHandlerServer).ReceiveDirectMessages(m, &nodeHandlerReceiveDirectMessagesServer{stream})
+}
+
+type NodeHandler_ReceiveDirectMessagesServer interface {
+	Send(*DirectMessage) error
+	grpc.ServerStream
+}
+
+type nodeHandlerReceiveDirectMessagesServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeHandlerReceiveDirectMessagesServer) Send(m *DirectMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NodeHandler_GetRateLimitOffenders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChannelHandlerServer).GetAllChannels(ctx, in)
+		return srv.(NodeHandlerServer).GetRateLimitOffenders(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ChannelHandler/GetAllChannels",
+		FullMethod: "/pb.NodeHandler/GetRateLimitOffenders",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChannelHandlerServer).GetAllChannels(ctx, req.(*Empty))
+		return srv.(NodeHandlerServer).GetRateLimitOffenders(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _ChannelHandler_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.ChannelHandler",
-	HandlerType: (*ChannelHandlerServer)(nil),
+var _NodeHandler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.NodeHandler",
+	HandlerType: (*NodeHandlerServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "Join",
-			Handler:    _ChannelHandler_Join_Handler,
+			MethodName: "GetAllPeers",
+			Handler:    _NodeHandler_GetAllPeers_Handler,
 		},
 		{
-			MethodName: "Leave",
-			Handler:    _ChannelHandler_Leave_Handler,
+			MethodName: "BlacklistPeer",
+			Handler:    _NodeHandler_BlacklistPeer_Handler,
 		},
 		{
-			MethodName: "GetChannel",
-			Handler:    _ChannelHandler_GetChannel_Handler,
+			MethodName: "GetNodeInfo",
+			Handler:    _NodeHandler_GetNodeInfo_Handler,
 		},
 		{
-			MethodName: "GetAllChannels",
-			Handler:    _ChannelHandler_GetAllChannels_Handler,
+			MethodName: "ConnectPeer",
+			Handler:    _NodeHandler_ConnectPeer_Handler,
+		},
+		{
+			MethodName: "DisconnectPeer",
+			Handler:    _NodeHandler_DisconnectPeer_Handler,
+		},
+		{
+			MethodName: "GetProtectedPeers",
+			Handler:    _NodeHandler_GetProtectedPeers_Handler,
+		},
+		{
+			MethodName: "SendDirectMessage",
+			Handler:    _NodeHandler_SendDirectMessage_Handler,
+		},
+		{
+			MethodName: "GetRateLimitOffenders",
+			Handler:    _NodeHandler_GetRateLimitOffenders_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReceiveDirectMessages",
+			Handler:       _NodeHandler_ReceiveDirectMessages_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "sprawl.proto",
 }
 
-// NodeHandlerClient is the client API for NodeHandler service.
+// APIKeyHandlerClient is the client API for APIKeyHandler service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type NodeHandlerClient interface {
-	GetAllPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerListResponse, error)
-	BlacklistPeer(ctx context.Context, in *Peer, opts ...grpc.CallOption) (*Empty, error)
+type APIKeyHandlerClient interface {
+	CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error)
+	GetAllAPIKeys(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*APIKeyList, error)
+	RevokeAPIKey(ctx context.Context, in *APIKeySpecificRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
-type nodeHandlerClient struct {
+type aPIKeyHandlerClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewNodeHandlerClient(cc *grpc.ClientConn) NodeHandlerClient {
-	return &nodeHandlerClient{cc}
+func NewAPIKeyHandlerClient(cc *grpc.ClientConn) APIKeyHandlerClient {
+	return &aPIKeyHandlerClient{cc}
 }
 
-func (c *nodeHandlerClient) GetAllPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerListResponse, error) {
-	out := new(PeerListResponse)
-	err := c.cc.Invoke(ctx, "/pb.NodeHandler/GetAllPeers", in, out, opts...)
+func (c *aPIKeyHandlerClient) CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error) {
+	out := new(CreateAPIKeyResponse)
+	err := c.cc.Invoke(ctx, "/pb.APIKeyHandler/CreateAPIKey", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *nodeHandlerClient) BlacklistPeer(ctx context.Context, in *Peer, opts ...grpc.CallOption) (*Empty, error) {
+func (c *aPIKeyHandlerClient) GetAllAPIKeys(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*APIKeyList, error) {
+	out := new(APIKeyList)
+	err := c.cc.Invoke(ctx, "/pb.APIKeyHandler/GetAllAPIKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIKeyHandlerClient) RevokeAPIKey(ctx context.Context, in *APIKeySpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/pb.NodeHandler/BlacklistPeer", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/pb.APIKeyHandler/RevokeAPIKey", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// NodeHandlerServer is the server API for NodeHandler service.
-type NodeHandlerServer interface {
-	GetAllPeers(context.Context, *Empty) (*PeerListResponse, error)
-	BlacklistPeer(context.Context, *Peer) (*Empty, error)
+// APIKeyHandlerServer is the server API for APIKeyHandler service.
+type APIKeyHandlerServer interface {
+	CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+	GetAllAPIKeys(context.Context, *Empty) (*APIKeyList, error)
+	RevokeAPIKey(context.Context, *APIKeySpecificRequest) (*Empty, error)
 }
 
-// UnimplementedNodeHandlerServer can be embedded to have forward compatible implementations.
-type UnimplementedNodeHandlerServer struct {
+// UnimplementedAPIKeyHandlerServer can be embedded to have forward compatible implementations.
+type UnimplementedAPIKeyHandlerServer struct {
 }
 
-func (*UnimplementedNodeHandlerServer) GetAllPeers(ctx context.Context, req *Empty) (*PeerListResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllPeers not implemented")
+func (*UnimplementedAPIKeyHandlerServer) CreateAPIKey(ctx context.Context, req *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIKey not implemented")
 }
-func (*UnimplementedNodeHandlerServer) BlacklistPeer(ctx context.Context, req *Peer) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BlacklistPeer not implemented")
+func (*UnimplementedAPIKeyHandlerServer) GetAllAPIKeys(ctx context.Context, req *Empty) (*APIKeyList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllAPIKeys not implemented")
+}
+func (*UnimplementedAPIKeyHandlerServer) RevokeAPIKey(ctx context.Context, req *APIKeySpecificRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPIKey not implemented")
 }
 
-func RegisterNodeHandlerServer(s *grpc.Server, srv NodeHandlerServer) {
-	s.RegisterService(&_NodeHandler_serviceDesc, srv)
+func RegisterAPIKeyHandlerServer(s *grpc.Server, srv APIKeyHandlerServer) {
+	s.RegisterService(&_APIKeyHandler_serviceDesc, srv)
 }
 
-func _NodeHandler_GetAllPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _APIKeyHandler_CreateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIKeyHandlerServer).CreateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.APIKeyHandler/CreateAPIKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIKeyHandlerServer).CreateAPIKey(ctx, req.(*CreateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIKeyHandler_GetAllAPIKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeHandlerServer).GetAllPeers(ctx, in)
+		return srv.(APIKeyHandlerServer).GetAllAPIKeys(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.NodeHandler/GetAllPeers",
+		FullMethod: "/pb.APIKeyHandler/GetAllAPIKeys",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeHandlerServer).GetAllPeers(ctx, req.(*Empty))
+		return srv.(APIKeyHandlerServer).GetAllAPIKeys(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NodeHandler_BlacklistPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Peer)
+func _APIKeyHandler_RevokeAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIKeySpecificRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeHandlerServer).BlacklistPeer(ctx, in)
+		return srv.(APIKeyHandlerServer).RevokeAPIKey(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.NodeHandler/BlacklistPeer",
+		FullMethod: "/pb.APIKeyHandler/RevokeAPIKey",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeHandlerServer).BlacklistPeer(ctx, req.(*Peer))
+		return srv.(APIKeyHandlerServer).RevokeAPIKey(ctx, req.(*APIKeySpecificRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _NodeHandler_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.NodeHandler",
-	HandlerType: (*NodeHandlerServer)(nil),
+var _APIKeyHandler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.APIKeyHandler",
+	HandlerType: (*APIKeyHandlerServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "GetAllPeers",
-			Handler:    _NodeHandler_GetAllPeers_Handler,
+			MethodName: "CreateAPIKey",
+			Handler:    _APIKeyHandler_CreateAPIKey_Handler,
 		},
 		{
-			MethodName: "BlacklistPeer",
-			Handler:    _NodeHandler_BlacklistPeer_Handler,
+			MethodName: "GetAllAPIKeys",
+			Handler:    _APIKeyHandler_GetAllAPIKeys_Handler,
+		},
+		{
+			MethodName: "RevokeAPIKey",
+			Handler:    _APIKeyHandler_RevokeAPIKey_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
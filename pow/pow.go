@@ -0,0 +1,54 @@
+// Package pow implements hashcash style proof-of-work stamps.
+// A stamp is a number that, hashed together with the stamped data, gives a SHA-256 hash
+// starting with at least as many zero bits as the difficulty requires.
+// Finding one takes about 2^difficulty hashes, while checking it takes one.
+package pow
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/bits"
+)
+
+// MaxDifficulty is the highest difficulty accepted, which already takes billions of hashes to meet
+const MaxDifficulty = 32
+
+// checkInterval is how many stamps Solve tries between checking whether it should give up
+const checkInterval = 1 << 12
+
+func hash(data []byte, stamp uint64) [sha256.Size]byte {
+	input := make([]byte, len(data)+8)
+	copy(input, data)
+	binary.BigEndian.PutUint64(input[len(data):], stamp)
+	return sha256.Sum256(input)
+}
+
+// LeadingZeroBits returns the number of zero bits at the start of the stamped data's hash
+func LeadingZeroBits(data []byte, stamp uint64) uint32 {
+	var zeros uint32
+	for _, b := range hash(data, stamp) {
+		if b != 0 {
+			return zeros + uint32(bits.LeadingZeros8(b))
+		}
+		zeros += 8
+	}
+	return zeros
+}
+
+// Solve finds a stamp for data that meets the difficulty, or returns the context's error if it's done first
+func Solve(ctx context.Context, data []byte, difficulty uint32) (uint64, error) {
+	var stamp uint64
+	for LeadingZeroBits(data, stamp) < difficulty {
+		stamp++
+		if stamp%checkInterval == 0 && ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+	}
+	return stamp, nil
+}
+
+// Verify reports whether the stamp for data meets the difficulty
+func Verify(data []byte, stamp uint64, difficulty uint32) bool {
+	return difficulty == 0 || LeadingZeroBits(data, stamp) >= difficulty
+}
@@ -0,0 +1,31 @@
+package pow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolveAndVerify(t *testing.T) {
+	data := []byte("signed order")
+	for _, difficulty := range []uint32{0, 1, 8, 12} {
+		stamp, err := Solve(context.Background(), data, difficulty)
+		assert.NoError(t, err)
+		assert.True(t, Verify(data, stamp, difficulty))
+		assert.True(t, LeadingZeroBits(data, stamp) >= difficulty)
+	}
+
+	// A stamp is only valid for the data it was computed for
+	stamp, err := Solve(context.Background(), data, 12)
+	assert.NoError(t, err)
+	assert.False(t, Verify([]byte("other order"), stamp, 12) && Verify([]byte("third order"), stamp, 12))
+	assert.True(t, Verify([]byte("anything"), 0, 0))
+}
+
+func TestSolveCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Solve(ctx, []byte("signed order"), MaxDifficulty)
+	assert.Equal(t, context.Canceled, err)
+}
@@ -0,0 +1,182 @@
+// Package reconcile implements bucketed set reconciliation of order books.
+// Every order, or tombstone of a deleted order, is summarized as an Item.
+// Items are grouped into buckets by the hash of their ID, and each bucket has a fingerprint that
+// changes whenever any of its items do. Two peers compare bucket fingerprints first, and then exchange
+// the items only for the buckets they differ on.
+package reconcile
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+)
+
+// BucketCount is the number of buckets items are divided into
+const BucketCount = 256
+
+// FingerprintLength is the length of item and bucket fingerprints in bytes
+const FingerprintLength = sha256.Size
+
+// Item summarizes the state of an order, which changes whenever its nonce does or it gets deleted
+type Item struct {
+	ID      []byte
+	Nonce   uint32
+	Deleted bool
+}
+
+// Bucket returns the bucket an order ID belongs to
+func Bucket(id []byte) uint32 {
+	hash := sha256.Sum256(id)
+	return uint32(hash[0]) % BucketCount
+}
+
+// Fingerprint returns a hash of the item's ID and state
+func (item Item) Fingerprint() [FingerprintLength]byte {
+	data := make([]byte, len(item.ID)+5)
+	copy(data, item.ID)
+	binary.BigEndian.PutUint32(data[len(item.ID):], item.Nonce)
+	if item.Deleted {
+		data[len(data)-1] = 1
+	}
+	return sha256.Sum256(data)
+}
+
+// Newer reports whether the item is a later version of the same order than other.
+// Deletion is final, so tombstones are always newer than live orders.
+func (item Item) Newer(other Item) bool {
+	if item.Deleted != other.Deleted {
+		return item.Deleted
+	}
+	return item.Nonce > other.Nonce
+}
+
+type bucket struct {
+	fingerprint [FingerprintLength]byte
+	items       map[string]Item
+}
+
+// Set is a set of items divided into buckets
+type Set struct {
+	buckets [BucketCount]*bucket
+}
+
+// NewSet builds a Set out of items. If an ID appears more than once, its newest version is kept.
+func NewSet(items []Item) *Set {
+	set := &Set{}
+	for _, item := range items {
+		set.Add(item)
+	}
+	return set
+}
+
+// Add adds an item to the set, replacing an older version of it
+func (set *Set) Add(item Item) {
+	index := Bucket(item.ID)
+	b := set.buckets[index]
+	if b == nil {
+		b = &bucket{items: make(map[string]Item)}
+		set.buckets[index] = b
+	}
+
+	if existing, ok := b.items[string(item.ID)]; ok {
+		if !item.Newer(existing) {
+			return
+		}
+		xor(&b.fingerprint, existing.Fingerprint())
+	}
+	b.items[string(item.ID)] = item
+	xor(&b.fingerprint, item.Fingerprint())
+}
+
+// Get returns the item with the given ID, if the set contains it
+func (set *Set) Get(id []byte) (Item, bool) {
+	b := set.buckets[Bucket(id)]
+	if b == nil {
+		return Item{}, false
+	}
+	item, ok := b.items[string(id)]
+	return item, ok
+}
+
+// Fingerprints returns the fingerprints of all non-empty buckets, keyed by bucket index
+func (set *Set) Fingerprints() map[uint32][]byte {
+	fingerprints := make(map[uint32][]byte)
+	for index, b := range set.buckets {
+		if b != nil && len(b.items) > 0 {
+			fingerprint := b.fingerprint
+			fingerprints[uint32(index)] = fingerprint[:]
+		}
+	}
+	return fingerprints
+}
+
+// DifferingBuckets returns the indexes of the buckets whose fingerprints differ from the remote ones,
+// including the buckets only one of the sets has items in
+func (set *Set) DifferingBuckets(remote map[uint32][]byte) []uint32 {
+	local := set.Fingerprints()
+	differing := make([]uint32, 0)
+	for index := uint32(0); index < BucketCount; index++ {
+		localFingerprint, inLocal := local[index]
+		remoteFingerprint, inRemote := remote[index]
+		if inLocal != inRemote || string(localFingerprint) != string(remoteFingerprint) {
+			differing = append(differing, index)
+		}
+	}
+	return differing
+}
+
+// Items returns the items in the given buckets, sorted by ID
+func (set *Set) Items(buckets []uint32) []Item {
+	items := make([]Item, 0)
+	for _, index := range buckets {
+		if index >= BucketCount || set.buckets[index] == nil {
+			continue
+		}
+		for _, item := range set.buckets[index].items {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return string(items[i].ID) < string(items[j].ID)
+	})
+	return items
+}
+
+// Compare compares the remote items of the given buckets with the local ones.
+// It returns the IDs of the items where the remote version is newer, or missing locally,
+// and the local items that are newer than the remote version, or missing remotely.
+func (set *Set) Compare(buckets []uint32, remote []Item) (wanted [][]byte, newer []Item) {
+	remoteItems := make(map[string]Item)
+	for _, item := range remote {
+		if existing, ok := remoteItems[string(item.ID)]; !ok || item.Newer(existing) {
+			remoteItems[string(item.ID)] = item
+		}
+	}
+
+	wanted = make([][]byte, 0)
+	for id, remoteItem := range remoteItems {
+		localItem, ok := set.Get([]byte(id))
+		if !ok || remoteItem.Newer(localItem) {
+			wanted = append(wanted, []byte(id))
+		}
+	}
+
+	newer = make([]Item, 0)
+	for _, localItem := range set.Items(buckets) {
+		remoteItem, ok := remoteItems[string(localItem.ID)]
+		if !ok || localItem.Newer(remoteItem) {
+			newer = append(newer, localItem)
+		}
+	}
+
+	sort.Slice(wanted, func(i, j int) bool {
+		return string(wanted[i]) < string(wanted[j])
+	})
+	return wanted, newer
+}
+
+func xor(fingerprint *[FingerprintLength]byte, other [FingerprintLength]byte) {
+	for i := range fingerprint {
+		fingerprint[i] ^= other[i]
+	}
+}
@@ -0,0 +1,72 @@
+package reconcile
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testItems(count int) []Item {
+	items := make([]Item, count)
+	for i := range items {
+		items[i] = Item{ID: []byte(fmt.Sprintf("order%d", i))}
+	}
+	return items
+}
+
+func TestFingerprintIsOrderIndependent(t *testing.T) {
+	items := testItems(100)
+	reversed := make([]Item, len(items))
+	for i, item := range items {
+		reversed[len(items)-1-i] = item
+	}
+
+	set := NewSet(items)
+	assert.Equal(t, set.Fingerprints(), NewSet(reversed).Fingerprints())
+	assert.Empty(t, set.DifferingBuckets(NewSet(reversed).Fingerprints()))
+	assert.Len(t, set.DifferingBuckets(NewSet(nil).Fingerprints()), len(set.Fingerprints()))
+}
+
+func TestNewerVersionsReplaceOlder(t *testing.T) {
+	item := Item{ID: []byte("order")}
+	locked := Item{ID: item.ID, Nonce: 1}
+	deleted := Item{ID: item.ID, Deleted: true}
+
+	assert.True(t, locked.Newer(item))
+	assert.False(t, item.Newer(locked))
+	assert.True(t, deleted.Newer(locked))
+	assert.False(t, locked.Newer(deleted))
+
+	set := NewSet([]Item{locked, item})
+	stored, ok := set.Get(item.ID)
+	assert.True(t, ok)
+	assert.Equal(t, locked, stored)
+	assert.Equal(t, NewSet([]Item{locked}).Fingerprints(), set.Fingerprints())
+
+	set.Add(deleted)
+	stored, _ = set.Get(item.ID)
+	assert.True(t, stored.Deleted)
+	assert.NotEqual(t, NewSet([]Item{locked}).Fingerprints(), set.Fingerprints())
+}
+
+func TestReconciliation(t *testing.T) {
+	shared := testItems(200)
+	local := NewSet(shared)
+	remoteItems := append([]Item{}, shared...)
+
+	// The remote has locked one order, deleted another and created a new one
+	remoteItems[10] = Item{ID: shared[10].ID, Nonce: 1}
+	remoteItems[20] = Item{ID: shared[20].ID, Deleted: true}
+	remoteItems = append(remoteItems, Item{ID: []byte("remote")})
+	// While the local node has one order the remote doesn't
+	local.Add(Item{ID: []byte("local")})
+
+	remote := NewSet(remoteItems)
+	buckets := local.DifferingBuckets(remote.Fingerprints())
+	assert.True(t, len(buckets) <= 4)
+
+	wanted, newer := local.Compare(buckets, remote.Items(buckets))
+	assert.ElementsMatch(t, [][]byte{shared[10].ID, shared[20].ID, []byte("remote")}, wanted)
+	assert.Equal(t, []Item{{ID: []byte("local")}}, newer)
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const apiKeyLength = 32
+
+// APIKeyService is a gRPC service for managing the API keys clients authenticate with.
+// Only the SHA-256 hashes of the keys are stored, and the hash doubles as the key's ID.
+type APIKeyService struct {
+	Logger  interfaces.Logger
+	Storage interfaces.Storage
+	// AdminToken tells that an API token is configured, which lets clients in as admins without any admin keys
+	AdminToken bool
+}
+
+// RegisterStorage registers a storage service to store the API keys in
+func (s *APIKeyService) RegisterStorage(storage interfaces.Storage) {
+	s.Storage = storage
+}
+
+func getAPIKeyStorageKey(id string) []byte {
+	return []byte(string(interfaces.APIKeyPrefix) + id)
+}
+
+func hashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// CreateAPIKey generates a new API key with the given role. The key itself is only returned here, it can't be recovered later.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, in *pb.CreateAPIKeyRequest) (*pb.CreateAPIKeyResponse, error) {
+	if _, ok := pb.APIKeyRole_name[int32(in.GetRole())]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Check API key role"), fmt.Sprintf("unknown role %d", in.GetRole())))
+	}
+
+	// The first key locks unauthenticated clients out, so there must be an admin left to manage the keys
+	if in.GetRole() != pb.APIKeyRole_ADMIN && !s.AdminToken {
+		hasAdmin, err := s.hasAdminKey()
+		if !errors.IsEmpty(err) {
+			return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Check admin API keys"), err))
+		}
+		if !hasAdmin {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", errors.E(errors.Op("Check API key role"), "create an admin API key, or configure an API token, before keys with other roles"))
+		}
+	}
+
+	secret := make([]byte, apiKeyLength)
+	_, err := rand.Read(secret)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Generate API key"), err))
+	}
+	key := hex.EncodeToString(secret)
+
+	apiKey := &pb.APIKey{
+		Id:      hashAPIKey(key),
+		Name:    in.GetName(),
+		Role:    in.GetRole(),
+		Created: ptypes.TimestampNow(),
+	}
+	data, err := proto.Marshal(apiKey)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Marshal API key"), err))
+	}
+	err = s.Storage.Put(getAPIKeyStorageKey(apiKey.GetId()), data)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Put API key"), err))
+	}
+
+	s.Logger.Infof("Created %s API key %s", apiKey.GetRole(), apiKey.GetName())
+	return &pb.CreateAPIKeyResponse{ApiKey: apiKey, Key: key}, nil
+}
+
+// GetAllAPIKeys fetches the stored API keys, oldest first
+func (s *APIKeyService) GetAllAPIKeys(ctx context.Context, in *pb.Empty) (*pb.APIKeyList, error) {
+	data, err := s.Storage.GetAllWithPrefix(string(interfaces.APIKeyPrefix))
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Get all API keys"), err))
+	}
+
+	keys := make([]*pb.APIKey, 0, len(data))
+	for _, value := range data {
+		apiKey := &pb.APIKey{}
+		err = proto.Unmarshal([]byte(value), apiKey)
+		if !errors.IsEmpty(err) {
+			return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Unmarshal API key"), err))
+		}
+		keys = append(keys, apiKey)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].GetCreated().GetSeconds() != keys[j].GetCreated().GetSeconds() {
+			return keys[i].GetCreated().GetSeconds() < keys[j].GetCreated().GetSeconds()
+		}
+		return keys[i].GetCreated().GetNanos() < keys[j].GetCreated().GetNanos()
+	})
+	return &pb.APIKeyList{Keys: keys}, nil
+}
+
+// RevokeAPIKey deletes an API key, so it can't be used anymore
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, in *pb.APIKeySpecificRequest) (*pb.Empty, error) {
+	hasKey, err := s.Storage.Has(getAPIKeyStorageKey(in.GetId()))
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Check API key from storage"), err))
+	}
+	if !hasKey {
+		return nil, status.Errorf(codes.NotFound, "%s", errors.E(errors.Op("Revoke API key"), fmt.Sprintf("API key %s not found", in.GetId())))
+	}
+
+	err = s.Storage.Delete(getAPIKeyStorageKey(in.GetId()))
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Delete API key"), err))
+	}
+
+	s.Logger.Infof("Revoked API key %s", in.GetId())
+	return &pb.Empty{}, nil
+}
+
+// Authenticate returns the stored API key matching key, nil if there's none
+func (s *APIKeyService) Authenticate(key string) (*pb.APIKey, error) {
+	storageKey := getAPIKeyStorageKey(hashAPIKey(key))
+	hasKey, err := s.Storage.Has(storageKey)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Check API key from storage"), err)
+	}
+	if !hasKey {
+		return nil, nil
+	}
+
+	data, err := s.Storage.Get(storageKey)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get API key from storage"), err)
+	}
+	apiKey := &pb.APIKey{}
+	err = proto.Unmarshal(data, apiKey)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal API key"), err)
+	}
+	return apiKey, nil
+}
+
+// HasKeys tells if any API keys have been created
+func (s *APIKeyService) HasKeys() (bool, error) {
+	count, err := s.Storage.CountWithPrefix(string(interfaces.APIKeyPrefix))
+	if !errors.IsEmpty(err) {
+		return false, errors.E(errors.Op("Count API keys"), err)
+	}
+	return count > 0, nil
+}
+
+func (s *APIKeyService) hasAdminKey() (bool, error) {
+	data, err := s.Storage.GetAllWithPrefix(string(interfaces.APIKeyPrefix))
+	if !errors.IsEmpty(err) {
+		return false, errors.E(errors.Op("Get all API keys"), err)
+	}
+	for _, value := range data {
+		apiKey := &pb.APIKey{}
+		err = proto.Unmarshal([]byte(value), apiKey)
+		if !errors.IsEmpty(err) {
+			return false, errors.E(errors.Op("Unmarshal API key"), err)
+		}
+		if apiKey.GetRole() == pb.APIKeyRole_ADMIN {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newAPIKeyTestService() *APIKeyService {
+	return &APIKeyService{Logger: new(util.PlaceholderLogger), Storage: &inmemory.Storage{Db: make(map[string]string)}}
+}
+
+func createTestAPIKey(t *testing.T, apiKeys *APIKeyService, role pb.APIKeyRole) string {
+	resp, err := apiKeys.CreateAPIKey(context.Background(), &pb.CreateAPIKeyRequest{Name: role.String(), Role: role})
+	assert.NoError(t, err)
+	return resp.GetKey()
+}
+
+func withBearerToken(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationHeader, bearerPrefix+token))
+}
+
+func TestAPIKeyManagement(t *testing.T) {
+	apiKeys := newAPIKeyTestService()
+	hasKeys, err := apiKeys.HasKeys()
+	assert.NoError(t, err)
+	assert.False(t, hasKeys)
+
+	// Without an API token, the first key must be an admin's
+	_, err = apiKeys.CreateAPIKey(context.Background(), &pb.CreateAPIKeyRequest{Name: "monitoring", Role: pb.APIKeyRole_READ_ONLY})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	hasKeys, err = apiKeys.HasKeys()
+	assert.NoError(t, err)
+	assert.False(t, hasKeys)
+	createTestAPIKey(t, apiKeys, pb.APIKeyRole_ADMIN)
+	hasKeys, err = apiKeys.HasKeys()
+	assert.NoError(t, err)
+	assert.True(t, hasKeys)
+
+	resp, err := apiKeys.CreateAPIKey(context.Background(), &pb.CreateAPIKeyRequest{Name: "monitoring", Role: pb.APIKeyRole_READ_ONLY})
+	assert.NoError(t, err)
+	assert.Equal(t, hashAPIKey(resp.GetKey()), resp.GetApiKey().GetId())
+	createTestAPIKey(t, apiKeys, pb.APIKeyRole_TRADER)
+
+	_, err = apiKeys.CreateAPIKey(context.Background(), &pb.CreateAPIKeyRequest{Role: pb.APIKeyRole(42)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Only the hashes are stored
+	data, err := apiKeys.Storage.GetAll()
+	assert.NoError(t, err)
+	for _, value := range data {
+		assert.False(t, strings.Contains(value, resp.GetKey()))
+	}
+
+	list, err := apiKeys.GetAllAPIKeys(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, list.GetKeys(), 3)
+	assert.Equal(t, "monitoring", list.GetKeys()[1].GetName())
+
+	apiKey, err := apiKeys.Authenticate(resp.GetKey())
+	assert.NoError(t, err)
+	assert.Equal(t, pb.APIKeyRole_READ_ONLY, apiKey.GetRole())
+	apiKey, err = apiKeys.Authenticate("wrong")
+	assert.NoError(t, err)
+	assert.Nil(t, apiKey)
+
+	_, err = apiKeys.RevokeAPIKey(context.Background(), &pb.APIKeySpecificRequest{Id: resp.GetApiKey().GetId()})
+	assert.NoError(t, err)
+	apiKey, err = apiKeys.Authenticate(resp.GetKey())
+	assert.NoError(t, err)
+	assert.Nil(t, apiKey)
+	_, err = apiKeys.RevokeAPIKey(context.Background(), &pb.APIKeySpecificRequest{Id: resp.GetApiKey().GetId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// The API token is an admin credential of its own, so keys of any role can come first with it
+	withToken := newAPIKeyTestService()
+	withToken.AdminToken = true
+	createTestAPIKey(t, withToken, pb.APIKeyRole_READ_ONLY)
+}
+
+func TestAPIKeyRoles(t *testing.T) {
+	server := &Server{APIKeys: newAPIKeyTestService()}
+
+	// Without any keys the API stays open
+	assert.NoError(t, server.authorize(context.Background(), "/pb.OrderHandler/Create"))
+
+	admin := createTestAPIKey(t, server.APIKeys, pb.APIKeyRole_ADMIN)
+	readOnly := createTestAPIKey(t, server.APIKeys, pb.APIKeyRole_READ_ONLY)
+	trader := createTestAPIKey(t, server.APIKeys, pb.APIKeyRole_TRADER)
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(server.authorize(context.Background(), "/pb.OrderHandler/GetAllOrders")))
+	assert.Equal(t, codes.Unauthenticated, status.Code(server.authorize(withBearerToken("wrong"), "/pb.OrderHandler/GetAllOrders")))
+
+	assert.NoError(t, server.authorize(withBearerToken(readOnly), "/pb.OrderHandler/GetAllOrders"))
+	assert.NoError(t, server.authorize(withBearerToken(readOnly), "/pb.OrderHandler/StreamEvents"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(server.authorize(withBearerToken(readOnly), "/pb.OrderHandler/Create")))
+
+	assert.NoError(t, server.authorize(withBearerToken(trader), "/pb.OrderHandler/GetAllOrders"))
+	assert.NoError(t, server.authorize(withBearerToken(trader), "/pb.OrderHandler/Delete"))
+	assert.NoError(t, server.authorize(withBearerToken(trader), "/pb.ChannelHandler/Join"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(server.authorize(withBearerToken(trader), "/pb.ChannelHandler/Leave")))
+	assert.Equal(t, codes.PermissionDenied, status.Code(server.authorize(withBearerToken(trader), "/pb.NodeHandler/BlacklistPeer")))
+	assert.Equal(t, codes.PermissionDenied, status.Code(server.authorize(withBearerToken(trader), "/pb.APIKeyHandler/CreateAPIKey")))
+
+	assert.NoError(t, server.authorize(withBearerToken(admin), "/pb.NodeHandler/BlacklistPeer"))
+	assert.NoError(t, server.authorize(withBearerToken(admin), "/pb.APIKeyHandler/CreateAPIKey"))
+
+	// The configured API token is an admin key of its own
+	server.APIToken = serverTestToken
+	assert.NoError(t, server.authorize(withBearerToken(serverTestToken), "/pb.ChannelHandler/Leave"))
+	assert.NoError(t, server.authorize(withBearerToken(readOnly), "/pb.ChannelHandler/GetAllChannels"))
+}
@@ -2,28 +2,94 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
 	"github.com/sprawl/sprawl/interfaces"
 	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/pow"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+const privateChannelSuffixLength = 8
+const privateChannelSeparator = "/"
+const difficultySeparator = "#"
+
 // ChannelService implements the ChannelHandlerServer service.proto
 type ChannelService struct {
-	Storage interfaces.Storage
-	P2p     interfaces.P2p
+	Logger   interfaces.Logger
+	Storage  interfaces.Storage
+	P2p      interfaces.P2p
+	activity interfaces.ChannelActivity
 }
 
 func getChannelStorageKey(channelOptBlob []byte) []byte {
 	return []byte(strings.Join([]string{string(interfaces.ChannelPrefix), string(channelOptBlob)}, ""))
 }
 
+// getChannel fetches a joined channel from storage, returning nil if this node hasn't joined it
+func getChannel(storage interfaces.Storage, channelID []byte) (*pb.Channel, error) {
+	hasChannel, err := storage.Has(getChannelStorageKey(channelID))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Check channel from storage"), err)
+	}
+	if !hasChannel {
+		return nil, nil
+	}
+
+	data, err := storage.Get(getChannelStorageKey(channelID))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get channel from storage"), err)
+	}
+	channel := &pb.Channel{}
+	err = proto.Unmarshal(data, channel)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal channel"), err)
+	}
+	return channel, nil
+}
+
+// isPrivate tells if a channel's data is encrypted and restricted to its members
+func isPrivate(channel *pb.Channel) bool {
+	return channel.GetOptions().GetType() == pb.ChannelType_PRIVATE
+}
+
+// isMember tells if a peer is allowed to read and post on a channel
+func isMember(channel *pb.Channel, peerID peer.ID) bool {
+	if !isPrivate(channel) {
+		return true
+	}
+	return contains(channel.GetOptions().GetMembers(), peerID.String())
+}
+
+// withoutKey returns a copy of a channel without its shared key, so the key never leaves the node through the API
+func withoutKey(channel *pb.Channel) *pb.Channel {
+	if channel.GetOptions().GetKey() == nil {
+		return channel
+	}
+	options := *channel.GetOptions()
+	options.Key = nil
+	return &pb.Channel{Id: channel.GetId(), Options: &options}
+}
+
+func contains(list []string, item string) bool {
+	for _, value := range list {
+		if value == item {
+			return true
+		}
+	}
+	return false
+}
+
 // RegisterStorage registers a storage service to store the Channels in
 func (s *ChannelService) RegisterStorage(storage interfaces.Storage) {
 	s.Storage = storage
@@ -34,6 +100,11 @@ func (s *ChannelService) RegisterP2p(p2p interfaces.P2p) {
 	s.P2p = p2p
 }
 
+// RegisterChannelActivity registers a source for the message counters reported in channel statistics
+func (s *ChannelService) RegisterChannelActivity(activity interfaces.ChannelActivity) {
+	s.activity = activity
+}
+
 // Join joins a channel, subscribing to new topic in libp2p
 func (s *ChannelService) Join(ctx context.Context, in *pb.JoinRequest) (*pb.JoinResponse, error) {
 	// Get all channel options, sort
@@ -42,9 +113,27 @@ func (s *ChannelService) Join(ctx context.Context, in *pb.JoinRequest) (*pb.Join
 
 	// Join the channel options together
 	channelOptBlob := []byte(strings.Join(assetPair[:], ","))
+	channelOptions := &pb.ChannelOptions{AssetPair: strings.Join(assetPair, ""), Difficulty: in.GetDifficulty()}
+	if in.GetDifficulty() > pow.MaxDifficulty {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Check difficulty"), fmt.Sprintf("difficulty %d is above the maximum of %d", in.GetDifficulty(), pow.MaxDifficulty)))
+	}
+
+	// The difficulty is a part of the channel's ID, so every node on a channel asks for the same work
+	if in.GetDifficulty() > 0 {
+		channelOptBlob = []byte(fmt.Sprintf("%s%s%d", channelOptBlob, difficultySeparator, in.GetDifficulty()))
+	}
+
+	// Private channels get a unique ID, a member allowlist and a shared key
+	if in.GetType() == pb.ChannelType_PRIVATE {
+		var err error
+		channelOptBlob, channelOptions, err = s.createPrivateChannel(channelOptBlob, channelOptions, in.GetMembers())
+		if !errors.IsEmpty(err) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Create private channel"), err))
+		}
+	}
 
 	// Create a Channel protobuf message to return to the user
-	joinedChannel := &pb.Channel{Id: channelOptBlob, Options: &pb.ChannelOptions{AssetPair: strings.Join(assetPair, "")}}
+	joinedChannel := &pb.Channel{Id: channelOptBlob, Options: channelOptions}
 	marshaledChannel, err := proto.Marshal(joinedChannel)
 	if !errors.IsEmpty(err) {
 		return nil, status.Errorf(codes.AlreadyExists, "%s", errors.E(errors.Op("Join"), err))
@@ -62,11 +151,89 @@ func (s *ChannelService) Join(ctx context.Context, in *pb.JoinRequest) (*pb.Join
 		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Saving channel to database in Join"), err))
 	}
 
+	// Distribute the shared key to all other members
+	if in.GetType() == pb.ChannelType_PRIVATE {
+		s.inviteMembers(joinedChannel, marshaledChannel)
+	}
+
 	return &pb.JoinResponse{
-		JoinedChannel: joinedChannel,
+		JoinedChannel: withoutKey(joinedChannel),
 	}, nil
 }
 
+// createPrivateChannel generates a unique channel ID and a shared key for a private channel
+func (s *ChannelService) createPrivateChannel(channelOptBlob []byte, channelOptions *pb.ChannelOptions, members []string) ([]byte, *pb.ChannelOptions, error) {
+	suffix := make([]byte, privateChannelSuffixLength)
+	_, err := rand.Read(suffix)
+	if !errors.IsEmpty(err) {
+		return nil, nil, errors.E(errors.Op("Generate private channel ID"), err)
+	}
+
+	key, err := identity.GenerateSymmetricKey(rand.Reader)
+	if !errors.IsEmpty(err) {
+		return nil, nil, errors.E(errors.Op("Generate private channel key"), err)
+	}
+
+	// Validate the members and always include this node in the allowlist
+	allowlist := []string{s.P2p.GetHostIDString()}
+	for _, member := range members {
+		if _, err := peer.IDB58Decode(member); !errors.IsEmpty(err) {
+			return nil, nil, errors.E(errors.Op("Decode member peer ID"), err)
+		}
+		if !contains(allowlist, member) {
+			allowlist = append(allowlist, member)
+		}
+	}
+
+	channelID := []byte(strings.Join([]string{string(channelOptBlob), hex.EncodeToString(suffix)}, privateChannelSeparator))
+	channelOptions.Type = pb.ChannelType_PRIVATE
+	channelOptions.Members = allowlist
+	channelOptions.Key = key
+	return channelID, channelOptions, nil
+}
+
+// inviteMembers sends the private channel, including its key, to every other member over a direct stream
+func (s *ChannelService) inviteMembers(channel *pb.Channel, marshaledChannel []byte) {
+	invite := &pb.WireMessage{ChannelID: channel.GetId(), Operation: pb.Operation_CHANNEL_INVITE, Data: marshaledChannel, Version: interfaces.WireVersion}
+	marshaledInvite, err := proto.Marshal(invite)
+	if !errors.IsEmpty(err) {
+		s.logWarn(errors.E(errors.Op("Marshal channel invite"), err))
+		return
+	}
+
+	for _, member := range channel.GetOptions().GetMembers() {
+		if member == s.P2p.GetHostIDString() {
+			continue
+		}
+		err = s.sendInvite(member, marshaledInvite)
+		if !errors.IsEmpty(err) {
+			s.logWarn(errors.E(errors.Op(fmt.Sprintf("Invite %s to channel %s", member, channel.GetId())), err))
+		}
+	}
+}
+
+func (s *ChannelService) sendInvite(member string, marshaledInvite []byte) error {
+	peerID, err := peer.IDB58Decode(member)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Decode member peer ID"), err)
+	}
+	stream, err := s.P2p.OpenStream(peerID)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Open an invite stream"), err)
+	}
+	err = stream.WriteToStream(marshaledInvite)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Write invite to stream"), err)
+	}
+	return nil
+}
+
+func (s *ChannelService) logWarn(err error) {
+	if s.Logger != nil {
+		s.Logger.Warn(err)
+	}
+}
+
 // Leave leaves a channel, removing a subscription from libp2p
 func (s *ChannelService) Leave(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.Empty, error) {
 	channelID := in.GetId()
@@ -96,7 +263,7 @@ func (s *ChannelService) GetChannel(ctx context.Context, in *pb.ChannelSpecificR
 		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Unmarshal channel data in GetChannel"), err))
 	}
 
-	return channel, nil
+	return withoutKey(channel), nil
 }
 
 // GetAllChannels fetches all channels from the database
@@ -111,10 +278,107 @@ func (s *ChannelService) GetAllChannels(ctx context.Context, in *pb.Empty) (*pb.
 	for _, value := range data {
 		channel := &pb.Channel{}
 		proto.Unmarshal([]byte(value), channel)
-		channels = append(channels, channel)
+		channels = append(channels, withoutKey(channel))
 		i++
 	}
 
 	ChannelList := &pb.ChannelList{Channels: channels}
 	return ChannelList, nil
 }
+
+// ListNetworkChannels lists the public channels other nodes on the network have joined, with approximate peer counts
+func (s *ChannelService) ListNetworkChannels(ctx context.Context, in *pb.Empty) (*pb.NetworkChannelList, error) {
+	if s.P2p == nil {
+		return nil, status.Errorf(codes.Unavailable, "%s", errors.E(errors.Op("List network channels"), "P2p service not registered with ChannelService"))
+	}
+	return &pb.NetworkChannelList{Channels: s.P2p.GetNetworkChannels()}, nil
+}
+
+// GetChannelStats reports the mesh peers, order counts and message activity of every joined channel
+func (s *ChannelService) GetChannelStats(ctx context.Context, in *pb.Empty) (*pb.ChannelStatsList, error) {
+	data, err := s.Storage.GetAllWithPrefix(string(interfaces.ChannelPrefix))
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Get all channels in GetChannelStats"), err))
+	}
+
+	channelStats := make([]*pb.ChannelStats, 0, len(data))
+	for _, value := range data {
+		channel := &pb.Channel{}
+		proto.Unmarshal([]byte(value), channel)
+		stats, err := s.getChannelStats(channel.GetId())
+		if !errors.IsEmpty(err) {
+			return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Get channel stats"), err))
+		}
+		channelStats = append(channelStats, stats)
+	}
+	sort.Slice(channelStats, func(i, j int) bool {
+		return string(channelStats[i].GetId()) < string(channelStats[j].GetId())
+	})
+
+	return &pb.ChannelStatsList{Channels: channelStats}, nil
+}
+
+// GetSyncStatus reports the progress of syncing every joined channel with its peers, and what the syncs repaired
+func (s *ChannelService) GetSyncStatus(ctx context.Context, in *pb.Empty) (*pb.SyncStatusList, error) {
+	data, err := s.Storage.GetAllWithPrefix(string(interfaces.ChannelPrefix))
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Get all channels in GetSyncStatus"), err))
+	}
+
+	syncStatus := make([]*pb.SyncStatus, 0, len(data))
+	for _, value := range data {
+		channel := &pb.Channel{}
+		proto.Unmarshal([]byte(value), channel)
+		syncStatus = append(syncStatus, s.getSyncStatus(channel.GetId()))
+	}
+	sort.Slice(syncStatus, func(i, j int) bool {
+		return string(syncStatus[i].GetChannelID()) < string(syncStatus[j].GetChannelID())
+	})
+
+	return &pb.SyncStatusList{Channels: syncStatus}, nil
+}
+
+func (s *ChannelService) getSyncStatus(channelID []byte) *pb.SyncStatus {
+	syncStatus := &pb.SyncStatus{ChannelID: channelID}
+	if s.P2p != nil {
+		syncStatus = s.P2p.GetSyncStatus(channelID)
+	}
+	if s.activity != nil {
+		results := s.activity.GetSyncResults(channelID)
+		syncStatus.OrdersReceived = results.GetOrdersReceived()
+		syncStatus.TombstonesReceived = results.GetTombstonesReceived()
+		syncStatus.ItemsSent = results.GetItemsSent()
+		syncStatus.LastInSync = results.GetLastInSync()
+		syncStatus.LastRepair = results.GetLastRepair()
+	}
+	return syncStatus
+}
+
+func (s *ChannelService) getChannelStats(channelID []byte) (*pb.ChannelStats, error) {
+	stats := &pb.ChannelStats{Id: channelID}
+	if s.activity != nil {
+		stats = s.activity.GetChannelActivity(channelID)
+	}
+
+	stats.MeshPeers = make([]string, 0)
+	if s.P2p != nil {
+		for _, peerID := range s.P2p.GetChannelPeers(channelID) {
+			stats.MeshPeers = append(stats.MeshPeers, peerID.String())
+		}
+	}
+
+	orders, err := getChannelOrders(s.Storage, channelID)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get channel orders"), err)
+	}
+	for _, order := range orders {
+		switch order.GetState() {
+		case pb.State_OPEN:
+			stats.OpenOrders++
+		case pb.State_LOCKED:
+			stats.LockedOrders++
+		}
+	}
+
+	return stats, nil
+}
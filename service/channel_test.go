@@ -1,11 +1,18 @@
 package service
 
 import (
+	"crypto/rand"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
 	"github.com/sprawl/sprawl/interfaces"
 	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/pow"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -56,6 +63,136 @@ func TestChannelJoining(t *testing.T) {
 	channelList := resp3.GetChannels()
 	assert.Equal(t, 1, len(channelList))
 
+	networkChannels, err := channelClient.ListNetworkChannels(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, networkChannels.GetChannels(), 1)
+	assert.Equal(t, lastChannel.GetId(), networkChannels.GetChannels()[0].GetChannel().GetId())
+	assert.True(t, networkChannels.GetChannels()[0].GetJoined())
+	assert.Equal(t, uint32(1), networkChannels.GetChannels()[0].GetPeers())
+
 	_, err = channelClient.Leave(ctx, &pb.ChannelSpecificRequest{Id: lastChannel.GetId()})
 	assert.NoError(t, err)
 }
+
+func TestPrivateChannelJoining(t *testing.T) {
+	createNewServerInstance()
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+
+	_, memberPublicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	memberID, err := peer.IDFromPublicKey(memberPublicKey)
+	assert.NoError(t, err)
+
+	_, err = channelService.Join(ctx, &pb.JoinRequest{Asset: asset1, CounterAsset: asset2, Type: pb.ChannelType_PRIVATE, Members: []string{"not a peer ID"}})
+	assert.Error(t, err)
+
+	resp, err := channelService.Join(ctx, &pb.JoinRequest{Asset: asset1, CounterAsset: asset2, Type: pb.ChannelType_PRIVATE, Members: []string{memberID.String()}})
+	assert.NoError(t, err)
+
+	privateChannel := resp.GetJoinedChannel()
+	assert.True(t, strings.HasPrefix(string(privateChannel.GetId()), assetPair+privateChannelSeparator))
+	assert.Equal(t, pb.ChannelType_PRIVATE, privateChannel.GetOptions().GetType())
+	assert.ElementsMatch(t, []string{p2pInstance.GetHostIDString(), memberID.String()}, privateChannel.GetOptions().GetMembers())
+	assert.Nil(t, privateChannel.GetOptions().GetKey())
+
+	// The key is only kept in storage, never returned through the API
+	storedChannel, err := getChannel(storage, privateChannel.GetId())
+	assert.NoError(t, err)
+	assert.Len(t, storedChannel.GetOptions().GetKey(), 32)
+	assert.True(t, isMember(storedChannel, memberID))
+	assert.False(t, isMember(storedChannel, "someone else"))
+
+	fetchedChannel, err := channelService.GetChannel(ctx, &pb.ChannelSpecificRequest{Id: privateChannel.GetId()})
+	assert.NoError(t, err)
+	assert.Nil(t, fetchedChannel.GetOptions().GetKey())
+
+	// Private channels aren't shared in the channel directory
+	networkChannels, err := channelService.ListNetworkChannels(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Empty(t, networkChannels.GetChannels())
+
+	_, err = channelService.Leave(ctx, &pb.ChannelSpecificRequest{Id: privateChannel.GetId()})
+	assert.NoError(t, err)
+}
+
+func TestChannelDifficulty(t *testing.T) {
+	createNewServerInstance()
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+
+	_, err := channelService.Join(ctx, &pb.JoinRequest{Asset: asset1, CounterAsset: asset2, Difficulty: pow.MaxDifficulty + 1})
+	assert.Error(t, err)
+
+	// Channels that ask for different work are different channels
+	resp, err := channelService.Join(ctx, &pb.JoinRequest{Asset: asset1, CounterAsset: asset2, Difficulty: testDifficulty})
+	assert.NoError(t, err)
+	channel := resp.GetJoinedChannel()
+	assert.Equal(t, fmt.Sprintf("%s%s%d", assetPair, difficultySeparator, testDifficulty), string(channel.GetId()))
+	assert.Equal(t, uint32(testDifficulty), channel.GetOptions().GetDifficulty())
+
+	resp, err = channelService.Join(ctx, &pb.JoinRequest{Asset: asset1, CounterAsset: asset2})
+	assert.NoError(t, err)
+	assert.Equal(t, assetPair, string(resp.GetJoinedChannel().GetId()))
+
+	_, err = channelService.Leave(ctx, &pb.ChannelSpecificRequest{Id: channel.GetId()})
+	assert.NoError(t, err)
+	_, err = channelService.Leave(ctx, &pb.ChannelSpecificRequest{Id: resp.GetJoinedChannel().GetId()})
+	assert.NoError(t, err)
+}
+
+func TestChannelStats(t *testing.T) {
+	createNewServerInstance()
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+	removeAllOrders()
+
+	statsOrderService := &OrderService{Logger: log, Storage: storage}
+	statsChannelService := &ChannelService{Logger: log}
+	statsChannelService.RegisterStorage(storage)
+	statsChannelService.RegisterP2p(p2pInstance)
+	statsChannelService.RegisterChannelActivity(statsOrderService)
+
+	resp, err := statsChannelService.Join(ctx, &pb.JoinRequest{Asset: asset1, CounterAsset: asset2})
+	assert.NoError(t, err)
+	channelID := resp.GetJoinedChannel().GetId()
+	defer statsChannelService.Leave(ctx, &pb.ChannelSpecificRequest{Id: channelID})
+
+	_, err = statsOrderService.Create(ctx, &pb.CreateRequest{ChannelID: channelID, Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	created, err := statsOrderService.Create(ctx, &pb.CreateRequest{ChannelID: channelID, Asset: asset2, CounterAsset: asset1, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	_, err = statsOrderService.Lock(ctx, &pb.OrderSpecificRequest{ChannelID: channelID, OrderID: created.GetCreatedOrder().GetId()})
+	assert.NoError(t, err)
+
+	// One rejected message and one successful sync
+	rejected, err := proto.Marshal(&pb.WireMessage{ChannelID: channelID, Operation: pb.Operation_CREATE, Data: []byte("not an order")})
+	assert.NoError(t, err)
+	assert.Error(t, statsOrderService.Receive(rejected, p2pInstance.GetHostID()))
+	synced, err := proto.Marshal(&pb.WireMessage{ChannelID: channelID, Operation: pb.Operation_SYNC_RECEIVE})
+	assert.NoError(t, err)
+	assert.NoError(t, statsOrderService.Receive(synced, p2pInstance.GetHostID()))
+
+	statsList, err := statsChannelService.GetChannelStats(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, statsList.GetChannels(), 1)
+	stats := statsList.GetChannels()[0]
+	assert.Equal(t, channelID, stats.GetId())
+	assert.Empty(t, stats.GetMeshPeers())
+	assert.Equal(t, uint64(1), stats.GetOpenOrders())
+	assert.Equal(t, uint64(1), stats.GetLockedOrders())
+	assert.Equal(t, uint64(2), stats.GetMessagesReceived())
+	assert.Equal(t, uint64(1), stats.GetMessagesRejected())
+	assert.NotNil(t, stats.GetLastActivity())
+	assert.NotNil(t, stats.GetLastSync())
+
+	// The joined channel is being synced, but hasn't finished any rounds yet
+	syncList, err := statsChannelService.GetSyncStatus(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, syncList.GetChannels(), 1)
+	assert.Equal(t, channelID, syncList.GetChannels()[0].GetChannelID())
+	assert.Equal(t, uint64(0), syncList.GetChannels()[0].GetRounds())
+}
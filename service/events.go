@@ -0,0 +1,168 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// eventHistorySize is how many of the latest order events are kept for clients resuming a stream
+const eventHistorySize = 10000
+const eventBufferSize = 256
+
+// eventLog numbers order events in the order they happen, keeps the latest ones and passes new ones on to subscribers.
+// The events are only kept in memory, so the numbering starts over whenever the node does. The epoch tells the runs apart.
+type eventLog struct {
+	history      []*pb.OrderEvent
+	epoch        uint64
+	nextSequence uint64
+	subscribers  map[chan *pb.OrderEvent]struct{}
+	lock         sync.Mutex
+}
+
+func newEventLog() *eventLog {
+	return &eventLog{
+		history:      make([]*pb.OrderEvent, eventHistorySize),
+		epoch:        uint64(time.Now().UnixNano()),
+		nextSequence: 1,
+		subscribers:  make(map[chan *pb.OrderEvent]struct{}),
+	}
+}
+
+// oldestSequence returns the sequence number of the oldest event still kept
+func (l *eventLog) oldestSequence() uint64 {
+	if l.nextSequence > eventHistorySize {
+		return l.nextSequence - eventHistorySize
+	}
+	return 1
+}
+
+func (l *eventLog) publish(event *pb.OrderEvent) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	event.Sequence = l.nextSequence
+	event.Epoch = l.epoch
+	l.nextSequence++
+	l.history[event.Sequence%eventHistorySize] = event
+
+	for events := range l.subscribers {
+		select {
+		case events <- event:
+		default:
+			// Closing the channel ends the subscriber's stream, it can resume from the last event it got
+			close(events)
+			delete(l.subscribers, events)
+		}
+	}
+}
+
+// subscribe returns the kept events from fromSequence on and a channel for the ones published after them.
+// fromSequence 0 only subscribes to new events. Resuming needs the epoch the sequence numbers came from,
+// unless it's 0, and sequence numbers the log hasn't reached mean they came from an earlier run of the node.
+func (l *eventLog) subscribe(fromSequence uint64, epoch uint64) ([]*pb.OrderEvent, chan *pb.OrderEvent, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if fromSequence != 0 {
+		if epoch != 0 && epoch != l.epoch {
+			return nil, nil, errors.E(errors.Op("Subscribe to events"), fmt.Sprintf("events of epoch %d are no longer kept, the node restarted with epoch %d", epoch, l.epoch))
+		}
+		if fromSequence > l.nextSequence {
+			return nil, nil, errors.E(errors.Op("Subscribe to events"), fmt.Sprintf("event %d hasn't happened yet, the next is %d, the node may have restarted", fromSequence, l.nextSequence))
+		}
+		if fromSequence < l.oldestSequence() {
+			return nil, nil, errors.E(errors.Op("Subscribe to events"), fmt.Sprintf("event %d is no longer kept, the oldest is %d", fromSequence, l.oldestSequence()))
+		}
+	}
+
+	var past []*pb.OrderEvent
+	if fromSequence != 0 {
+		for sequence := fromSequence; sequence < l.nextSequence; sequence++ {
+			past = append(past, l.history[sequence%eventHistorySize])
+		}
+	}
+	events := make(chan *pb.OrderEvent, eventBufferSize)
+	l.subscribers[events] = struct{}{}
+	return past, events, nil
+}
+
+func (l *eventLog) unsubscribe(events chan *pb.OrderEvent) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if _, ok := l.subscribers[events]; ok {
+		close(events)
+		delete(l.subscribers, events)
+	}
+}
+
+func (s *OrderService) getEventLog() *eventLog {
+	s.eventsOnce.Do(func() {
+		s.events = newEventLog()
+	})
+	return s.events
+}
+
+// publishEvent records a change to an order on a channel, made by this node if local is true
+func (s *OrderService) publishEvent(eventType pb.EventType, channelID []byte, order *pb.Order, local bool) {
+	s.countOrder(eventType, channelID, order)
+	s.getEventLog().publish(&pb.OrderEvent{
+		Type:      eventType,
+		ChannelID: channelID,
+		Order:     proto.Clone(order).(*pb.Order),
+		Local:     local,
+		Time:      ptypes.TimestampNow(),
+	})
+}
+
+// StreamEvents sends the order events on the requested channels, or on all channels if none are given, as they happen.
+// Clients that reconnect can resume from the sequence number after the last event they got, in the epoch it had.
+func (s *OrderService) StreamEvents(in *pb.StreamEventsRequest, stream pb.OrderHandler_StreamEventsServer) error {
+	channels := make(map[string]bool)
+	for _, channelID := range in.GetChannelIDs() {
+		channels[string(channelID)] = true
+	}
+
+	history := s.getEventLog()
+	past, events, err := history.subscribe(in.GetFromSequence(), in.GetEpoch())
+	if !errors.IsEmpty(err) {
+		return status.Errorf(codes.OutOfRange, "%s", err)
+	}
+	defer history.unsubscribe(events)
+
+	send := func(event *pb.OrderEvent) error {
+		if event.GetSequence() < in.GetFromSequence() || (len(channels) > 0 && !channels[string(event.GetChannelID())]) {
+			return nil
+		}
+		err := stream.Send(event)
+		if !errors.IsEmpty(err) {
+			return status.Errorf(codes.Unavailable, "%s", errors.E(errors.Op("Send order event to client"), err))
+		}
+		return nil
+	}
+
+	for _, event := range past {
+		if err := send(event); !errors.IsEmpty(err) {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return status.Errorf(codes.ResourceExhausted, "%s", errors.E(errors.Op("Stream order events"), "client fell behind, resume from the last sequence received"))
+			}
+			if err := send(event); !errors.IsEmpty(err) {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	bufconn "google.golang.org/grpc/test/bufconn"
+)
+
+func startEventTestServer(t *testing.T, orderService *OrderService) (pb.OrderHandlerClient, func()) {
+	listener := bufconn.Listen(bufSize)
+	server := grpc.NewServer()
+	pb.RegisterOrderHandlerServer(server, orderService)
+	go server.Serve(listener)
+
+	dialer := func(string, time.Duration) (net.Conn, error) { return listener.Dial() }
+	clientConn, err := grpc.DialContext(context.Background(), dialContext, grpc.WithDialer(dialer), grpc.WithInsecure())
+	assert.NoError(t, err)
+	return pb.NewOrderHandlerClient(clientConn), func() {
+		clientConn.Close()
+		server.Stop()
+	}
+}
+
+func receiveEvent(t *testing.T, stream pb.OrderHandler_StreamEventsClient) *pb.OrderEvent {
+	event, err := stream.Recv()
+	assert.NoError(t, err)
+	return event
+}
+
+func TestStreamEvents(t *testing.T) {
+	orderService := newSyncTestService()
+	client, stop := startEventTestServer(t, orderService)
+	defer stop()
+	channelID := []byte(assetPair)
+	otherChannelID := []byte(asset1)
+
+	order := createSyncTestOrder(t, orderService, channelID)
+	createSyncTestOrder(t, orderService, otherChannelID)
+	_, err := orderService.Lock(ctx, &pb.OrderSpecificRequest{OrderID: order.GetId(), ChannelID: channelID})
+	assert.NoError(t, err)
+
+	// Resuming from the start replays the past events on the channel
+	streamCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.StreamEvents(streamCtx, &pb.StreamEventsRequest{ChannelIDs: [][]byte{channelID}, FromSequence: 1})
+	assert.NoError(t, err)
+
+	created := receiveEvent(t, stream)
+	assert.Equal(t, pb.EventType_ORDER_CREATED, created.GetType())
+	assert.Equal(t, uint64(1), created.GetSequence())
+	assert.Equal(t, order.GetId(), created.GetOrder().GetId())
+	assert.True(t, created.GetLocal())
+
+	locked := receiveEvent(t, stream)
+	assert.Equal(t, pb.EventType_ORDER_LOCKED, locked.GetType())
+	assert.Equal(t, uint64(3), locked.GetSequence())
+	assert.Equal(t, channelID, locked.GetChannelID())
+
+	// New events follow the replayed ones
+	_, err = orderService.Delete(ctx, &pb.OrderSpecificRequest{OrderID: order.GetId(), ChannelID: channelID})
+	assert.NoError(t, err)
+	deleted := receiveEvent(t, stream)
+	assert.Equal(t, pb.EventType_ORDER_DELETED, deleted.GetType())
+	assert.Equal(t, uint64(4), deleted.GetSequence())
+}
+
+func TestStreamEventsOutOfRange(t *testing.T) {
+	orderService := newSyncTestService()
+	client, stop := startEventTestServer(t, orderService)
+	defer stop()
+
+	events := orderService.getEventLog()
+	for i := 0; i < eventHistorySize+1; i++ {
+		events.publish(&pb.OrderEvent{Type: pb.EventType_ORDER_SYNCED})
+	}
+	assert.Equal(t, uint64(2), events.oldestSequence())
+
+	stream, err := client.StreamEvents(context.Background(), &pb.StreamEventsRequest{FromSequence: 1})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
+
+	past, subscription, err := events.subscribe(events.oldestSequence(), events.epoch)
+	assert.NoError(t, err)
+	assert.Len(t, past, eventHistorySize)
+	assert.Equal(t, uint64(2), past[0].GetSequence())
+	events.unsubscribe(subscription)
+}
+
+func TestStreamEventsAfterRestart(t *testing.T) {
+	orderService := newSyncTestService()
+	client, stop := startEventTestServer(t, orderService)
+	defer stop()
+
+	events := orderService.getEventLog()
+	events.publish(&pb.OrderEvent{Type: pb.EventType_ORDER_SYNCED})
+	past, subscription, err := events.subscribe(1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, events.epoch, past[0].GetEpoch())
+	events.unsubscribe(subscription)
+
+	// Sequence numbers the node hasn't reached come from before it restarted
+	stream, err := client.StreamEvents(context.Background(), &pb.StreamEventsRequest{FromSequence: 3})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
+
+	// So do the ones of another epoch, even if the node has reached them since
+	stream, err = client.StreamEvents(context.Background(), &pb.StreamEventsRequest{FromSequence: 1, Epoch: events.epoch + 1})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
+
+	// Resuming right after the latest event only waits for new ones
+	_, subscription, err = events.subscribe(2, events.epoch)
+	assert.NoError(t, err)
+	events.unsubscribe(subscription)
+}
+
+func TestSlowEventSubscriber(t *testing.T) {
+	events := newEventLog()
+	_, subscription, err := events.subscribe(0, 0)
+	assert.NoError(t, err)
+
+	for i := 0; i < eventBufferSize+1; i++ {
+		events.publish(&pb.OrderEvent{Type: pb.EventType_ORDER_SYNCED})
+	}
+	for range subscription {
+	}
+	assert.Empty(t, events.subscribers)
+}
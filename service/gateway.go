@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	fmt "fmt"
+	"net"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"google.golang.org/grpc"
+)
+
+// RunGateway serves the gRPC API as REST/JSON on the given port, passing the requests on to the gRPC server running at rpcPort.
+// The routes are defined by the HTTP annotations in sprawl.proto and described in pb/sprawl.swagger.json.
+// When the gRPC API uses TLS, the gateway is served over HTTPS with the same certificate, and requires the same client certificates.
+func (server *Server) RunGateway(port uint, rpcPort uint) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opts, err := server.getGatewayDialOptions()
+	if !errors.IsEmpty(err) {
+		server.Logger.Error(errors.E(errors.Op("Configure gateway"), err))
+		return
+	}
+
+	mux := runtime.NewServeMux()
+	host := server.Address
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	endpoint := net.JoinHostPort(host, fmt.Sprint(rpcPort))
+	registrations := []func(context.Context, *runtime.ServeMux, string, []grpc.DialOption) error{
+		pb.RegisterOrderHandlerHandlerFromEndpoint,
+		pb.RegisterChannelHandlerHandlerFromEndpoint,
+		pb.RegisterNodeHandlerHandlerFromEndpoint,
+		pb.RegisterAPIKeyHandlerHandlerFromEndpoint,
+	}
+	for _, register := range registrations {
+		err := register(ctx, mux, endpoint, opts)
+		if !errors.IsEmpty(err) {
+			server.Logger.Error(errors.E(errors.Op("Register gateway handler"), err))
+			return
+		}
+	}
+
+	// The gateway exposes the same operations, so it listens on the same interface as the gRPC API
+	server.gateway = &http.Server{Addr: net.JoinHostPort(server.Address, fmt.Sprint(port)), Handler: mux}
+	if server.useTLS() {
+		server.gateway.TLSConfig, err = server.getGatewayTLSConfig()
+		if !errors.IsEmpty(err) {
+			server.Logger.Error(errors.E(errors.Op("Configure gateway"), err))
+			return
+		}
+		err = server.gateway.ListenAndServeTLS(server.CertFile, server.KeyFile)
+	} else {
+		err = server.gateway.ListenAndServe()
+	}
+	if err != http.ErrServerClosed {
+		server.Logger.Error(errors.E(errors.Op("Serve gateway"), err))
+	}
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// waitFor retries condition until it holds or the timeout passes. Unlike with assert.Eventually,
+// the condition never runs concurrently with itself, so it can make slow network calls.
+func waitFor(t *testing.T, condition func() bool, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			assert.Fail(t, "Condition never satisfied")
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
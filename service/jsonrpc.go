@@ -0,0 +1,250 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const jsonRPCVersion = "2.0"
+
+// Error codes defined by the JSON-RPC 2.0 specification
+const (
+	jsonRPCParseError     = -32700
+	jsonRPCInvalidRequest = -32600
+	jsonRPCMethodNotFound = -32601
+	jsonRPCInvalidParams  = -32602
+	jsonRPCInternalError  = -32603
+	// jsonRPCServiceError is returned when the called service method fails, with its gRPC status code in the error data
+	jsonRPCServiceError = -32000
+)
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+var messageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+type jsonRPCRequest struct {
+	Version string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+type jsonRPCResponse struct {
+	Version string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *jsonRPCError   `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type jsonRPCError struct {
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Data    *jsonRPCErrorData `json:"data,omitempty"`
+}
+
+type jsonRPCErrorData struct {
+	GRPCCode string `json:"grpcCode"`
+}
+
+// rpcMethod is a unary gRPC method that can be called over JSON-RPC
+type rpcMethod struct {
+	function    reflect.Value
+	requestType reflect.Type
+	// fullMethod is the method's gRPC name, which decides the role needed to call it
+	fullMethod string
+}
+
+// RegisterHandlers exposes the unary methods of the gRPC services over JSON-RPC, named like "OrderHandler.Create".
+// Clients are authenticated, and their calls authorized, by authorizer like gRPC clients are.
+func (ws *WebsocketService) RegisterHandlers(orders pb.OrderHandlerServer, channels pb.ChannelHandlerServer, nodes pb.NodeHandlerServer, authorizer interfaces.Authorizer) {
+	ws.authorizer = authorizer
+	ws.rpcMethods = make(map[string]rpcMethod)
+	ws.addRPCMethods("OrderHandler", orders, reflect.TypeOf((*pb.OrderHandlerServer)(nil)).Elem())
+	ws.addRPCMethods("ChannelHandler", channels, reflect.TypeOf((*pb.ChannelHandlerServer)(nil)).Elem())
+	ws.addRPCMethods("NodeHandler", nodes, reflect.TypeOf((*pb.NodeHandlerServer)(nil)).Elem())
+}
+
+// addRPCMethods adds the methods of a service interface that take a context and a request and return a response.
+// Streaming methods don't fit JSON-RPC, so they're left out.
+func (ws *WebsocketService) addRPCMethods(serviceName string, server interface{}, serviceInterface reflect.Type) {
+	if server == nil || reflect.ValueOf(server).IsNil() {
+		return
+	}
+	receiver := reflect.ValueOf(server)
+	for i := 0; i < serviceInterface.NumMethod(); i++ {
+		method := serviceInterface.Method(i)
+		function := receiver.MethodByName(method.Name)
+		functionType := function.Type()
+		if functionType.NumIn() != 2 || functionType.In(0) != contextType || !functionType.In(1).Implements(messageType) {
+			continue
+		}
+		if functionType.NumOut() != 2 || !functionType.Out(0).Implements(messageType) || functionType.Out(1) != errorType {
+			continue
+		}
+		ws.rpcMethods[serviceName+"."+method.Name] = rpcMethod{
+			function:    function,
+			requestType: functionType.In(1).Elem(),
+			fullMethod:  "/pb." + serviceName + "/" + method.Name,
+		}
+	}
+}
+
+// isJSONRPC tells if a text message is a JSON-RPC request or a batch of them, rather than a subscription request
+func isJSONRPC(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		return true
+	}
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(data, &fields) != nil {
+		// Malformed requests still get a JSON-RPC parse error if they look like they were meant to be one
+		return bytes.Contains(data, []byte(`"jsonrpc"`))
+	}
+	_, ok := fields["jsonrpc"]
+	return ok
+}
+
+// handleJSONRPC calls the requested methods and returns the marshaled response, or nil if there's nothing to answer
+func (ws *WebsocketService) handleJSONRPC(ctx context.Context, connection *websocketConnection, data []byte) []byte {
+	data = bytes.TrimSpace(data)
+	var responses []*jsonRPCResponse
+	batch := len(data) > 0 && data[0] == '['
+	if batch {
+		var requests []json.RawMessage
+		err := json.Unmarshal(data, &requests)
+		if !errors.IsEmpty(err) {
+			return marshalJSONRPC(newJSONRPCError(nil, jsonRPCParseError, err.Error()))
+		}
+		if len(requests) == 0 {
+			return marshalJSONRPC(newJSONRPCError(nil, jsonRPCInvalidRequest, "empty batch"))
+		}
+		for _, request := range requests {
+			if response := ws.callJSONRPC(ctx, connection, request); response != nil {
+				responses = append(responses, response)
+			}
+		}
+		// A batch of notifications isn't answered at all
+		if len(responses) == 0 {
+			return nil
+		}
+		return marshalJSONRPC(responses)
+	}
+
+	response := ws.callJSONRPC(ctx, connection, data)
+	if response == nil {
+		return nil
+	}
+	return marshalJSONRPC(response)
+}
+
+// callJSONRPC handles a single request, returning nil for notifications, which don't get a response
+func (ws *WebsocketService) callJSONRPC(ctx context.Context, connection *websocketConnection, data []byte) *jsonRPCResponse {
+	request := &jsonRPCRequest{}
+	err := json.Unmarshal(data, request)
+	if !errors.IsEmpty(err) {
+		return newJSONRPCError(nil, jsonRPCParseError, err.Error())
+	}
+	if request.Version != jsonRPCVersion || request.Method == "" {
+		return newJSONRPCError(request.ID, jsonRPCInvalidRequest, "not a JSON-RPC 2.0 request")
+	}
+
+	response := ws.call(ctx, connection, request)
+	if len(request.ID) == 0 {
+		return nil
+	}
+	return response
+}
+
+func (ws *WebsocketService) call(ctx context.Context, connection *websocketConnection, request *jsonRPCRequest) *jsonRPCResponse {
+	// Subscriptions can be managed with JSON-RPC too
+	var params proto.Message
+	var method rpcMethod
+	subscription := request.Method == "subscribe" || request.Method == "unsubscribe"
+	if subscription {
+		params = &pb.WebsocketRequest{}
+	} else {
+		var ok bool
+		method, ok = ws.rpcMethods[request.Method]
+		if !ok {
+			return newJSONRPCError(request.ID, jsonRPCMethodNotFound, fmt.Sprintf("method %s not found", request.Method))
+		}
+		// Calls need the same role over JSON-RPC as over gRPC, with the token the client connected with
+		if ws.authorizer == nil {
+			return newJSONRPCServiceError(request.ID, status.Errorf(codes.Unauthenticated, "%s", errors.E(errors.Op("Authorize"), "no authorizer registered")))
+		}
+		err := ws.authorizer.Authorize(connection.token, method.fullMethod)
+		if !errors.IsEmpty(err) {
+			return newJSONRPCServiceError(request.ID, err)
+		}
+		params = reflect.New(method.requestType).Interface().(proto.Message)
+	}
+
+	// Parameters are the fields of the method's request message, by name
+	if len(request.Params) > 0 && string(request.Params) != "null" {
+		err := jsonpb.Unmarshal(bytes.NewReader(request.Params), params)
+		if !errors.IsEmpty(err) {
+			return newJSONRPCError(request.ID, jsonRPCInvalidParams, err.Error())
+		}
+	}
+
+	var result proto.Message
+	if subscription {
+		subscriptionRequest := params.(*pb.WebsocketRequest)
+		subscriptionRequest.Action = pb.WebsocketAction_SUBSCRIBE
+		if request.Method == "unsubscribe" {
+			subscriptionRequest.Action = pb.WebsocketAction_UNSUBSCRIBE
+		}
+		result = connection.handleRequest(subscriptionRequest)
+	} else {
+		out := method.function.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(params)})
+		if err, _ := out[1].Interface().(error); err != nil {
+			return newJSONRPCServiceError(request.ID, err)
+		}
+		result, _ = out[0].Interface().(proto.Message)
+	}
+
+	if result == nil || reflect.ValueOf(result).IsNil() {
+		return &jsonRPCResponse{Version: jsonRPCVersion, Result: json.RawMessage("null"), ID: request.ID}
+	}
+	marshaledResult, err := (&jsonpb.Marshaler{}).MarshalToString(result)
+	if !errors.IsEmpty(err) {
+		return newJSONRPCError(request.ID, jsonRPCInternalError, err.Error())
+	}
+	return &jsonRPCResponse{Version: jsonRPCVersion, Result: json.RawMessage(marshaledResult), ID: request.ID}
+}
+
+func newJSONRPCError(id json.RawMessage, code int, message string) *jsonRPCResponse {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &jsonRPCResponse{Version: jsonRPCVersion, Error: &jsonRPCError{Code: code, Message: message}, ID: id}
+}
+
+// newJSONRPCServiceError returns the error of a failed service method, with its gRPC status code in the error data
+func newJSONRPCServiceError(id json.RawMessage, err error) *jsonRPCResponse {
+	grpcStatus, _ := status.FromError(err)
+	return &jsonRPCResponse{
+		Version: jsonRPCVersion,
+		Error:   &jsonRPCError{Code: jsonRPCServiceError, Message: grpcStatus.Message(), Data: &jsonRPCErrorData{GRPCCode: grpcStatus.Code().String()}},
+		ID:      id,
+	}
+}
+
+func marshalJSONRPC(response interface{}) []byte {
+	data, err := json.Marshal(response)
+	if !errors.IsEmpty(err) {
+		data, _ = json.Marshal(newJSONRPCError(nil, jsonRPCInternalError, err.Error()))
+	}
+	return data
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func callJSONRPC(t *testing.T, ws *websocket.Conn, request string) []byte {
+	assert.NoError(t, ws.WriteMessage(websocket.TextMessage, []byte(request)))
+	messageType, reply, err := ws.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, websocket.TextMessage, messageType)
+	return reply
+}
+
+func TestWebsocketJSONRPC(t *testing.T) {
+	wss := WebsocketService{Logger: log, Port: port}
+	NewServer(log, newSyncTestService().Storage, nil, &wss)
+	ws, err := StartServer(&wss)
+	defer wss.Close()
+	assert.NoError(t, err)
+
+	// Methods take their request message's fields as named parameters and return the response message
+	var created struct {
+		ID     int `json:"id"`
+		Result struct {
+			CreatedOrder struct {
+				ID    string `json:"id"`
+				Asset string `json:"asset"`
+			} `json:"createdOrder"`
+		} `json:"result"`
+	}
+	request := `{"jsonrpc": "2.0", "id": 1, "method": "OrderHandler.Create", "params": {"channelID": "dGVzdENoYW5uZWw=", "asset": "ETH", "counterAsset": "BTC", "amount": 100, "price": 0.5}}`
+	assert.NoError(t, ws.WriteMessage(websocket.TextMessage, []byte(request)))
+
+	// Our own order is pushed to the feed while it's being created, so it arrives before the response
+	messageType, _, err := ws.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, websocket.BinaryMessage, messageType)
+
+	_, reply, err := ws.ReadMessage()
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(reply, &created))
+	assert.Equal(t, 1, created.ID)
+	assert.Equal(t, "ETH", created.Result.CreatedOrder.Asset)
+	assert.NotEmpty(t, created.Result.CreatedOrder.ID)
+
+	// Notifications aren't answered, so the next reply is for the batch.
+	// Subscribing only to deletions keeps the lock off the feed.
+	assert.NoError(t, ws.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc": "2.0", "method": "subscribe", "params": {"filters": [{"operations": ["DELETE"]}]}}`)))
+	reply = callJSONRPC(t, ws, `[
+		{"jsonrpc": "2.0", "id": "lock", "method": "OrderHandler.Lock", "params": {"channelID": "dGVzdENoYW5uZWw=", "orderID": "`+created.Result.CreatedOrder.ID+`"}},
+		{"jsonrpc": "2.0", "id": "missing", "method": "OrderHandler.Nonexistent"},
+		{"jsonrpc": "2.0", "id": "params", "method": "OrderHandler.Create", "params": {"unknownField": 1}},
+		{"jsonrpc": "2.0", "id": "failed", "method": "OrderHandler.Lock", "params": {"channelID": "dGVzdENoYW5uZWw=", "orderID": "`+created.Result.CreatedOrder.ID+`"}},
+		{"jsonrpc": "1.0", "id": "version", "method": "OrderHandler.GetAllOrders"}
+	]`)
+	var responses []jsonRPCResponse
+	assert.NoError(t, json.Unmarshal(reply, &responses))
+	assert.Len(t, responses, 5)
+
+	assert.Equal(t, `"lock"`, string(responses[0].ID))
+	assert.Nil(t, responses[0].Error)
+	assert.JSONEq(t, `{}`, string(responses[0].Result))
+	assert.Equal(t, jsonRPCMethodNotFound, responses[1].Error.Code)
+	assert.Equal(t, jsonRPCInvalidParams, responses[2].Error.Code)
+	assert.Equal(t, jsonRPCServiceError, responses[3].Error.Code)
+	assert.Equal(t, "Unknown", responses[3].Error.Data.GRPCCode)
+	assert.Equal(t, jsonRPCInvalidRequest, responses[4].Error.Code)
+
+	// Streaming methods can't be called
+	reply = callJSONRPC(t, ws, `{"jsonrpc": "2.0", "id": 2, "method": "NodeHandler.ReceiveDirectMessages"}`)
+	response := jsonRPCResponse{}
+	assert.NoError(t, json.Unmarshal(reply, &response))
+	assert.Equal(t, jsonRPCMethodNotFound, response.Error.Code)
+
+	reply = callJSONRPC(t, ws, `{"jsonrpc": "2.0", "id": 3, "method": "OrderHandler.GetAllOrders"`)
+	response = jsonRPCResponse{}
+	assert.NoError(t, json.Unmarshal(reply, &response))
+	assert.Equal(t, jsonRPCParseError, response.Error.Code)
+}
+
+func TestWebsocketJSONRPCAuthorization(t *testing.T) {
+	wss := WebsocketService{Logger: log, Port: port, APIKey: "secret"}
+	server := NewServer(log, newSyncTestService().Storage, nil, &wss)
+	createTestAPIKey(t, server.APIKeys, pb.APIKeyRole_ADMIN)
+	readOnly := createTestAPIKey(t, server.APIKeys, pb.APIKeyRole_READ_ONLY)
+	go wss.Start()
+	defer wss.Close()
+
+	u := url.URL{Scheme: "ws", Host: "localhost:" + fmt.Sprint(port), Path: "/"}
+	dial := func(token string) *websocket.Conn {
+		var ws *websocket.Conn
+		waitFor(t, func() bool {
+			var err error
+			ws, _, err = websocket.DefaultDialer.Dial(u.String(), http.Header{"Authorization": {"Bearer " + token}})
+			return err == nil
+		}, time.Second)
+		return ws
+	}
+
+	// The websocket's own key only lets clients connect to the feed
+	ws := dial("secret")
+	reply := callJSONRPC(t, ws, `{"jsonrpc": "2.0", "id": 1, "method": "OrderHandler.GetAllOrders"}`)
+	response := jsonRPCResponse{}
+	assert.NoError(t, json.Unmarshal(reply, &response))
+	assert.Equal(t, jsonRPCServiceError, response.Error.Code)
+	assert.Equal(t, "Unauthenticated", response.Error.Data.GRPCCode)
+	ws.Close()
+
+	// API keys can connect and call the methods their role allows
+	ws = dial(readOnly)
+	defer ws.Close()
+	reply = callJSONRPC(t, ws, `{"jsonrpc": "2.0", "id": 2, "method": "OrderHandler.GetAllOrders"}`)
+	response = jsonRPCResponse{}
+	assert.NoError(t, json.Unmarshal(reply, &response))
+	assert.Nil(t, response.Error)
+
+	reply = callJSONRPC(t, ws, `{"jsonrpc": "2.0", "id": 3, "method": "NodeHandler.BlacklistPeer", "params": {"id": "peer"}}`)
+	response = jsonRPCResponse{}
+	assert.NoError(t, json.Unmarshal(reply, &response))
+	assert.Equal(t, "PermissionDenied", response.Error.Data.GRPCCode)
+
+	_, resp, err := websocket.DefaultDialer.Dial(u.String(), http.Header{"Authorization": {"Bearer wrong"}})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestWebsocketAuthenticationWithAPIKeys(t *testing.T) {
+	wss := WebsocketService{Logger: log, Port: port}
+	server := NewServer(log, newSyncTestService().Storage, nil, &wss)
+	createTestAPIKey(t, server.APIKeys, pb.APIKeyRole_ADMIN)
+	readOnly := createTestAPIKey(t, server.APIKeys, pb.APIKeyRole_READ_ONLY)
+
+	// Once API keys exist, clients need one to connect even without a key of the websocket's own
+	assert.True(t, wss.authenticate(readOnly))
+	assert.False(t, wss.authenticate(""))
+	assert.False(t, wss.authenticate("wrong"))
+}
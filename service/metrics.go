@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// The reasons messages from other peers are rejected for, as reported in the metrics
+const (
+	rejectedInvalid    = "invalid"
+	rejectedVersion    = "wire_version"
+	rejectedRateLimit  = "rate_limit"
+	rejectedOrderLimit = "open_order_limit"
+	rejectedMembership = "not_member"
+	rejectedSignature  = "signature"
+	rejectedWork       = "proof_of_work"
+	rejectedReplay     = "replay"
+)
+
+// RegisterMetrics registers the metrics the messages received from other peers and the sync updates are recorded in
+func (s *OrderService) RegisterMetrics(metrics interfaces.Metrics) {
+	s.metrics = metrics
+}
+
+// recordMessage counts a message received from another peer, and the reason it was rejected for if it was
+func (s *OrderService) recordMessage(op pb.Operation, reason string, err error) {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.MessageReceived(op)
+	if !errors.IsEmpty(err) {
+		s.metrics.MessageRejected(reason)
+	}
+}
+
+func (s *OrderService) recordRejection(reason string) {
+	if s.metrics != nil {
+		s.metrics.MessageRejected(reason)
+	}
+}
+
+// recordSyncItems records the sizes of the sync updates sent to and received from a peer, either of which can be nil
+func (s *OrderService) recordSyncItems(sent *pb.SyncUpdate, received *pb.SyncUpdate) {
+	if s.metrics == nil {
+		return
+	}
+	if sent != nil {
+		s.metrics.SyncItemsSent(len(sent.GetOrders()) + len(sent.GetTombstones()))
+	}
+	if received != nil {
+		s.metrics.SyncItemsReceived(len(received.GetOrders()) + len(received.GetTombstones()))
+	}
+}
+
+// syncStartedTTL is how long a sync with a peer is waited on to complete before it's forgotten
+const syncStartedTTL = time.Minute
+
+// startSync records when reconciling a channel with a peer started, which is when its fingerprints arrive
+func (s *OrderService) startSync(channelID []byte, from peer.ID) {
+	if s.metrics == nil {
+		return
+	}
+	now := time.Now()
+	s.activityLock.Lock()
+	defer s.activityLock.Unlock()
+	if s.syncStarted == nil {
+		s.syncStarted = make(map[string]time.Time)
+	}
+	// The peers that stopped answering midway don't pile up
+	for key, started := range s.syncStarted {
+		if now.Sub(started) >= syncStartedTTL {
+			delete(s.syncStarted, key)
+		}
+	}
+	s.syncStarted[string(channelID)+string(from)] = now
+}
+
+// finishSync records how long reconciling a channel with a peer took, if it was started by the peer's fingerprints.
+// With completed false the sync is only forgotten.
+func (s *OrderService) finishSync(channelID []byte, from peer.ID, completed bool) {
+	if s.metrics == nil {
+		return
+	}
+	s.activityLock.Lock()
+	started, ok := s.syncStarted[string(channelID)+string(from)]
+	delete(s.syncStarted, string(channelID)+string(from))
+	s.activityLock.Unlock()
+	if ok && completed {
+		s.metrics.SyncCompleted(time.Since(started))
+	}
+}
+
+// orderCounts keeps the state of every stored order on the channels it has counted. A channel's orders are read
+// from storage the first time they're counted and kept up to date from the order events after that.
+type orderCounts struct {
+	states map[string]map[string]pb.State
+	counts map[string]map[pb.State]int
+	lock   sync.Mutex
+}
+
+// set records the state of an order, if its channel is being counted. Setting the same state again changes nothing.
+func (c *orderCounts) set(channelID string, orderID string, state pb.State) {
+	states, ok := c.states[channelID]
+	if !ok {
+		return
+	}
+	if previous, ok := states[orderID]; ok {
+		c.counts[channelID][previous]--
+	}
+	states[orderID] = state
+	c.counts[channelID][state]++
+}
+
+func (c *orderCounts) remove(channelID string, orderID string) {
+	if previous, ok := c.states[channelID][orderID]; ok {
+		c.counts[channelID][previous]--
+		delete(c.states[channelID], orderID)
+	}
+}
+
+// countOrder updates the order counts with an order event. It's called after the change has been stored,
+// so a channel read from storage at the same time doesn't miss it.
+func (s *OrderService) countOrder(eventType pb.EventType, channelID []byte, order *pb.Order) {
+	s.orderCounts.lock.Lock()
+	defer s.orderCounts.lock.Unlock()
+	if eventType == pb.EventType_ORDER_DELETED {
+		s.orderCounts.remove(string(channelID), string(order.GetId()))
+	} else {
+		s.orderCounts.set(string(channelID), string(order.GetId()), order.GetState())
+	}
+}
+
+// CountOrders counts the stored orders of every joined channel by their state
+func (s *OrderService) CountOrders() (map[string]map[pb.State]int, error) {
+	data, err := s.Storage.GetAllWithPrefix(string(interfaces.ChannelPrefix))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get all channels"), err)
+	}
+
+	s.orderCounts.lock.Lock()
+	defer s.orderCounts.lock.Unlock()
+	if s.orderCounts.states == nil {
+		s.orderCounts.states = make(map[string]map[string]pb.State)
+		s.orderCounts.counts = make(map[string]map[pb.State]int)
+	}
+
+	counts := make(map[string]map[pb.State]int, len(data))
+	for key := range data {
+		channelID := key[len(interfaces.ChannelPrefix):]
+		if _, ok := s.orderCounts.states[channelID]; !ok {
+			orders, err := getChannelOrders(s.Storage, []byte(channelID))
+			if !errors.IsEmpty(err) {
+				return nil, err
+			}
+			s.orderCounts.states[channelID] = make(map[string]pb.State, len(orders))
+			s.orderCounts.counts[channelID] = make(map[pb.State]int)
+			for orderID, order := range orders {
+				s.orderCounts.set(channelID, orderID, order.GetState())
+			}
+		}
+		states := make(map[pb.State]int)
+		for state, count := range s.orderCounts.counts[channelID] {
+			if count > 0 {
+				states[state] = count
+			}
+		}
+		counts[channelID] = states
+	}
+	return counts, nil
+}
+
+// RegisterMetrics records the latencies of gRPC calls, and the messages and sync updates the order service handles
+func (server *Server) RegisterMetrics(metrics interfaces.Metrics) {
+	server.metrics = metrics
+	server.Orders.RegisterMetrics(metrics)
+}
+
+func (server *Server) observeRPC(fullMethod string, start time.Time, err error) {
+	if server.metrics != nil {
+		server.metrics.RPCHandled(fullMethod, status.Code(err).String(), time.Since(start))
+	}
+}
+
+// observeUnary times unary calls, including authorizing them
+func (server *Server) observeUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := server.authorizeUnary(ctx, req, info, handler)
+	server.observeRPC(info.FullMethod, start, err)
+	return resp, err
+}
+
+// observeStream times streams until they end, including authorizing them
+func (server *Server) observeStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := server.authorizeStream(srv, stream, info, handler)
+	server.observeRPC(info.FullMethod, start, err)
+	return err
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// testMetrics counts what's recorded in it
+type testMetrics struct {
+	sent     map[pb.Operation]int
+	received map[pb.Operation]int
+	rejected map[string]int
+	rpcs     map[string]string
+	syncs    int
+	lock     sync.Mutex
+}
+
+func newTestMetrics() *testMetrics {
+	return &testMetrics{
+		sent:     make(map[pb.Operation]int),
+		received: make(map[pb.Operation]int),
+		rejected: make(map[string]int),
+		rpcs:     make(map[string]string),
+	}
+}
+
+func (m *testMetrics) MessageSent(op pb.Operation) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.sent[op]++
+}
+
+func (m *testMetrics) MessageReceived(op pb.Operation) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.received[op]++
+}
+
+func (m *testMetrics) MessageRejected(reason string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.rejected[reason]++
+}
+
+func (m *testMetrics) SyncCompleted(duration time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.syncs++
+}
+
+func (m *testMetrics) SyncItemsSent(items int) {}
+
+func (m *testMetrics) SyncItemsReceived(items int) {}
+
+func (m *testMetrics) RPCHandled(method string, code string, duration time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.rpcs[method] = code
+}
+
+var _ interfaces.Metrics = &testMetrics{}
+
+func TestMessageMetrics(t *testing.T) {
+	local := newSyncTestService()
+	remote := newSyncTestService()
+	metrics := newTestMetrics()
+	remote.RegisterMetrics(metrics)
+	channelID := []byte(assetPair)
+	storeTestChannel(t, local, channelID, testDifficulty)
+	storeTestChannel(t, remote, channelID, testDifficulty)
+
+	order := createSyncTestOrder(t, local, channelID)
+	_, signerPublicKey, err := identity.GetIdentity(local.Storage)
+	assert.NoError(t, err)
+	signerID, err := peer.IDFromPublicKey(signerPublicKey)
+	assert.NoError(t, err)
+
+	// Rejections are counted by reason, whether they happen when validating or receiving
+	unstampedOrder := *order
+	unstampedOrder.Stamp = 0
+	unstamped := marshalWireMessage(t, channelID, pb.Operation_CREATE, &unstampedOrder)
+	assert.Error(t, remote.Validate(channelID, unstamped, signerID))
+	assert.Error(t, remote.Receive(unstamped, signerID))
+	assert.Equal(t, 2, metrics.rejected[rejectedWork])
+	outsider := marshalWireMessage(t, []byte("otherChannel"), pb.Operation_CREATE, order)
+	assert.Error(t, remote.Validate(channelID, outsider, signerID))
+	assert.Equal(t, 1, metrics.rejected[rejectedInvalid])
+
+	data, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	newer, err := proto.Marshal(&pb.WireMessage{ChannelID: channelID, Operation: pb.Operation_CREATE, Data: data, Version: interfaces.WireVersion + 1})
+	assert.NoError(t, err)
+	assert.Error(t, remote.Receive(newer, signerID))
+	assert.Equal(t, 1, metrics.rejected[rejectedVersion])
+
+	assert.NoError(t, remote.Receive(marshalWireMessage(t, channelID, pb.Operation_CREATE, order), signerID))
+	assert.Equal(t, 2, metrics.received[pb.Operation_CREATE])
+	assert.Len(t, metrics.rejected, 3)
+
+	counts, err := remote.CountOrders()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[pb.State]int{assetPair: {pb.State_OPEN: 1}}, counts)
+}
+
+func TestSyncMetrics(t *testing.T) {
+	local := newSyncTestService()
+	remote := newSyncTestService()
+	metrics := newTestMetrics()
+	local.RegisterMetrics(metrics)
+	channelID := []byte(assetPair)
+	_, publicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	from, err := peer.IDFromPublicKey(publicKey)
+	assert.NoError(t, err)
+
+	// A sync is complete once the peer's update has been applied
+	order := createSyncTestOrder(t, remote, channelID)
+	local.startSync(channelID, from)
+	update, err := proto.Marshal(&pb.SyncUpdate{Orders: []*pb.Order{order}})
+	assert.NoError(t, err)
+	assert.NoError(t, local.receiveSyncUpdate(channelID, update, from))
+	assert.Equal(t, 1, metrics.syncs)
+
+	// Or once the peer's fingerprints show there's nothing to repair
+	fingerprints, err := remote.getFingerprints(channelID)
+	assert.NoError(t, err)
+	marshaledFingerprints, err := proto.Marshal(fingerprints)
+	assert.NoError(t, err)
+	assert.NoError(t, local.receiveFingerprints(channelID, marshaledFingerprints, from))
+	assert.Equal(t, 2, metrics.syncs)
+
+	// Updates that answer our own, with no fingerprints received before them, aren't syncs of their own
+	assert.NoError(t, local.receiveSyncUpdate(channelID, update, from))
+	assert.Equal(t, 2, metrics.syncs)
+	assert.Empty(t, local.syncStarted)
+}
+
+func TestRPCMetrics(t *testing.T) {
+	server := &Server{Orders: newSyncTestService(), APIKeys: newAPIKeyTestService()}
+	metrics := newTestMetrics()
+	server.RegisterMetrics(metrics)
+	createTestAPIKey(t, server.APIKeys, pb.APIKeyRole_ADMIN)
+
+	// Calls are timed with the status they end in, including failing to authorize
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return &pb.Empty{}, nil }
+	_, err := server.observeUnary(context.Background(), &pb.Empty{}, &grpc.UnaryServerInfo{FullMethod: "/pb.ChannelHandler/GetAllChannels"}, handler)
+	assert.Error(t, err)
+	assert.Equal(t, "Unauthenticated", metrics.rpcs["/pb.ChannelHandler/GetAllChannels"])
+
+	server.APIToken = serverTestToken
+	_, err = server.observeUnary(withBearerToken(serverTestToken), &pb.Empty{}, &grpc.UnaryServerInfo{FullMethod: "/pb.ChannelHandler/GetAllChannels"}, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "OK", metrics.rpcs["/pb.ChannelHandler/GetAllChannels"])
+}
+
+func TestOrderCounts(t *testing.T) {
+	orderService := newSyncTestService()
+	channelID := []byte(assetPair)
+	storeTestChannel(t, orderService, channelID, 0)
+
+	// Orders stored before the first count are read from storage
+	order := createSyncTestOrder(t, orderService, channelID)
+	counts, err := orderService.CountOrders()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[pb.State]int{assetPair: {pb.State_OPEN: 1}}, counts)
+
+	// After that the counts follow the changes without reading the orders again
+	other := createSyncTestOrder(t, orderService, channelID)
+	_, err = orderService.Lock(ctx, &pb.OrderSpecificRequest{OrderID: order.GetId(), ChannelID: channelID})
+	assert.NoError(t, err)
+	counts, err = orderService.CountOrders()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[pb.State]int{assetPair: {pb.State_OPEN: 1, pb.State_LOCKED: 1}}, counts)
+
+	_, err = orderService.Delete(ctx, &pb.OrderSpecificRequest{OrderID: other.GetId(), ChannelID: channelID})
+	assert.NoError(t, err)
+	// Removed without an event, so it's still counted
+	assert.NoError(t, orderService.Storage.Delete(getOrderStorageKey(channelID, order.GetId())))
+	counts, err = orderService.CountOrders()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[pb.State]int{assetPair: {pb.State_LOCKED: 1}}, counts)
+}
@@ -2,14 +2,37 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"sync"
 
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
 	"github.com/sprawl/sprawl/interfaces"
 	"github.com/sprawl/sprawl/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+const directMessageBufferSize = 64
+
 // NodeService is a gRPC service for p2p operations.
 type NodeService struct {
-	P2p interfaces.P2p
+	Logger                   interfaces.Logger
+	Storage                  interfaces.Storage
+	P2p                      interfaces.P2p
+	limiter                  interfaces.Limiter
+	directMessageSubscribers map[chan *pb.DirectMessage]struct{}
+	subscriberLock           sync.RWMutex
+}
+
+// RegisterStorage registers a storage service to read node statistics from
+func (s *NodeService) RegisterStorage(storage interfaces.Storage) {
+	s.Storage = storage
 }
 
 // RegisterP2p registers a p2p interface with NodeService
@@ -17,10 +40,15 @@ func (s *NodeService) RegisterP2p(p2p interfaces.P2p) {
 	s.P2p = p2p
 }
 
+// RegisterLimiter registers the limiter whose offenders NodeService reports
+func (s *NodeService) RegisterLimiter(limiter interfaces.Limiter) {
+	s.limiter = limiter
+}
+
 // GetAllPeers fetches all connected peers from NodeService.P2p
 func (s *NodeService) GetAllPeers(ctx context.Context, in *pb.Empty) (*pb.PeerListResponse, error) {
 	peerIDs := s.P2p.GetAllPeers()
-	data := make([]string, len(peerIDs))
+	data := make([]string, 0, len(peerIDs))
 	for _, peerID := range peerIDs {
 		data = append(data, peerID.String())
 	}
@@ -33,3 +61,207 @@ func (s *NodeService) BlacklistPeer(ctx context.Context, in *pb.Peer) (*pb.Empty
 	s.P2p.BlacklistPeer(in)
 	return &pb.Empty{}, nil
 }
+
+// ConnectPeer connects directly to a peer with a multiaddress, protected peers are redialed on disconnect
+func (s *NodeService) ConnectPeer(ctx context.Context, in *pb.ConnectRequest) (*pb.Empty, error) {
+	addr, err := ma.NewMultiaddr(in.GetAddress())
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Parse multiaddress in ConnectPeer"), err))
+	}
+
+	err = s.P2p.ConnectPeer(addr, in.GetProtected())
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Unavailable, "%s", errors.E(errors.Op("Connect peer"), err))
+	}
+
+	return &pb.Empty{}, nil
+}
+
+// DisconnectPeer disconnects from a peer and stops redialing it
+func (s *NodeService) DisconnectPeer(ctx context.Context, in *pb.Peer) (*pb.Empty, error) {
+	peerID, err := peer.IDB58Decode(in.GetId())
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Decode peer ID in DisconnectPeer"), err))
+	}
+
+	err = s.P2p.DisconnectPeer(peerID)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Disconnect peer"), err))
+	}
+
+	return &pb.Empty{}, nil
+}
+
+// GetProtectedPeers fetches the addresses of all peers this node always keeps connected to
+func (s *NodeService) GetProtectedPeers(ctx context.Context, in *pb.Empty) (*pb.PeerAddressList, error) {
+	addrs := s.P2p.GetProtectedPeers()
+	addresses := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		addresses = append(addresses, addr.String())
+	}
+	return &pb.PeerAddressList{Addresses: addresses}, nil
+}
+
+// SendDirectMessage encrypts a payload to the recipient's public key and sends it over a direct stream
+func (s *NodeService) SendDirectMessage(ctx context.Context, in *pb.DirectMessageRequest) (*pb.Empty, error) {
+	peerID, err := peer.IDB58Decode(in.GetRecipient().GetId())
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Decode peer ID in SendDirectMessage"), err))
+	}
+
+	publicKey, err := peerID.ExtractPublicKey()
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Extract recipient public key"), err))
+	}
+
+	box, err := identity.EncryptTo(publicKey, rand.Reader, in.GetPayload())
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Encrypt direct message"), err))
+	}
+
+	wireMessage := &pb.WireMessage{Operation: pb.Operation_DIRECT_MESSAGE, Data: box, Version: interfaces.WireVersion}
+	marshaledData, err := proto.Marshal(wireMessage)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Marshal direct message"), err))
+	}
+
+	stream, err := s.P2p.OpenStream(peerID)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Unavailable, "%s", errors.E(errors.Op("Open a direct message stream"), err))
+	}
+	err = stream.WriteToStream(marshaledData)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Unavailable, "%s", errors.E(errors.Op("Write direct message to stream"), err))
+	}
+
+	return &pb.Empty{}, nil
+}
+
+// ReceiveDirectMessages streams all direct messages this node receives to the client until it disconnects
+func (s *NodeService) ReceiveDirectMessages(in *pb.Empty, stream pb.NodeHandler_ReceiveDirectMessagesServer) error {
+	messages := s.subscribeDirectMessages()
+	defer s.unsubscribeDirectMessages(messages)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case message := <-messages:
+			err := stream.Send(message)
+			if !errors.IsEmpty(err) {
+				return status.Errorf(codes.Unavailable, "%s", errors.E(errors.Op("Send direct message to client"), err))
+			}
+		}
+	}
+}
+
+// ReceiveDirectMessage decrypts a direct message another peer sent to this node and passes it to the subscribed clients.
+// The sender is the authenticated remote peer of the stream the message arrived in.
+func (s *NodeService) ReceiveDirectMessage(data []byte, from peer.ID) error {
+	privateKey, _, err := identity.GetIdentity(s.Storage)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get private key in ReceiveDirectMessage"), err)
+	}
+
+	payload, err := identity.DecryptWith(privateKey, data)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Decrypt direct message"), err)
+	}
+
+	message := &pb.DirectMessage{From: from.String(), Payload: payload, Received: ptypes.TimestampNow()}
+
+	s.subscriberLock.RLock()
+	defer s.subscriberLock.RUnlock()
+	if len(s.directMessageSubscribers) == 0 {
+		s.logWarnf("No clients subscribed to direct messages, dropping message from %s", from)
+	}
+	for messages := range s.directMessageSubscribers {
+		select {
+		case messages <- message:
+		default:
+			s.logWarnf("Direct message subscriber is full, dropping message from %s", from)
+		}
+	}
+	return nil
+}
+
+func (s *NodeService) subscribeDirectMessages() chan *pb.DirectMessage {
+	messages := make(chan *pb.DirectMessage, directMessageBufferSize)
+	s.subscriberLock.Lock()
+	if s.directMessageSubscribers == nil {
+		s.directMessageSubscribers = make(map[chan *pb.DirectMessage]struct{})
+	}
+	s.directMessageSubscribers[messages] = struct{}{}
+	s.subscriberLock.Unlock()
+	return messages
+}
+
+func (s *NodeService) unsubscribeDirectMessages(messages chan *pb.DirectMessage) {
+	s.subscriberLock.Lock()
+	delete(s.directMessageSubscribers, messages)
+	s.subscriberLock.Unlock()
+}
+
+func (s *NodeService) logWarnf(format string, args ...interface{}) {
+	if s.Logger != nil {
+		s.Logger.Warnf(format, args...)
+	}
+}
+
+// GetNodeInfo returns this node's identity, addresses, joined topics, uptime and storage statistics
+func (s *NodeService) GetNodeInfo(ctx context.Context, in *pb.Empty) (*pb.NodeInfo, error) {
+	addrs := s.P2p.GetAddrs()
+	addresses := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		addresses = append(addresses, addr.String())
+	}
+
+	storageStats, err := s.getStorageStats()
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Get storage stats in GetNodeInfo"), err))
+	}
+
+	return &pb.NodeInfo{
+		Id:              s.P2p.GetHostIDString(),
+		Addresses:       addresses,
+		ProtocolVersion: s.P2p.GetProtocolVersion(),
+		Capabilities:    s.P2p.GetCapabilities(),
+		Topics:          s.P2p.GetTopics(),
+		Uptime:          uint64(s.P2p.GetUptime().Seconds()),
+		Storage:         storageStats,
+	}, nil
+}
+
+func (s *NodeService) getStorageStats() (*pb.StorageStats, error) {
+	storageStats := &pb.StorageStats{}
+	if s.Storage == nil {
+		return storageStats, nil
+	}
+
+	// Only the keys are counted, the entries aren't read
+	entries, err := s.Storage.CountWithPrefix("")
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Count all entries"), err)
+	}
+	orders, err := s.Storage.CountWithPrefix(string(interfaces.OrderPrefix))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Count all orders"), err)
+	}
+	channels, err := s.Storage.CountWithPrefix(string(interfaces.ChannelPrefix))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Count all channels"), err)
+	}
+
+	storageStats.Entries = uint64(entries)
+	storageStats.Orders = uint64(orders)
+	storageStats.Channels = uint64(channels)
+	return storageStats, nil
+}
+
+// GetRateLimitOffenders returns the peers that have exceeded the message or open order limits on any channel
+func (s *NodeService) GetRateLimitOffenders(ctx context.Context, in *pb.Empty) (*pb.OffenderList, error) {
+	if s.limiter == nil {
+		return &pb.OffenderList{Offenders: []*pb.Offender{}}, nil
+	}
+	return &pb.OffenderList{Offenders: s.limiter.GetOffenders()}, nil
+}
@@ -2,13 +2,19 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
 	"testing"
+	"time"
 
 	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
 	"github.com/sprawl/sprawl/interfaces"
 	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
 )
 
+const testPayload = "Settle to this address"
+
 func TestNodeService(t *testing.T) {
 	createNewServerInstance()
 	defer p2pInstance.Close()
@@ -16,6 +22,7 @@ func TestNodeService(t *testing.T) {
 	defer conn.Close()
 
 	var nodeService interfaces.NodeService = &NodeService{}
+	nodeService.RegisterStorage(storage)
 	nodeService.RegisterP2p(p2pInstance)
 	pb.RegisterNodeHandlerServer(s, nodeService)
 
@@ -33,4 +40,67 @@ func TestNodeService(t *testing.T) {
 	} else {
 		nodeClient.BlacklistPeer(context.Background(), &pb.Peer{Id: "Testi"})
 	}
+
+	nodeInfo, err := nodeClient.GetNodeInfo(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, p2pInstance.GetHostIDString(), nodeInfo.GetId())
+	assert.Equal(t, p2pInstance.GetProtocolVersion(), nodeInfo.GetProtocolVersion())
+	assert.NotNil(t, nodeInfo.GetStorage())
+
+	_, err = nodeClient.ConnectPeer(context.Background(), &pb.ConnectRequest{Address: "not a multiaddress", Protected: true})
+	assert.Error(t, err)
+	_, err = nodeClient.DisconnectPeer(context.Background(), &pb.Peer{Id: "not a peer ID"})
+	assert.Error(t, err)
+
+	protectedPeers, err := nodeClient.GetProtectedPeers(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Empty(t, protectedPeers.GetAddresses())
+}
+
+func TestDirectMessages(t *testing.T) {
+	createNewServerInstance()
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+
+	nodeService := &NodeService{Logger: log}
+	nodeService.RegisterStorage(storage)
+	nodeService.RegisterP2p(p2pInstance)
+	pb.RegisterNodeHandlerServer(s, nodeService)
+
+	go func() {
+		if err := s.Serve(lis); !errors.IsEmpty(err) {
+			log.Fatalf("Server exited with error: %v", err)
+		}
+		defer s.Stop()
+	}()
+
+	nodeClient := pb.NewNodeHandlerClient(conn)
+	_, err := nodeClient.SendDirectMessage(ctx, &pb.DirectMessageRequest{Recipient: &pb.Peer{Id: "not a peer ID"}, Payload: []byte(testPayload)})
+	assert.Error(t, err)
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := nodeClient.ReceiveDirectMessages(streamCtx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		nodeService.subscriberLock.RLock()
+		defer nodeService.subscriberLock.RUnlock()
+		return len(nodeService.directMessageSubscribers) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// Messages are encrypted to the identity kept in storage
+	_, publicKey, err := identity.GetIdentity(storage)
+	box, err := identity.EncryptTo(publicKey, rand.Reader, []byte(testPayload))
+	assert.NoError(t, err)
+
+	err = nodeService.ReceiveDirectMessage(box, p2pInstance.GetHostID())
+	assert.NoError(t, err)
+	err = nodeService.ReceiveDirectMessage([]byte(testPayload), p2pInstance.GetHostID())
+	assert.Error(t, err)
+
+	message, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, testPayload, string(message.GetPayload()))
+	assert.Equal(t, p2pInstance.GetHostIDString(), message.GetFrom())
 }
@@ -3,8 +3,12 @@ package service
 import (
 	"context"
 	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
+	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	ptypes "github.com/golang/protobuf/ptypes"
@@ -18,10 +22,23 @@ import (
 
 // OrderService implements the OrderService Server service.proto
 type OrderService struct {
-	Logger    interfaces.Logger
-	Storage   interfaces.Storage
-	P2p       interfaces.P2p
-	websocket interfaces.WebsocketService
+	Logger         interfaces.Logger
+	Storage        interfaces.Storage
+	P2p            interfaces.P2p
+	websocket      interfaces.WebsocketService
+	directMessages interfaces.DirectMessageReceiver
+	activity       map[string]*pb.ChannelStats
+	syncResults    map[string]*pb.SyncStatus
+	syncStarted    map[string]time.Time
+	limiter        interfaces.Limiter
+	metrics        interfaces.Metrics
+	activityLock   sync.RWMutex
+	events         *eventLog
+	eventsOnce     sync.Once
+	orderCounts    orderCounts
+	quoteLock      sync.Mutex
+	// MaxQuoteTTL is the longest quote requests and quotes can be valid for, defaultMaxQuoteTTL if zero
+	MaxQuoteTTL time.Duration
 }
 
 func getOrderStorageKey(channelID []byte, orderID []byte) []byte {
@@ -37,6 +54,16 @@ func (s *OrderService) RegisterWebsocket(websocket interfaces.WebsocketService)
 	s.websocket = websocket
 }
 
+// RegisterDirectMessageReceiver registers a receiver for the direct messages other peers send to this node
+func (s *OrderService) RegisterDirectMessageReceiver(receiver interfaces.DirectMessageReceiver) {
+	s.directMessages = receiver
+}
+
+// RegisterLimiter registers a limiter for the messages and open orders other peers send on channels
+func (s *OrderService) RegisterLimiter(limiter interfaces.Limiter) {
+	s.limiter = limiter
+}
+
 // RegisterStorage registers a storage service to store the Orders in
 func (s *OrderService) RegisterStorage(storage interfaces.Storage) {
 	s.Storage = storage
@@ -53,6 +80,8 @@ func (s *OrderService) GetSignature(order *pb.Order) ([]byte, error) {
 	orderCopy.State = pb.State_OPEN
 	orderCopy.Signature = nil
 	orderCopy.Nonce = 0
+	orderCopy.Stamp = 0
+	orderCopy.StateSignature = nil
 	orderInBytes, err := proto.Marshal(&orderCopy)
 	if !errors.IsEmpty(err) {
 		return nil, errors.E(errors.Op("Marshal order in GetSignature"), err)
@@ -61,6 +90,25 @@ func (s *OrderService) GetSignature(order *pb.Order) ([]byte, error) {
 	return identity.Sign(s.Storage, orderInBytes)
 }
 
+// getStateData returns what an order's creator signs to put the order in a state, or to delete it
+func getStateData(orderID []byte, state pb.State, nonce uint32, deleted bool) ([]byte, error) {
+	return proto.Marshal(&pb.OrderState{Id: orderID, State: state, Nonce: nonce, Deleted: deleted})
+}
+
+// signState signs the order's current state, or its deletion, so peers that sync the order from others can tell
+// that its creator changed it
+func (s *OrderService) signState(order *pb.Order, deleted bool) error {
+	data, err := getStateData(order.GetId(), order.GetState(), order.GetNonce(), deleted)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal order state"), err)
+	}
+	order.StateSignature, err = identity.Sign(s.Storage, data)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Sign order state"), err)
+	}
+	return nil
+}
+
 // VerifyOrder verifies order
 func (s *OrderService) VerifyOrder(publicKey crypto.PubKey, order *pb.Order) (bool, error) {
 	orderCopy := *order
@@ -68,6 +116,8 @@ func (s *OrderService) VerifyOrder(publicKey crypto.PubKey, order *pb.Order) (bo
 	orderCopy.Signature = nil
 	orderCopy.State = pb.State_OPEN
 	orderCopy.Nonce = 0
+	orderCopy.Stamp = 0
+	orderCopy.StateSignature = nil
 	orderInBytes, err := proto.Marshal(&orderCopy)
 	if !errors.IsEmpty(err) {
 		return false, errors.E(errors.Op("Marshal order in VerifyOrder"), err)
@@ -90,6 +140,10 @@ func (s *OrderService) Create(ctx context.Context, in *pb.CreateRequest) (*pb.Cr
 	if !errors.IsEmpty(err) {
 		errors.E(errors.Op("Turn public key into bytes"), err)
 	}
+	creator, err := crypto.MarshalPublicKey(publicKey)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal creator public key"), err)
+	}
 
 	// Create a new HMAC by defining the hash type and the key (as byte array)
 	h := hmac.New(sha256.New, secret)
@@ -110,6 +164,7 @@ func (s *OrderService) Create(ctx context.Context, in *pb.CreateRequest) (*pb.Cr
 		Price:        in.Price,
 		State:        pb.State_OPEN, //Mutable
 		Nonce:        0,             //Mutable
+		Creator:      creator,
 	}
 
 	sig, err := s.GetSignature(order)
@@ -120,21 +175,49 @@ func (s *OrderService) Create(ctx context.Context, in *pb.CreateRequest) (*pb.Cr
 	}
 
 	order.Signature = sig
+	err = s.signState(order, false)
+	if !errors.IsEmpty(err) {
+		return &pb.CreateResponse{
+			CreatedOrder: order,
+		}, err
+	}
+
+	// Do the work the channel asks for, to make flooding it with orders expensive
+	err = s.stampOrder(ctx, in.GetChannelID(), order)
+	if !errors.IsEmpty(err) {
+		return &pb.CreateResponse{
+			CreatedOrder: order,
+		}, errors.E(errors.Op("Stamp order"), err)
+	}
 
 	// Get order as bytes
 	orderInBytes, err := proto.Marshal(order)
 	if !errors.IsEmpty(err) {
-		s.Logger.Warn(errors.E(errors.Op("Marshal order"), err))
+		return &pb.CreateResponse{
+			CreatedOrder: order,
+		}, errors.E(errors.Op("Marshal order"), err)
 	}
 
-	// Save order to LevelDB locally
+	// Save order to LevelDB locally. An order that wasn't stored isn't announced anywhere.
 	err = s.Storage.Put(getOrderStorageKey(in.GetChannelID(), id), orderInBytes)
 	if !errors.IsEmpty(err) {
-		err = errors.E(errors.Op("Put order"), err)
+		return &pb.CreateResponse{
+			CreatedOrder: order,
+		}, errors.E(errors.Op("Put order"), err)
+	}
+	s.publishEvent(pb.EventType_ORDER_CREATED, in.GetChannelID(), order, true)
+	s.pushToWebsockets(in.GetChannelID(), pb.Operation_CREATE, orderInBytes)
+
+	// Encrypt the order for channel members if the channel is private
+	wireData, err := s.sealForChannel(in.GetChannelID(), orderInBytes)
+	if !errors.IsEmpty(err) {
+		return &pb.CreateResponse{
+			CreatedOrder: order,
+		}, errors.E(errors.Op("Seal order for channel"), err)
 	}
 
 	// Construct the message to send to other peers
-	wireMessage := &pb.WireMessage{ChannelID: in.GetChannelID(), Operation: pb.Operation_CREATE, Data: orderInBytes}
+	wireMessage := &pb.WireMessage{ChannelID: in.GetChannelID(), Operation: pb.Operation_CREATE, Data: wireData}
 
 	if s.P2p != nil {
 		// Send the order creation by wire
@@ -145,18 +228,21 @@ func (s *OrderService) Create(ctx context.Context, in *pb.CreateRequest) (*pb.Cr
 
 	return &pb.CreateResponse{
 		CreatedOrder: order,
-	}, err
+	}, nil
 }
 
 // Receive receives a buffer from p2p and tries to unmarshal it into a struct
-func (s *OrderService) Receive(buf []byte, from peer.ID) error {
+func (s *OrderService) Receive(buf []byte, from peer.ID) (err error) {
 	wireMessage := &pb.WireMessage{}
-	err := proto.Unmarshal(buf, wireMessage)
+	err = proto.Unmarshal(buf, wireMessage)
 	if !errors.IsEmpty(err) {
+		s.recordRejection(rejectedInvalid)
 		return errors.E(errors.Op("Unmarshal wiremessage proto in Receive"), err)
 	}
-	if s.websocket != nil {
-		s.websocket.PushToWebsockets(wireMessage)
+
+	if wireMessage.GetVersion() > interfaces.WireVersion {
+		s.recordRejection(rejectedVersion)
+		return errors.E(errors.Op("Check wiremessage version in Receive"), fmt.Sprintf("unsupported wire version %d from %s, this node speaks %d", wireMessage.GetVersion(), from, interfaces.WireVersion))
 	}
 
 	// Read operation and data from the WireMessage
@@ -164,8 +250,46 @@ func (s *OrderService) Receive(buf []byte, from peer.ID) error {
 	data := wireMessage.GetData()
 	channelID := wireMessage.GetChannelID()
 
+	// Count every message on the channel, and whether it was rejected, once it has been handled
+	reason := rejectedInvalid
+	defer func() {
+		s.recordActivity(channelID, op, err)
+		s.recordMessage(op, reason, err)
+	}()
+
+	// Drop messages from peers flooding the channel before they're stored
+	if s.limiter != nil && len(channelID) > 0 && !s.limiter.Allow(channelID, from) {
+		reason = rejectedRateLimit
+		return errors.E(errors.Op("Check rate limit in Receive"), fmt.Sprintf("%s exceeds the message rate limit on channel %s", from, channelID))
+	}
+
 	s.Logger.Debugf("%s: %s.%s", from.String(), channelID, op)
 
+	switch op {
+	case pb.Operation_CHANNEL_INVITE:
+		return s.acceptInvite(channelID, data, from)
+	case pb.Operation_DIRECT_MESSAGE:
+		if s.directMessages == nil {
+			s.Logger.Warn("Direct message receiver not registered with OrderService, dropping direct message!")
+			return nil
+		}
+		return s.directMessages.ReceiveDirectMessage(data, from)
+	}
+
+	// Drop messages from non-members of private channels and decrypt the rest
+	if s.Storage != nil {
+		data, err = s.openFromChannel(channelID, data, from)
+		if !errors.IsEmpty(err) {
+			reason = rejectedMembership
+			return errors.E(errors.Op("Open channel data in Receive"), err)
+		}
+		wireMessage.Data = data
+	}
+
+	if s.websocket != nil {
+		s.websocket.PushToWebsockets(wireMessage)
+	}
+
 	if s.Storage != nil {
 		switch op {
 
@@ -183,14 +307,28 @@ func (s *OrderService) Receive(buf []byte, from peer.ID) error {
 			}
 			isCreator, err := s.VerifyOrder(publickey, order)
 			if !errors.IsEmpty(err) {
+				reason = rejectedSignature
 				return errors.E(errors.Op("Verify order creator in Receive"), err)
 			}
 			if isCreator {
+				err = s.checkWork(channelID, order)
+				if !errors.IsEmpty(err) {
+					reason = rejectedWork
+					return errors.E(errors.Op("Check order proof-of-work in Receive"), err)
+				}
+				if s.limiter != nil && !s.limiter.ReserveOrder(channelID, from, order.GetId()) {
+					reason = rejectedOrderLimit
+					return errors.E(errors.Op("Check open order limit in Receive"), fmt.Sprintf("%s has too many open orders on channel %s", from, channelID))
+				}
 				// Save order to LevelDB locally
 				err = s.Storage.Put(getOrderStorageKey(channelID, order.GetId()), data)
 				if !errors.IsEmpty(err) {
-					err = errors.E(errors.Op("Put order"), err)
+					if s.limiter != nil {
+						s.limiter.ReleaseOrder(channelID, from, order.GetId())
+					}
+					return errors.E(errors.Op("Put order"), err)
 				}
+				s.publishEvent(pb.EventType_ORDER_CREATED, channelID, order, false)
 			} else {
 				s.Logger.Debug("Received create request from someone that doesn't own the order")
 			}
@@ -209,6 +347,7 @@ func (s *OrderService) Receive(buf []byte, from peer.ID) error {
 
 			isCreator, err := s.VerifyOrder(publickey, order)
 			if !errors.IsEmpty(err) {
+				reason = rejectedSignature
 				return errors.E(errors.Op("Verify order creator in Receive"), err)
 			}
 			if isCreator {
@@ -216,66 +355,34 @@ func (s *OrderService) Receive(buf []byte, from peer.ID) error {
 				if !errors.IsEmpty(err) {
 					return errors.E(errors.Op("Delete order"), err)
 				}
+				err = s.storeTombstone(channelID, order)
+				if !errors.IsEmpty(err) {
+					return errors.E(errors.Op("Store tombstone"), err)
+				}
+				if s.limiter != nil {
+					s.limiter.ReleaseOrder(channelID, from, order.GetId())
+				}
+				s.publishEvent(pb.EventType_ORDER_DELETED, channelID, order, false)
 			} else {
 				s.Logger.Debug("Received delete request from someone that doesn't own the order")
 			}
 
 		case pb.Operation_SYNC_REQUEST:
-			orders, err := s.Storage.GetAllWithPrefix(string(getOrderQueryPrefix(channelID)))
-			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Fetch orders for sync"), err)
-			}
-
-			orderList := &pb.OrderList{}
-			for _, value := range orders {
-				order := &pb.Order{}
-				proto.Unmarshal([]byte(value), order)
-				orderList.Orders = append(orderList.Orders, order)
-			}
-
-			marshaledOrderList, err := proto.Marshal(orderList)
-			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Marshal orderList in sync request"), err)
-			}
-
-			syncMessage := &pb.WireMessage{Operation: pb.Operation_SYNC_RECEIVE, ChannelID: channelID, Data: marshaledOrderList}
-
-			marshaledData, err := proto.Marshal(syncMessage)
-			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Marshal wireMessage in sync request"), err)
+			if s.P2p != nil && s.P2p.IsLegacyPeer(from) {
+				err = s.sendAllOrders(channelID, from)
+			} else {
+				err = s.sendFingerprints(channelID, from)
 			}
 
-			stream, err := s.P2p.OpenStream(from)
-			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Open a sync request stream"), err)
-			}
+		case pb.Operation_SYNC_FINGERPRINTS:
+			err = s.receiveFingerprints(channelID, data, from)
 
-			err = stream.WriteToStream(marshaledData)
-			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Write to stream"), err)
-			}
-			err = s.P2p.CloseStream(from)
-			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Close the stream"), err)
-			}
+		case pb.Operation_SYNC_DIGEST:
+			err = s.receiveDigest(channelID, data, from)
 
 		case pb.Operation_SYNC_RECEIVE:
-			orderList := &pb.OrderList{}
-			err = proto.Unmarshal(data, orderList)
-			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Unmarshal order proto in Receive"), err)
-			}
-			s.Logger.Info(orderList)
-			for _, order := range orderList.GetOrders() {
-				orderBytes, err := proto.Marshal(order)
-				if !errors.IsEmpty(err) {
-					err = errors.E(errors.Op("Marshal order from received orderList"), err)
-				}
-				err = s.Storage.Put(getOrderStorageKey(channelID, order.GetId()), orderBytes)
-				if !errors.IsEmpty(err) {
-					err = errors.E(errors.Op("Put order"), err)
-				}
-			}
+			err = s.receiveSyncUpdate(channelID, data, from)
+
 		case pb.Operation_LOCK, pb.Operation_UNLOCK:
 			// Unmarshal order to get its key, validate
 			order := &pb.Order{}
@@ -291,6 +398,7 @@ func (s *OrderService) Receive(buf []byte, from peer.ID) error {
 			previousOrder := &pb.Order{}
 			proto.Unmarshal(previousOrderData, previousOrder)
 			if previousOrder.Nonce >= order.Nonce {
+				reason = rejectedReplay
 				return errors.E(errors.Op("Compare nonces"), "received order state is behind current status")
 			}
 
@@ -301,6 +409,7 @@ func (s *OrderService) Receive(buf []byte, from peer.ID) error {
 
 			isCreator, err := s.VerifyOrder(publickey, order)
 			if !errors.IsEmpty(err) {
+				reason = rejectedSignature
 				return errors.E(errors.Op("Verify order creator in Receive"), err)
 			}
 
@@ -310,10 +419,24 @@ func (s *OrderService) Receive(buf []byte, from peer.ID) error {
 				if !errors.IsEmpty(err) {
 					return errors.E(errors.Op("Store lock/unlock order"), err)
 				}
+				if op == pb.Operation_LOCK {
+					s.publishEvent(pb.EventType_ORDER_LOCKED, channelID, order, false)
+				} else {
+					s.publishEvent(pb.EventType_ORDER_UNLOCKED, channelID, order, false)
+				}
 			} else {
 				s.Logger.Debug("Received delete request from someone that doesn't own the order")
 			}
 
+		case pb.Operation_QUOTE_REQUEST:
+			err = s.receiveQuoteRequest(channelID, data, from)
+
+		case pb.Operation_QUOTE:
+			err = s.receiveQuote(channelID, data, from)
+
+		case pb.Operation_QUOTE_ACCEPT:
+			err = s.receiveQuoteAccept(channelID, data, from)
+
 		}
 	} else {
 		s.Logger.Warn("Storage not registered with OrderService, not persisting Orders!")
@@ -322,6 +445,151 @@ func (s *OrderService) Receive(buf []byte, from peer.ID) error {
 	return err
 }
 
+// pushToWebsockets shows this node's own operations on the websocket feed, unencrypted like the ones received from peers
+func (s *OrderService) pushToWebsockets(channelID []byte, op pb.Operation, data []byte) {
+	if s.websocket != nil {
+		s.websocket.PushToWebsockets(&pb.WireMessage{ChannelID: channelID, Operation: op, Data: data, Version: interfaces.WireVersion})
+	}
+}
+
+// sealForChannel encrypts data with the channel's shared key if the channel is private
+func (s *OrderService) sealForChannel(channelID []byte, data []byte) ([]byte, error) {
+	channel, err := getChannel(s.Storage, channelID)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get channel"), err)
+	}
+	if channel == nil || !isPrivate(channel) {
+		return data, nil
+	}
+	return identity.Encrypt(channel.GetOptions().GetKey(), rand.Reader, data)
+}
+
+// openFromChannel rejects data from peers that aren't members of a private channel and decrypts the rest
+func (s *OrderService) openFromChannel(channelID []byte, data []byte, from peer.ID) ([]byte, error) {
+	channel, err := getChannel(s.Storage, channelID)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get channel"), err)
+	}
+	if channel == nil || !isPrivate(channel) {
+		return data, nil
+	}
+	if !isMember(channel, from) {
+		return nil, errors.E(errors.Op("Check channel membership"), fmt.Sprintf("%s is not a member of private channel %s", from, channelID))
+	}
+	if len(data) == 0 {
+		return data, nil
+	}
+	return identity.Decrypt(channel.GetOptions().GetKey(), data)
+}
+
+// recordActivity updates the message counters of a channel after a message on it has been handled
+func (s *OrderService) recordActivity(channelID []byte, op pb.Operation, err error) {
+	if len(channelID) == 0 {
+		return
+	}
+
+	s.activityLock.Lock()
+	defer s.activityLock.Unlock()
+	if s.activity == nil {
+		s.activity = make(map[string]*pb.ChannelStats)
+	}
+	stats, ok := s.activity[string(channelID)]
+	if !ok {
+		stats = &pb.ChannelStats{Id: channelID}
+		s.activity[string(channelID)] = stats
+	}
+
+	now := ptypes.TimestampNow()
+	stats.MessagesReceived++
+	stats.LastActivity = now
+	if !errors.IsEmpty(err) {
+		stats.MessagesRejected++
+	} else if op == pb.Operation_SYNC_RECEIVE || op == pb.Operation_SYNC_FINGERPRINTS {
+		stats.LastSync = now
+	}
+}
+
+// GetChannelActivity returns the message counters of a channel, which are empty if nothing has been received on it
+func (s *OrderService) GetChannelActivity(channelID []byte) *pb.ChannelStats {
+	s.activityLock.RLock()
+	defer s.activityLock.RUnlock()
+	stats, ok := s.activity[string(channelID)]
+	if !ok {
+		return &pb.ChannelStats{Id: channelID}
+	}
+	statsCopy := *stats
+	return &statsCopy
+}
+
+// sendToPeer sends a WireMessage to a single peer over a direct stream
+func (s *OrderService) sendToPeer(peerID peer.ID, wireMessage *pb.WireMessage) error {
+	if s.P2p == nil {
+		return errors.E(errors.Op("Check P2p"), "P2p service not registered with OrderService")
+	}
+
+	wireMessage.Version = interfaces.WireVersion
+	marshaledData, err := proto.Marshal(wireMessage)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal wireMessage"), err)
+	}
+
+	stream, err := s.P2p.OpenStream(peerID)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Open a stream"), err)
+	}
+	err = stream.WriteToStream(marshaledData)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Write to stream"), err)
+	}
+	if s.metrics != nil {
+		s.metrics.MessageSent(wireMessage.GetOperation())
+	}
+	return nil
+}
+
+// acceptInvite joins a private channel that another member has invited this node to
+func (s *OrderService) acceptInvite(channelID []byte, data []byte, from peer.ID) error {
+	if s.Storage == nil || s.P2p == nil {
+		s.Logger.Warn("Storage or P2p not registered with OrderService, not accepting channel invites!")
+		return nil
+	}
+
+	channel := &pb.Channel{}
+	err := proto.Unmarshal(data, channel)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal channel in invite"), err)
+	}
+
+	if !isPrivate(channel) || string(channel.GetId()) != string(channelID) {
+		return errors.E(errors.Op("Check invite"), "invite is not for a private channel")
+	}
+	if !isMember(channel, from) || !isMember(channel, s.P2p.GetHostID()) {
+		return errors.E(errors.Op("Check invite"), fmt.Sprintf("invite from %s to a channel we're not both members of", from))
+	}
+
+	existingChannel, err := getChannel(s.Storage, channelID)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get channel"), err)
+	}
+	if existingChannel != nil {
+		s.Logger.Debugf("Already joined private channel %s", channelID)
+		return nil
+	}
+
+	err = s.Storage.Put(getChannelStorageKey(channelID), data)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Put invited channel"), err)
+	}
+
+	_, err = s.P2p.Subscribe(channel)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Subscribe to invited channel"), err)
+	}
+
+	s.Logger.Infof("Joined private channel %s on invite from %s", channelID, from)
+	return nil
+}
+
 // GetOrder fetches a single order from the database
 func (s *OrderService) GetOrder(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.Order, error) {
 	data, err := s.Storage.Get(getOrderStorageKey(in.GetChannelID(), in.GetOrderID()))
@@ -376,8 +644,26 @@ func (s *OrderService) Delete(ctx context.Context, in *pb.OrderSpecificRequest)
 		return nil, errors.E(errors.Op("Verify the order"), err)
 	}
 
+	// The deletion is signed, so the tombstone synced to other peers can't be forged
+	if isCreator {
+		err = s.signState(order, true)
+		if !errors.IsEmpty(err) {
+			return nil, err
+		}
+		orderInBytes, err = proto.Marshal(order)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Marshal order in Delete"), err)
+		}
+	}
+
+	// Encrypt the order for channel members if the channel is private
+	wireData, err := s.sealForChannel(in.GetChannelID(), orderInBytes)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Seal order for channel"), err)
+	}
+
 	// Construct the message to send to other peers
-	wireMessage := &pb.WireMessage{ChannelID: in.GetChannelID(), Operation: pb.Operation_DELETE, Data: orderInBytes}
+	wireMessage := &pb.WireMessage{ChannelID: in.GetChannelID(), Operation: pb.Operation_DELETE, Data: wireData}
 
 	if s.P2p != nil {
 		if isCreator {
@@ -394,6 +680,14 @@ func (s *OrderService) Delete(ctx context.Context, in *pb.OrderSpecificRequest)
 		return nil, errors.E(errors.Op("Delete order"), err)
 	}
 
+	// Remember the deletion so that sync doesn't bring the order back
+	err = s.storeTombstone(in.GetChannelID(), order)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Store tombstone"), err)
+	}
+	s.pushToWebsockets(in.GetChannelID(), pb.Operation_DELETE, orderInBytes)
+	s.publishEvent(pb.EventType_ORDER_DELETED, in.GetChannelID(), order, true)
+
 	return &pb.Empty{}, nil
 }
 
@@ -427,6 +721,12 @@ func (s *OrderService) Lock(ctx context.Context, in *pb.OrderSpecificRequest) (*
 
 	order.State = pb.State_LOCKED
 	order.Nonce++
+	if isCreator {
+		err = s.signState(order, false)
+		if !errors.IsEmpty(err) {
+			return nil, err
+		}
+	}
 
 	// Get order as bytes
 	orderInBytes, err = proto.Marshal(order)
@@ -434,8 +734,14 @@ func (s *OrderService) Lock(ctx context.Context, in *pb.OrderSpecificRequest) (*
 		s.Logger.Warn(errors.E(errors.Op("Marshal order"), err))
 	}
 
+	// Encrypt the order for channel members if the channel is private
+	wireData, err := s.sealForChannel(in.GetChannelID(), orderInBytes)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Seal order for channel"), err)
+	}
+
 	// Construct the message to send to other peers
-	wireMessage := &pb.WireMessage{ChannelID: in.GetChannelID(), Operation: pb.Operation_LOCK, Data: orderInBytes}
+	wireMessage := &pb.WireMessage{ChannelID: in.GetChannelID(), Operation: pb.Operation_LOCK, Data: wireData}
 
 	if s.P2p != nil {
 		if isCreator {
@@ -450,7 +756,10 @@ func (s *OrderService) Lock(ctx context.Context, in *pb.OrderSpecificRequest) (*
 	err = s.Storage.Put(getOrderStorageKey(in.GetChannelID(), in.GetOrderID()), orderInBytes)
 	if !errors.IsEmpty(err) {
 		err = errors.E(errors.Op("Put order"), err)
+	} else {
+		s.publishEvent(pb.EventType_ORDER_LOCKED, in.GetChannelID(), order, true)
 	}
+	s.pushToWebsockets(in.GetChannelID(), pb.Operation_LOCK, orderInBytes)
 
 	return &pb.Empty{}, nil
 }
@@ -486,6 +795,12 @@ func (s *OrderService) Unlock(ctx context.Context, in *pb.OrderSpecificRequest)
 
 	order.State = pb.State_OPEN
 	order.Nonce++
+	if isCreator {
+		err = s.signState(order, false)
+		if !errors.IsEmpty(err) {
+			return nil, err
+		}
+	}
 
 	// Get order as bytes
 	orderInBytes, err = proto.Marshal(order)
@@ -493,8 +808,14 @@ func (s *OrderService) Unlock(ctx context.Context, in *pb.OrderSpecificRequest)
 		s.Logger.Warn(errors.E(errors.Op("Marshal order"), err))
 	}
 
+	// Encrypt the order for channel members if the channel is private
+	wireData, err := s.sealForChannel(in.GetChannelID(), orderInBytes)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Seal order for channel"), err)
+	}
+
 	// Construct the message to send to other peers
-	wireMessage := &pb.WireMessage{ChannelID: in.GetChannelID(), Operation: pb.Operation_UNLOCK, Data: orderInBytes}
+	wireMessage := &pb.WireMessage{ChannelID: in.GetChannelID(), Operation: pb.Operation_UNLOCK, Data: wireData}
 
 	if s.P2p != nil {
 		if isCreator {
@@ -509,7 +830,10 @@ func (s *OrderService) Unlock(ctx context.Context, in *pb.OrderSpecificRequest)
 	err = s.Storage.Put(getOrderStorageKey(in.GetChannelID(), in.GetOrderID()), orderInBytes)
 	if !errors.IsEmpty(err) {
 		err = errors.E(errors.Op("Put order"), err)
+	} else {
+		s.publishEvent(pb.EventType_ORDER_UNLOCKED, in.GetChannelID(), order, true)
 	}
+	s.pushToWebsockets(in.GetChannelID(), pb.Operation_UNLOCK, orderInBytes)
 
 	return &pb.Empty{}, nil
 }
@@ -6,16 +6,20 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"net"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 	ptypes "github.com/golang/protobuf/ptypes"
+	peer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/sprawl/sprawl/config"
+	"github.com/sprawl/sprawl/database/inmemory"
 	"github.com/sprawl/sprawl/database/leveldb"
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/identity"
 	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/limits"
 	"github.com/sprawl/sprawl/p2p"
 	"github.com/sprawl/sprawl/pb"
 	"github.com/sprawl/sprawl/util"
@@ -40,7 +44,7 @@ var bufSize = 1024 * 1024
 var lis *bufconn.Listener
 var conn *grpc.ClientConn
 var err error
-var ctx context.Context
+var ctx = context.Background()
 var storage *leveldb.Storage = &leveldb.Storage{}
 var p2pInstance *p2p.P2p
 var websocketService *WebsocketService
@@ -193,6 +197,16 @@ func TestOrderReceive(t *testing.T) {
 	order, err := orderService.Create(ctx, &testOrder)
 	marshaledOrder, err := proto.Marshal(order)
 
+	// Our own order shows up on the websocket feed first
+	_, p, err := ws.ReadMessage()
+	assert.NoError(t, err)
+	localWireMessage := &pb.WireMessage{}
+	assert.NoError(t, proto.Unmarshal(p, localWireMessage))
+	assert.Equal(t, pb.Operation_CREATE, localWireMessage.GetOperation())
+	localOrder := &pb.Order{}
+	assert.NoError(t, proto.Unmarshal(localWireMessage.GetData(), localOrder))
+	assert.Equal(t, order.GetCreatedOrder().GetId(), localOrder.GetId())
+
 	err = orderService.Receive(marshaledOrder, p2pInstance.GetHostID())
 
 	wireMessage := &pb.WireMessage{}
@@ -201,7 +215,7 @@ func TestOrderReceive(t *testing.T) {
 	err = proto.Unmarshal(marshaledOrder, wireMessage)
 	assert.NoError(t, err)
 
-	_, p, err := ws.ReadMessage()
+	_, p, err = ws.ReadMessage()
 	assert.NoError(t, err)
 	testWireMessage2 := &pb.WireMessage{}
 	proto.Unmarshal(p, testWireMessage2)
@@ -321,3 +335,162 @@ func BenchmarkOrderReceive(b *testing.B) {
 		orderClient.GetOrder(ctx, &pb.OrderSpecificRequest{OrderID: order.GetCreatedOrder().GetId()})
 	}
 }
+
+func TestPrivateChannelReceive(t *testing.T) {
+	createNewServerInstance()
+	orderService.RegisterStorage(storage)
+	orderService.RegisterP2p(p2pInstance)
+	orderService.RegisterWebsocket(nil)
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+	removeAllOrders()
+
+	// Orders are signed with the identity in storage, which makes it a member of the channel
+	_, signerPublicKey, err := identity.GetIdentity(storage)
+	signerID, err := peer.IDFromPublicKey(signerPublicKey)
+	assert.NoError(t, err)
+
+	resp, err := channelService.Join(ctx, &pb.JoinRequest{Asset: asset1, CounterAsset: asset2, Type: pb.ChannelType_PRIVATE, Members: []string{signerID.String()}})
+	assert.NoError(t, err)
+	privateChannel, err := getChannel(storage, resp.GetJoinedChannel().GetId())
+	assert.NoError(t, err)
+	defer channelService.Leave(ctx, &pb.ChannelSpecificRequest{Id: privateChannel.GetId()})
+
+	created, err := orderService.Create(ctx, &pb.CreateRequest{ChannelID: privateChannel.GetId(), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	orderInBytes, err := proto.Marshal(created.GetCreatedOrder())
+	assert.NoError(t, err)
+	removeAllOrders()
+
+	encryptedOrder, err := identity.Encrypt(privateChannel.GetOptions().GetKey(), rand.Reader, orderInBytes)
+	assert.NoError(t, err)
+	wireMessage, err := proto.Marshal(&pb.WireMessage{ChannelID: privateChannel.GetId(), Operation: pb.Operation_CREATE, Data: encryptedOrder})
+	assert.NoError(t, err)
+
+	// Messages from peers outside the allowlist are dropped
+	_, outsiderPublicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	outsiderID, err := peer.IDFromPublicKey(outsiderPublicKey)
+	assert.NoError(t, err)
+	err = orderService.Receive(wireMessage, outsiderID)
+	assert.Error(t, err)
+
+	// Unencrypted messages from members are dropped too
+	plainWireMessage, err := proto.Marshal(&pb.WireMessage{ChannelID: privateChannel.GetId(), Operation: pb.Operation_CREATE, Data: orderInBytes})
+	assert.NoError(t, err)
+	err = orderService.Receive(plainWireMessage, signerID)
+	assert.Error(t, err)
+
+	err = orderService.Receive(wireMessage, signerID)
+	assert.NoError(t, err)
+	storedOrder, err := orderService.GetOrder(ctx, &pb.OrderSpecificRequest{OrderID: created.GetCreatedOrder().GetId(), ChannelID: privateChannel.GetId()})
+	assert.NoError(t, err)
+	assert.Equal(t, created.GetCreatedOrder().GetId(), storedOrder.GetId())
+}
+
+func TestReceiveWireVersion(t *testing.T) {
+	orderService := newSyncTestService()
+	order := createSyncTestOrder(t, orderService, []byte(assetPair))
+	orderService.Storage.DeleteAllWithPrefix(string(interfaces.OrderPrefix))
+	orderInBytes, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	_, signerPublicKey, err := identity.GetIdentity(orderService.Storage)
+	assert.NoError(t, err)
+	signerID, err := peer.IDFromPublicKey(signerPublicKey)
+	assert.NoError(t, err)
+
+	// Messages from a newer, incompatible wire format are rejected
+	newer, err := proto.Marshal(&pb.WireMessage{ChannelID: []byte(assetPair), Operation: pb.Operation_CREATE, Data: orderInBytes, Version: interfaces.WireVersion + 1})
+	assert.NoError(t, err)
+	assert.Error(t, orderService.Receive(newer, signerID))
+
+	// While messages from nodes older than versioning are still accepted
+	legacy, err := proto.Marshal(&pb.WireMessage{ChannelID: []byte(assetPair), Operation: pb.Operation_CREATE, Data: orderInBytes})
+	assert.NoError(t, err)
+	assert.NoError(t, orderService.Receive(legacy, signerID))
+}
+
+func TestReceiveLimits(t *testing.T) {
+	orderService := newSyncTestService()
+	channelID := []byte(assetPair)
+	first := createSyncTestOrder(t, orderService, channelID)
+	second := createSyncTestOrder(t, orderService, channelID)
+	orderService.Storage.DeleteAllWithPrefix(string(interfaces.OrderPrefix))
+
+	_, signerPublicKey, err := identity.GetIdentity(orderService.Storage)
+	assert.NoError(t, err)
+	signerID, err := peer.IDFromPublicKey(signerPublicKey)
+	assert.NoError(t, err)
+
+	limiter := limits.NewLimiter(0, 0, 1, nil)
+	orderService.RegisterLimiter(limiter)
+	nodeService := &NodeService{}
+	nodeService.RegisterLimiter(limiter)
+
+	// Orders over the open order limit aren't stored
+	assert.NoError(t, orderService.Receive(marshalWireMessage(t, channelID, pb.Operation_CREATE, first), signerID))
+	assert.Error(t, orderService.Receive(marshalWireMessage(t, channelID, pb.Operation_CREATE, second), signerID))
+	has, err := orderService.Storage.Has(getOrderStorageKey(channelID, second.GetId()))
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	// Until an open order is deleted
+	assert.NoError(t, orderService.Receive(marshalWireMessage(t, channelID, pb.Operation_DELETE, first), signerID))
+	assert.NoError(t, orderService.Receive(marshalWireMessage(t, channelID, pb.Operation_CREATE, second), signerID))
+
+	offenders, err := nodeService.GetRateLimitOffenders(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, offenders.GetOffenders(), 1)
+	assert.Equal(t, signerID.String(), offenders.GetOffenders()[0].GetPeer())
+	assert.Equal(t, uint64(1), offenders.GetOffenders()[0].GetRejectedOrders())
+
+	// Orders that couldn't be stored don't count towards the limit
+	limiter = limits.NewLimiter(0, 0, 1, nil)
+	orderService.RegisterLimiter(limiter)
+	storage := orderService.Storage
+	orderService.Storage = &failingOrderStorage{storage.(*inmemory.Storage)}
+	assert.Error(t, orderService.Receive(marshalWireMessage(t, channelID, pb.Operation_CREATE, first), signerID))
+	orderService.Storage = storage
+	assert.True(t, limiter.ReserveOrder(channelID, signerID, []byte("another order")))
+
+	// Messages over the rate limit are dropped
+	orderService.RegisterLimiter(limits.NewLimiter(1, 1, 0, nil))
+	sync := marshalWireMessage(t, channelID, pb.Operation_SYNC_RECEIVE, &pb.SyncUpdate{})
+	assert.NoError(t, orderService.Receive(sync, signerID))
+	assert.Error(t, orderService.Receive(sync, signerID))
+}
+
+// failingOrderStorage fails to store orders
+type failingOrderStorage struct {
+	*inmemory.Storage
+}
+
+func (storage *failingOrderStorage) Put(key []byte, data []byte) error {
+	if strings.HasPrefix(string(key), string(interfaces.OrderPrefix)) {
+		return errors.E(errors.Op("Put"), "storage is full")
+	}
+	return storage.Storage.Put(key, data)
+}
+
+// countingWebsocket counts the messages pushed to it
+type countingWebsocket struct {
+	pushed int
+}
+
+func (ws *countingWebsocket) Start()                                   {}
+func (ws *countingWebsocket) Close()                                   {}
+func (ws *countingWebsocket) PushToWebsockets(message *pb.WireMessage) { ws.pushed++ }
+func (ws *countingWebsocket) RegisterHandlers(orders pb.OrderHandlerServer, channels pb.ChannelHandlerServer, nodes pb.NodeHandlerServer, authorizer interfaces.Authorizer) {
+}
+
+func TestOrderCreationStorageFailure(t *testing.T) {
+	websocket := &countingWebsocket{}
+	orderService := &OrderService{Logger: new(util.PlaceholderLogger), Storage: &failingOrderStorage{&inmemory.Storage{Db: make(map[string]string)}}}
+	orderService.RegisterWebsocket(websocket)
+
+	// An order that couldn't be stored fails to be created and isn't announced
+	_, err := orderService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.Error(t, err)
+	assert.Equal(t, 0, websocket.pushed)
+}
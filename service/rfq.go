@@ -0,0 +1,640 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// defaultQuoteTTL is used for quote requests and quotes that don't specify how long they're valid
+const defaultQuoteTTL = 30 * time.Second
+
+// defaultMaxQuoteTTL is the longest quote requests and quotes can be valid for, unless configured otherwise
+const defaultMaxQuoteTTL = time.Hour
+
+func getQuoteRequestStorageKey(requestID []byte) []byte {
+	return []byte(strings.Join([]string{string(interfaces.QuoteRequestPrefix), string(requestID)}, ""))
+}
+
+func getQuoteStorageKey(requestID []byte, quoteID []byte) []byte {
+	return []byte(strings.Join([]string{string(interfaces.QuotePrefix), string(requestID), string(quoteID)}, ""))
+}
+
+func getQuoteQueryPrefix(requestID []byte) []byte {
+	return []byte(strings.Join([]string{string(interfaces.QuotePrefix), string(requestID)}, ""))
+}
+
+func (s *OrderService) getMaxQuoteTTL() time.Duration {
+	if s.MaxQuoteTTL > 0 {
+		return s.MaxQuoteTTL
+	}
+	return defaultMaxQuoteTTL
+}
+
+// getExpiry returns when a quote request or a quote created now with ttl in seconds expires
+func (s *OrderService) getExpiry(now *timestamp.Timestamp, ttl uint32) (*timestamp.Timestamp, error) {
+	duration := defaultQuoteTTL
+	if ttl > 0 {
+		duration = time.Duration(ttl) * time.Second
+	}
+	if duration > s.getMaxQuoteTTL() {
+		return nil, errors.E(errors.Op("Check TTL"), fmt.Sprintf("TTL %s is longer than the maximum of %s", duration, s.getMaxQuoteTTL()))
+	}
+	created, err := ptypes.Timestamp(now)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	return ptypes.TimestampProto(created.Add(duration))
+}
+
+func isExpired(expires *timestamp.Timestamp) bool {
+	expiry, err := ptypes.Timestamp(expires)
+	return !errors.IsEmpty(err) || time.Now().After(expiry)
+}
+
+// checkExpiry rejects the quote requests and quotes of other nodes that would be kept for longer than the maximum TTL
+func (s *OrderService) checkExpiry(expires *timestamp.Timestamp) error {
+	expiry, err := ptypes.Timestamp(expires)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Check expiry"), err)
+	}
+	if time.Until(expiry) > s.getMaxQuoteTTL() {
+		return errors.E(errors.Op("Check expiry"), fmt.Sprintf("expiry %s is further away than the maximum TTL of %s", expiry, s.getMaxQuoteTTL()))
+	}
+	return nil
+}
+
+func quoteRequestBytes(request *pb.QuoteRequest) ([]byte, error) {
+	requestCopy := *request
+	requestCopy.Signature = nil
+	requestCopy.AcceptedQuoteID = nil
+	return proto.Marshal(&requestCopy)
+}
+
+func quoteBytes(quote *pb.Quote) ([]byte, error) {
+	quoteCopy := *quote
+	quoteCopy.Signature = nil
+	return proto.Marshal(&quoteCopy)
+}
+
+// VerifyQuoteRequest verifies that the quote request is signed with the given public key
+func VerifyQuoteRequest(publicKey crypto.PubKey, request *pb.QuoteRequest) (bool, error) {
+	requestInBytes, err := quoteRequestBytes(request)
+	if !errors.IsEmpty(err) {
+		return false, errors.E(errors.Op("Marshal quote request in VerifyQuoteRequest"), err)
+	}
+	return identity.Verify(publicKey, requestInBytes, request.GetSignature())
+}
+
+// VerifyQuote verifies that the quote is signed with the given public key
+func VerifyQuote(publicKey crypto.PubKey, quote *pb.Quote) (bool, error) {
+	quoteInBytes, err := quoteBytes(quote)
+	if !errors.IsEmpty(err) {
+		return false, errors.E(errors.Op("Marshal quote in VerifyQuote"), err)
+	}
+	return identity.Verify(publicKey, quoteInBytes, quote.GetSignature())
+}
+
+// getOwnID returns the peer ID of the identity this node signs orders and quotes with
+func (s *OrderService) getOwnID() (peer.ID, crypto.PubKey, error) {
+	_, publicKey, err := identity.GetIdentity(s.Storage)
+	if !errors.IsEmpty(err) {
+		return "", nil, errors.E(errors.Op("Get identity"), err)
+	}
+	ownID, err := peer.IDFromPublicKey(publicKey)
+	if !errors.IsEmpty(err) {
+		return "", nil, errors.E(errors.Op("Get peer ID from public key"), err)
+	}
+	return ownID, publicKey, nil
+}
+
+func (s *OrderService) getQuoteRequest(requestID []byte) (*pb.QuoteRequest, error) {
+	data, err := s.Storage.Get(getQuoteRequestStorageKey(requestID))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get quote request"), err)
+	}
+	request := &pb.QuoteRequest{}
+	err = proto.Unmarshal(data, request)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal quote request"), err)
+	}
+	return request, nil
+}
+
+func (s *OrderService) getQuote(requestID []byte, quoteID []byte) (*pb.Quote, error) {
+	data, err := s.Storage.Get(getQuoteStorageKey(requestID, quoteID))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get quote"), err)
+	}
+	quote := &pb.Quote{}
+	err = proto.Unmarshal(data, quote)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal quote"), err)
+	}
+	return quote, nil
+}
+
+// RequestQuote broadcasts a signed request for firm quotes to all other nodes on the channel
+func (s *OrderService) RequestQuote(ctx context.Context, in *pb.CreateQuoteRequest) (*pb.QuoteRequest, error) {
+	if len(in.GetChannelID()) == 0 || in.GetAsset() == "" || in.GetCounterAsset() == "" || in.GetAmount() == 0 {
+		return nil, errors.E(errors.Op("Validate quote request"), "channel, assets and amount are required")
+	}
+
+	ownID, publicKey, err := s.getOwnID()
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get own ID in RequestQuote"), err)
+	}
+	secret, err := publicKey.Bytes()
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Turn public key into bytes"), err)
+	}
+
+	now := ptypes.TimestampNow()
+	expires, err := s.getExpiry(now, in.GetTtl())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get quote request expiry"), err)
+	}
+
+	h := hmac.New(sha256.New, secret)
+	h.Write(append([]byte(in.String()), []byte(now.String())...))
+
+	request := &pb.QuoteRequest{
+		Id:           h.Sum(nil),
+		ChannelID:    in.GetChannelID(),
+		Requester:    ownID.String(),
+		Asset:        in.GetAsset(),
+		CounterAsset: in.GetCounterAsset(),
+		Amount:       in.GetAmount(),
+		Created:      now,
+		Expires:      expires,
+	}
+
+	requestInBytes, err := quoteRequestBytes(request)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal quote request"), err)
+	}
+	request.Signature, err = identity.Sign(s.Storage, requestInBytes)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Sign quote request"), err)
+	}
+
+	requestInBytes, err = proto.Marshal(request)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal signed quote request"), err)
+	}
+	err = s.purgeExpiredQuoteRequests()
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	err = s.Storage.Put(getQuoteRequestStorageKey(request.GetId()), requestInBytes)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Put quote request"), err)
+	}
+
+	wireData, err := s.sealForChannel(in.GetChannelID(), requestInBytes)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Seal quote request for channel"), err)
+	}
+
+	if s.P2p != nil {
+		s.P2p.Send(&pb.WireMessage{ChannelID: in.GetChannelID(), Operation: pb.Operation_QUOTE_REQUEST, Data: wireData})
+	} else {
+		s.Logger.Warn("P2p service not registered with OrderService, not publishing quote requests to the network!")
+	}
+
+	return request, nil
+}
+
+// GetQuoteRequests fetches all quote requests that haven't expired yet, both sent and received
+func (s *OrderService) GetQuoteRequests(ctx context.Context, in *pb.Empty) (*pb.QuoteRequestList, error) {
+	err := s.purgeExpiredQuoteRequests()
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	data, err := s.Storage.GetAllWithPrefix(string(interfaces.QuoteRequestPrefix))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get all quote requests"), err)
+	}
+
+	requests := make([]*pb.QuoteRequest, 0)
+	for _, value := range data {
+		request := &pb.QuoteRequest{}
+		proto.Unmarshal([]byte(value), request)
+		requests = append(requests, request)
+	}
+
+	return &pb.QuoteRequestList{Requests: requests}, nil
+}
+
+// purgeExpiredQuoteRequests deletes the expired quote requests along with their quotes.
+// It runs whenever quote requests are stored or listed, so they can't pile up in storage.
+func (s *OrderService) purgeExpiredQuoteRequests() error {
+	data, err := s.Storage.GetAllWithPrefix(string(interfaces.QuoteRequestPrefix))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get all quote requests"), err)
+	}
+
+	for key, value := range data {
+		request := &pb.QuoteRequest{}
+		err = proto.Unmarshal([]byte(value), request)
+		if errors.IsEmpty(err) && !isExpired(request.GetExpires()) {
+			continue
+		}
+		err = s.Storage.DeleteAllWithPrefix(string(getQuoteQueryPrefix(request.GetId())))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Delete expired quotes"), err)
+		}
+		err = s.Storage.Delete([]byte(key))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Delete expired quote request"), err)
+		}
+	}
+	return nil
+}
+
+// SendQuote answers another node's quote request with a signed firm quote over a direct stream
+func (s *OrderService) SendQuote(ctx context.Context, in *pb.SendQuoteRequest) (*pb.Quote, error) {
+	quote, requester, err := s.newQuote(ctx, in)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Create quote"), err)
+	}
+
+	quoteInBytes, err := proto.Marshal(quote)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal quote"), err)
+	}
+	err = s.Storage.Put(getQuoteStorageKey(quote.GetRequestID(), quote.GetId()), quoteInBytes)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Put quote"), err)
+	}
+
+	wireData, err := s.sealForChannel(quote.GetChannelID(), quoteInBytes)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Seal quote for channel"), err)
+	}
+
+	err = s.sendToPeer(requester, &pb.WireMessage{ChannelID: quote.GetChannelID(), Operation: pb.Operation_QUOTE, Data: wireData})
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Send quote"), err)
+	}
+
+	return quote, nil
+}
+
+// newQuote builds a signed quote, containing an order signed by this node, for a received quote request
+func (s *OrderService) newQuote(ctx context.Context, in *pb.SendQuoteRequest) (*pb.Quote, peer.ID, error) {
+	request, err := s.getQuoteRequest(in.GetRequestID())
+	if !errors.IsEmpty(err) {
+		return nil, "", err
+	}
+	if isExpired(request.GetExpires()) {
+		return nil, "", errors.E(errors.Op("Check quote request expiry"), "quote request has expired")
+	}
+
+	requester, err := peer.IDB58Decode(request.GetRequester())
+	if !errors.IsEmpty(err) {
+		return nil, "", errors.E(errors.Op("Decode requester peer ID"), err)
+	}
+	ownID, publicKey, err := s.getOwnID()
+	if !errors.IsEmpty(err) {
+		return nil, "", err
+	}
+	if requester == ownID {
+		return nil, "", errors.E(errors.Op("Check requester"), "can't quote our own quote request")
+	}
+	secret, err := publicKey.Bytes()
+	if !errors.IsEmpty(err) {
+		return nil, "", errors.E(errors.Op("Turn public key into bytes"), err)
+	}
+	creator, err := crypto.MarshalPublicKey(publicKey)
+	if !errors.IsEmpty(err) {
+		return nil, "", errors.E(errors.Op("Marshal creator public key"), err)
+	}
+
+	now := ptypes.TimestampNow()
+	expires, err := s.getExpiry(now, in.GetTtl())
+	if !errors.IsEmpty(err) {
+		return nil, "", errors.E(errors.Op("Get quote expiry"), err)
+	}
+
+	h := hmac.New(sha256.New, secret)
+	h.Write(append([]byte(in.String()), []byte(now.String())...))
+	id := h.Sum(nil)
+
+	order := &pb.Order{
+		Id:           id,
+		Created:      now,
+		Asset:        request.GetAsset(),
+		CounterAsset: request.GetCounterAsset(),
+		Amount:       request.GetAmount(),
+		Price:        in.GetPrice(),
+		State:        pb.State_OPEN,
+		Nonce:        0,
+		Creator:      creator,
+	}
+	order.Signature, err = s.GetSignature(order)
+	if !errors.IsEmpty(err) {
+		return nil, "", errors.E(errors.Op("Sign quoted order"), err)
+	}
+	err = s.signState(order, false)
+	if !errors.IsEmpty(err) {
+		return nil, "", err
+	}
+	// The order joins the channel's orders once the quote is accepted, so it needs the same work as any other
+	err = s.stampOrder(ctx, request.GetChannelID(), order)
+	if !errors.IsEmpty(err) {
+		return nil, "", errors.E(errors.Op("Stamp quoted order"), err)
+	}
+
+	quote := &pb.Quote{
+		Id:        id,
+		RequestID: request.GetId(),
+		ChannelID: request.GetChannelID(),
+		Quoter:    ownID.String(),
+		Order:     order,
+		Expires:   expires,
+	}
+	quoteInBytes, err := quoteBytes(quote)
+	if !errors.IsEmpty(err) {
+		return nil, "", errors.E(errors.Op("Marshal quote"), err)
+	}
+	quote.Signature, err = identity.Sign(s.Storage, quoteInBytes)
+	if !errors.IsEmpty(err) {
+		return nil, "", errors.E(errors.Op("Sign quote"), err)
+	}
+
+	return quote, requester, nil
+}
+
+// GetQuotes fetches all quotes for the given quote request
+func (s *OrderService) GetQuotes(ctx context.Context, in *pb.QuoteRequestSpecificRequest) (*pb.QuoteList, error) {
+	data, err := s.Storage.GetAllWithPrefix(string(getQuoteQueryPrefix(in.GetRequestID())))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get quotes"), err)
+	}
+
+	quotes := make([]*pb.Quote, 0)
+	for _, value := range data {
+		quote := &pb.Quote{}
+		proto.Unmarshal([]byte(value), quote)
+		quotes = append(quotes, quote)
+	}
+
+	return &pb.QuoteList{Quotes: quotes}, nil
+}
+
+// AcceptQuote accepts a quote received for our own quote request, locking the quoted order on both nodes.
+// A quote request can only be filled once, so its other quotes can't be accepted afterwards.
+func (s *OrderService) AcceptQuote(ctx context.Context, in *pb.QuoteSpecificRequest) (*pb.Order, error) {
+	s.quoteLock.Lock()
+	defer s.quoteLock.Unlock()
+
+	quote, err := s.getQuote(in.GetRequestID(), in.GetQuoteID())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get quote in AcceptQuote"), err)
+	}
+	if isExpired(quote.GetExpires()) {
+		return nil, errors.E(errors.Op("Check quote expiry"), "quote has expired")
+	}
+	request, err := s.getQuoteRequest(quote.GetRequestID())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get quote request in AcceptQuote"), err)
+	}
+	if len(request.GetAcceptedQuoteID()) > 0 {
+		return nil, errors.E(errors.Op("Check quote request"), "quote request has already been filled")
+	}
+
+	quoter, err := peer.IDB58Decode(quote.GetQuoter())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Decode quoter peer ID"), err)
+	}
+	ownID, _, err := s.getOwnID()
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	if quoter == ownID {
+		return nil, errors.E(errors.Op("Check quoter"), "can't accept our own quote")
+	}
+
+	quoteInBytes, err := proto.Marshal(quote)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal quote"), err)
+	}
+	wireData, err := s.sealForChannel(quote.GetChannelID(), quoteInBytes)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Seal quote acceptance for channel"), err)
+	}
+
+	err = s.sendToPeer(quoter, &pb.WireMessage{ChannelID: quote.GetChannelID(), Operation: pb.Operation_QUOTE_ACCEPT, Data: wireData})
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Send quote acceptance"), err)
+	}
+
+	return s.fillQuoteRequest(ctx, request, quote)
+}
+
+// fillQuoteRequest adds the order of an accepted quote to the channel's orders and locks it with Lock,
+// then marks the quote request as filled by the quote. The quoter signs the locked state and announces it.
+func (s *OrderService) fillQuoteRequest(ctx context.Context, request *pb.QuoteRequest, quote *pb.Quote) (*pb.Order, error) {
+	orderKey := getOrderStorageKey(quote.GetChannelID(), quote.GetOrder().GetId())
+	stored, err := s.Storage.Has(orderKey)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Check quoted order"), err)
+	}
+	if !stored {
+		orderInBytes, err := proto.Marshal(quote.GetOrder())
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Marshal quoted order"), err)
+		}
+		err = s.Storage.Put(orderKey, orderInBytes)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Put quoted order"), err)
+		}
+	}
+
+	_, err = s.Lock(ctx, &pb.OrderSpecificRequest{ChannelID: quote.GetChannelID(), OrderID: quote.GetOrder().GetId()})
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Lock quoted order"), err)
+	}
+
+	request.AcceptedQuoteID = quote.GetId()
+	requestInBytes, err := proto.Marshal(request)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal filled quote request"), err)
+	}
+	err = s.Storage.Put(getQuoteRequestStorageKey(request.GetId()), requestInBytes)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Put filled quote request"), err)
+	}
+
+	orderInBytes, err := s.Storage.Get(orderKey)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get locked quoted order"), err)
+	}
+	lockedOrder := &pb.Order{}
+	err = proto.Unmarshal(orderInBytes, lockedOrder)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal locked quoted order"), err)
+	}
+	return lockedOrder, nil
+}
+
+// receiveQuoteRequest stores a quote request broadcast by another node on the channel
+func (s *OrderService) receiveQuoteRequest(channelID []byte, data []byte, from peer.ID) error {
+	request, err := checkQuoteRequest(channelID, data, from)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+
+	if isExpired(request.GetExpires()) {
+		s.Logger.Debugf("Dropping expired quote request from %s", from)
+		return nil
+	}
+	err = s.checkExpiry(request.GetExpires())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Check quote request expiry"), err)
+	}
+
+	err = s.purgeExpiredQuoteRequests()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	err = s.Storage.Put(getQuoteRequestStorageKey(request.GetId()), data)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Put quote request"), err)
+	}
+	return nil
+}
+
+// checkQuoteRequest unmarshals a quote request and checks that it was signed by its sender for the channel it came in
+func checkQuoteRequest(channelID []byte, data []byte, from peer.ID) (*pb.QuoteRequest, error) {
+	request := &pb.QuoteRequest{}
+	err := proto.Unmarshal(data, request)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal quote request"), err)
+	}
+	if request.GetRequester() != from.String() || string(request.GetChannelID()) != string(channelID) {
+		return nil, errors.E(errors.Op("Check quote request"), fmt.Sprintf("quote request from %s doesn't match its sender or channel", from))
+	}
+
+	publicKey, err := from.ExtractPublicKey()
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Extract public key"), err)
+	}
+	isRequester, err := VerifyQuoteRequest(publicKey, request)
+	if !errors.IsEmpty(err) || !isRequester {
+		return nil, errors.E(errors.Op("Verify quote request"), fmt.Sprintf("invalid quote request signature from %s", from))
+	}
+	return request, nil
+}
+
+// receiveQuote stores a firm quote another node sent for one of our quote requests
+func (s *OrderService) receiveQuote(channelID []byte, data []byte, from peer.ID) error {
+	quote := &pb.Quote{}
+	err := proto.Unmarshal(data, quote)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal quote"), err)
+	}
+	if quote.GetQuoter() != from.String() || string(quote.GetChannelID()) != string(channelID) {
+		return errors.E(errors.Op("Check quote"), fmt.Sprintf("quote from %s doesn't match its sender or channel", from))
+	}
+
+	publicKey, err := from.ExtractPublicKey()
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Extract public key"), err)
+	}
+	isQuoter, err := VerifyQuote(publicKey, quote)
+	if !errors.IsEmpty(err) || !isQuoter {
+		return errors.E(errors.Op("Verify quote"), fmt.Sprintf("invalid quote signature from %s", from))
+	}
+	isCreator, err := s.VerifyOrder(publicKey, quote.GetOrder())
+	if !errors.IsEmpty(err) || !isCreator {
+		return errors.E(errors.Op("Verify quoted order"), fmt.Sprintf("invalid quoted order signature from %s", from))
+	}
+
+	request, err := s.getQuoteRequest(quote.GetRequestID())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get quoted request"), err)
+	}
+	ownID, _, err := s.getOwnID()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if request.GetRequester() != ownID.String() || string(request.GetChannelID()) != string(channelID) {
+		return errors.E(errors.Op("Check quoted request"), "quote isn't for one of our quote requests")
+	}
+	if isExpired(request.GetExpires()) || isExpired(quote.GetExpires()) {
+		s.Logger.Debugf("Dropping expired quote from %s", from)
+		return nil
+	}
+	if len(request.GetAcceptedQuoteID()) > 0 {
+		s.Logger.Debugf("Dropping quote from %s for a filled quote request", from)
+		return nil
+	}
+	err = s.checkExpiry(quote.GetExpires())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Check quote expiry"), err)
+	}
+
+	err = s.Storage.Put(getQuoteStorageKey(quote.GetRequestID(), quote.GetId()), data)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Put quote"), err)
+	}
+	return nil
+}
+
+// receiveQuoteAccept locks the order of a quote we sent once its requester accepts it
+func (s *OrderService) receiveQuoteAccept(channelID []byte, data []byte, from peer.ID) error {
+	s.quoteLock.Lock()
+	defer s.quoteLock.Unlock()
+
+	accepted := &pb.Quote{}
+	err := proto.Unmarshal(data, accepted)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal accepted quote"), err)
+	}
+
+	// Only trust our own copy of the quote, not the one the requester sent back
+	quote, err := s.getQuote(accepted.GetRequestID(), accepted.GetId())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get accepted quote"), err)
+	}
+	request, err := s.getQuoteRequest(quote.GetRequestID())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get accepted quote request"), err)
+	}
+	ownID, _, err := s.getOwnID()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if quote.GetQuoter() != ownID.String() || request.GetRequester() != from.String() || string(quote.GetChannelID()) != string(channelID) {
+		return errors.E(errors.Op("Check accepted quote"), fmt.Sprintf("%s can't accept this quote", from))
+	}
+	if isExpired(quote.GetExpires()) {
+		return errors.E(errors.Op("Check quote expiry"), fmt.Sprintf("%s accepted an expired quote", from))
+	}
+
+	if len(request.GetAcceptedQuoteID()) > 0 {
+		return errors.E(errors.Op("Check accepted quote request"), fmt.Sprintf("%s accepted a quote for a filled quote request", from))
+	}
+
+	_, err = s.fillQuoteRequest(context.Background(), request, quote)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Lock accepted quote"), err)
+	}
+	s.Logger.Infof("Quote %x accepted by %s", quote.GetId(), from)
+	return nil
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func newQuoteTestService(t *testing.T) (*OrderService, peer.ID) {
+	quoteService := &OrderService{Logger: new(util.PlaceholderLogger), Storage: &inmemory.Storage{Db: make(map[string]string)}}
+	ownID, _, err := quoteService.getOwnID()
+	assert.NoError(t, err)
+	return quoteService, ownID
+}
+
+func marshalWireMessage(t *testing.T, channelID []byte, op pb.Operation, message proto.Message) []byte {
+	data, err := proto.Marshal(message)
+	assert.NoError(t, err)
+	wireMessage, err := proto.Marshal(&pb.WireMessage{ChannelID: channelID, Operation: op, Data: data})
+	assert.NoError(t, err)
+	return wireMessage
+}
+
+func TestQuoteExpiry(t *testing.T) {
+	assert.True(t, isExpired(nil))
+
+	quoteService, _ := newQuoteTestService(t)
+	now := ptypes.TimestampNow()
+	expires, err := quoteService.getExpiry(now, 0)
+	assert.NoError(t, err)
+	assert.False(t, isExpired(expires))
+	assert.Equal(t, now.GetSeconds()+int64(defaultQuoteTTL.Seconds()), expires.GetSeconds())
+	assert.NoError(t, quoteService.checkExpiry(expires))
+
+	_, err = quoteService.getExpiry(now, uint32(defaultMaxQuoteTTL.Seconds())+1)
+	assert.Error(t, err)
+	quoteService.MaxQuoteTTL = 2 * defaultMaxQuoteTTL
+	expires, err = quoteService.getExpiry(now, uint32(defaultMaxQuoteTTL.Seconds())+1)
+	assert.NoError(t, err)
+	assert.NoError(t, quoteService.checkExpiry(expires))
+	quoteService.MaxQuoteTTL = 0
+	assert.Error(t, quoteService.checkExpiry(expires))
+}
+
+func TestReceiveLongLivedQuoteRequest(t *testing.T) {
+	requester, requesterID := newQuoteTestService(t)
+	quoter, _ := newQuoteTestService(t)
+	channelID := []byte(assetPair)
+
+	// Other nodes can't make us keep their quote requests for longer than our own maximum
+	requester.MaxQuoteTTL = 2 * defaultMaxQuoteTTL
+	request, err := requester.RequestQuote(ctx, &pb.CreateQuoteRequest{ChannelID: channelID, Asset: asset2, CounterAsset: asset1, Amount: testAmount, Ttl: uint32(requester.MaxQuoteTTL.Seconds())})
+	assert.NoError(t, err)
+	err = quoter.Receive(marshalWireMessage(t, channelID, pb.Operation_QUOTE_REQUEST, request), requesterID)
+	assert.Error(t, err)
+	requests, err := quoter.GetQuoteRequests(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Empty(t, requests.GetRequests())
+}
+
+func TestQuoteWorkflow(t *testing.T) {
+	requester, requesterID := newQuoteTestService(t)
+	quoter, quoterID := newQuoteTestService(t)
+	channelID := []byte(assetPair)
+
+	_, err := requester.RequestQuote(ctx, &pb.CreateQuoteRequest{ChannelID: channelID})
+	assert.Error(t, err)
+
+	request, err := requester.RequestQuote(ctx, &pb.CreateQuoteRequest{ChannelID: channelID, Asset: asset2, CounterAsset: asset1, Amount: testAmount, Ttl: 30})
+	assert.NoError(t, err)
+	assert.Equal(t, requesterID.String(), request.GetRequester())
+	requests, err := requester.GetQuoteRequests(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, requests.GetRequests(), 1)
+
+	// Quote requests are only accepted from the node that signed them
+	requestMessage := marshalWireMessage(t, channelID, pb.Operation_QUOTE_REQUEST, request)
+	err = quoter.Receive(requestMessage, quoterID)
+	assert.Error(t, err)
+	err = quoter.Receive(requestMessage, requesterID)
+	assert.NoError(t, err)
+	requests, err = quoter.GetQuoteRequests(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, requests.GetRequests(), 1)
+
+	_, _, err = requester.newQuote(ctx, &pb.SendQuoteRequest{RequestID: request.GetId(), Price: testPrice})
+	assert.Error(t, err)
+	quote, recipient, err := quoter.newQuote(ctx, &pb.SendQuoteRequest{RequestID: request.GetId(), Price: testPrice})
+	assert.NoError(t, err)
+	assert.Equal(t, requesterID, recipient)
+	assert.Equal(t, uint64(testAmount), quote.GetOrder().GetAmount())
+	quoteInBytes, err := proto.Marshal(quote)
+	assert.NoError(t, err)
+	err = quoter.Storage.Put(getQuoteStorageKey(quote.GetRequestID(), quote.GetId()), quoteInBytes)
+	assert.NoError(t, err)
+
+	// Quotes are only accepted from the node that signed them
+	quoteMessage := marshalWireMessage(t, channelID, pb.Operation_QUOTE, quote)
+	err = requester.Receive(quoteMessage, requesterID)
+	assert.Error(t, err)
+	err = requester.Receive(quoteMessage, quoterID)
+	assert.NoError(t, err)
+	quotes, err := requester.GetQuotes(ctx, &pb.QuoteRequestSpecificRequest{RequestID: request.GetId()})
+	assert.NoError(t, err)
+	assert.Len(t, quotes.GetQuotes(), 1)
+
+	// Without a p2p service the acceptance can't reach the quoter, so the requester fills the quote request below
+	_, err = requester.AcceptQuote(ctx, &pb.QuoteSpecificRequest{RequestID: request.GetId(), QuoteID: quote.GetId()})
+	assert.Error(t, err)
+
+	// Only the requester can accept the quote, and only once
+	acceptMessage := marshalWireMessage(t, channelID, pb.Operation_QUOTE_ACCEPT, quote)
+	err = quoter.Receive(acceptMessage, quoterID)
+	assert.Error(t, err)
+	err = quoter.Receive(acceptMessage, requesterID)
+	assert.NoError(t, err)
+	err = quoter.Receive(acceptMessage, requesterID)
+	assert.Error(t, err)
+
+	// The quoted order is locked like any other order, with the lock signed by the quoter
+	lockedOrder, err := quoter.GetOrder(ctx, &pb.OrderSpecificRequest{ChannelID: channelID, OrderID: quote.GetOrder().GetId()})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_LOCKED, lockedOrder.GetState())
+	assert.Equal(t, uint32(1), lockedOrder.GetNonce())
+	quoterPublicKey, err := quoterID.ExtractPublicKey()
+	assert.NoError(t, err)
+	isCreator, err := requester.VerifyOrder(quoterPublicKey, lockedOrder)
+	assert.NoError(t, err)
+	assert.True(t, isCreator)
+	stateData, err := getStateData(lockedOrder.GetId(), lockedOrder.GetState(), lockedOrder.GetNonce(), false)
+	assert.NoError(t, err)
+	validState, err := identity.Verify(quoterPublicKey, stateData, lockedOrder.GetStateSignature())
+	assert.NoError(t, err)
+	assert.True(t, validState)
+
+	// So it can be unlocked again
+	_, err = quoter.Unlock(ctx, &pb.OrderSpecificRequest{ChannelID: channelID, OrderID: quote.GetOrder().GetId()})
+	assert.NoError(t, err)
+
+	// Once the quote request is filled, its other quotes are dropped and can't be accepted
+	filled, err := quoter.getQuoteRequest(request.GetId())
+	assert.NoError(t, err)
+	assert.Equal(t, quote.GetId(), filled.GetAcceptedQuoteID())
+	requesterPublicKey, err := requesterID.ExtractPublicKey()
+	assert.NoError(t, err)
+	isRequester, err := VerifyQuoteRequest(requesterPublicKey, filled)
+	assert.NoError(t, err)
+	assert.True(t, isRequester)
+
+	secondQuote, _, err := quoter.newQuote(ctx, &pb.SendQuoteRequest{RequestID: request.GetId(), Price: testPrice + 1})
+	assert.NoError(t, err)
+	quoteInBytes, err = proto.Marshal(secondQuote)
+	assert.NoError(t, err)
+	err = quoter.Storage.Put(getQuoteStorageKey(secondQuote.GetRequestID(), secondQuote.GetId()), quoteInBytes)
+	assert.NoError(t, err)
+	err = quoter.Receive(marshalWireMessage(t, channelID, pb.Operation_QUOTE_ACCEPT, secondQuote), requesterID)
+	assert.Error(t, err)
+
+	_, err = requester.fillQuoteRequest(ctx, request, quote)
+	assert.NoError(t, err)
+	err = requester.Receive(marshalWireMessage(t, channelID, pb.Operation_QUOTE, secondQuote), quoterID)
+	assert.NoError(t, err)
+	quotes, err = requester.GetQuotes(ctx, &pb.QuoteRequestSpecificRequest{RequestID: request.GetId()})
+	assert.NoError(t, err)
+	assert.Len(t, quotes.GetQuotes(), 1)
+	_, err = requester.AcceptQuote(ctx, &pb.QuoteSpecificRequest{RequestID: request.GetId(), QuoteID: quote.GetId()})
+	assert.Error(t, err)
+}
+
+func TestPurgeExpiredQuoteRequests(t *testing.T) {
+	requester, _ := newQuoteTestService(t)
+	channelID := []byte(assetPair)
+
+	request, err := requester.RequestQuote(ctx, &pb.CreateQuoteRequest{ChannelID: channelID, Asset: asset2, CounterAsset: asset1, Amount: testAmount, Ttl: 30})
+	assert.NoError(t, err)
+	assert.NoError(t, requester.Storage.Put(getQuoteStorageKey(request.GetId(), []byte("quote")), []byte("quote")))
+
+	request.Expires = &timestamp.Timestamp{Seconds: request.GetExpires().GetSeconds() - 60}
+	requestInBytes, err := proto.Marshal(request)
+	assert.NoError(t, err)
+	assert.NoError(t, requester.Storage.Put(getQuoteRequestStorageKey(request.GetId()), requestInBytes))
+
+	// Expired quote requests are deleted along with their quotes
+	requests, err := requester.GetQuoteRequests(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Empty(t, requests.GetRequests())
+	stored, err := requester.Storage.GetAllWithPrefix(string(interfaces.QuoteRequestPrefix))
+	assert.NoError(t, err)
+	assert.Empty(t, stored)
+	stored, err = requester.Storage.GetAllWithPrefix(string(getQuoteQueryPrefix(request.GetId())))
+	assert.NoError(t, err)
+	assert.Empty(t, stored)
+}
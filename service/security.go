@@ -0,0 +1,231 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const authorizationHeader = "authorization"
+const bearerPrefix = "Bearer "
+
+// useTLS tells if the gRPC API is served over TLS, which needs both a certificate and its key
+func (server *Server) useTLS() bool {
+	return server.CertFile != "" && server.KeyFile != ""
+}
+
+// getServerOptions returns the TLS credentials the gRPC server is configured with and the interceptors that authorize and time calls
+func (server *Server) getServerOptions() ([]grpc.ServerOption, error) {
+	opts := []grpc.ServerOption{}
+	if server.useTLS() {
+		certificate, err := tls.LoadX509KeyPair(server.CertFile, server.KeyFile)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Load certificate"), err)
+		}
+		tlsConfig := &tls.Config{Certificates: []tls.Certificate{certificate}, MinVersion: tls.VersionTLS12}
+
+		// Only clients with a certificate issued by the client CA are let in
+		if server.ClientCAFile != "" {
+			clientCAs, err := loadCertPool(server.ClientCAFile)
+			if !errors.IsEmpty(err) {
+				return nil, errors.E(errors.Op("Load client CA"), err)
+			}
+			tlsConfig.ClientCAs = clientCAs
+			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	} else if server.ClientCAFile != "" {
+		return nil, errors.E(errors.Op("Configure TLS"), "client certificates can't be verified without TLS, set both the certificate and the key")
+	}
+
+	// grpc only takes one interceptor of each kind, so timing the calls wraps authorizing them
+	opts = append(opts, grpc.UnaryInterceptor(server.observeUnary), grpc.StreamInterceptor(server.observeStream))
+	return opts, nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(file)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Read certificates"), err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, errors.E(errors.Op("Parse certificates"), "no PEM encoded certificates in "+file)
+	}
+	return pool, nil
+}
+
+// methodRoles lists the least role needed to call each method. Methods missing from it need the admin role.
+var methodRoles = map[string]pb.APIKeyRole{
+	"/pb.OrderHandler/GetOrder":              pb.APIKeyRole_READ_ONLY,
+	"/pb.OrderHandler/GetAllOrders":          pb.APIKeyRole_READ_ONLY,
+	"/pb.OrderHandler/GetQuoteRequests":      pb.APIKeyRole_READ_ONLY,
+	"/pb.OrderHandler/GetQuotes":             pb.APIKeyRole_READ_ONLY,
+	"/pb.OrderHandler/StreamEvents":          pb.APIKeyRole_READ_ONLY,
+	"/pb.ChannelHandler/GetChannel":          pb.APIKeyRole_READ_ONLY,
+	"/pb.ChannelHandler/GetAllChannels":      pb.APIKeyRole_READ_ONLY,
+	"/pb.ChannelHandler/ListNetworkChannels": pb.APIKeyRole_READ_ONLY,
+	"/pb.ChannelHandler/GetChannelStats":     pb.APIKeyRole_READ_ONLY,
+	"/pb.ChannelHandler/GetSyncStatus":       pb.APIKeyRole_READ_ONLY,
+	"/pb.NodeHandler/GetAllPeers":            pb.APIKeyRole_READ_ONLY,
+	"/pb.NodeHandler/GetNodeInfo":            pb.APIKeyRole_READ_ONLY,
+	"/pb.NodeHandler/GetProtectedPeers":      pb.APIKeyRole_READ_ONLY,
+	"/pb.NodeHandler/GetRateLimitOffenders":  pb.APIKeyRole_READ_ONLY,
+	"/pb.NodeHandler/ReceiveDirectMessages":  pb.APIKeyRole_READ_ONLY,
+	"/pb.OrderHandler/Create":                pb.APIKeyRole_TRADER,
+	"/pb.OrderHandler/Delete":                pb.APIKeyRole_TRADER,
+	"/pb.OrderHandler/Lock":                  pb.APIKeyRole_TRADER,
+	"/pb.OrderHandler/Unlock":                pb.APIKeyRole_TRADER,
+	"/pb.OrderHandler/RequestQuote":          pb.APIKeyRole_TRADER,
+	"/pb.OrderHandler/SendQuote":             pb.APIKeyRole_TRADER,
+	"/pb.OrderHandler/AcceptQuote":           pb.APIKeyRole_TRADER,
+	"/pb.ChannelHandler/Join":                pb.APIKeyRole_TRADER,
+	"/pb.NodeHandler/SendDirectMessage":      pb.APIKeyRole_TRADER,
+}
+
+func getRequiredRole(fullMethod string) pb.APIKeyRole {
+	role, ok := methodRoles[fullMethod]
+	if !ok {
+		return pb.APIKeyRole_ADMIN
+	}
+	return role
+}
+
+// getBearerToken returns the bearer token in the call's authorization metadata, empty if there's none
+func getBearerToken(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(authorizationHeader) {
+		if strings.HasPrefix(value, bearerPrefix) {
+			return strings.TrimPrefix(value, bearerPrefix)
+		}
+	}
+	return ""
+}
+
+// authorize checks that the call's bearer token is the API token or an API key with a role that allows the method.
+// Until an API token is configured or an API key is created, the API is open to every client.
+func (server *Server) authorize(ctx context.Context, fullMethod string) error {
+	token := getBearerToken(ctx)
+	if token != "" && server.APIToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(server.APIToken)) == 1 {
+		return nil
+	}
+
+	if token != "" {
+		apiKey, err := server.APIKeys.Authenticate(token)
+		if !errors.IsEmpty(err) {
+			return status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Authenticate"), err))
+		}
+		if apiKey != nil {
+			if required := getRequiredRole(fullMethod); apiKey.GetRole() < required {
+				return status.Errorf(codes.PermissionDenied, "%s", errors.E(errors.Op("Authorize"), fmt.Sprintf("%s needs the %s role, API key %s has %s", fullMethod, required, apiKey.GetName(), apiKey.GetRole())))
+			}
+			return nil
+		}
+	}
+
+	if server.APIToken == "" {
+		hasKeys, err := server.APIKeys.HasKeys()
+		if !errors.IsEmpty(err) {
+			return status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Authenticate"), err))
+		}
+		if !hasKeys {
+			return nil
+		}
+	}
+	return status.Errorf(codes.Unauthenticated, "%s", errors.E(errors.Op("Authenticate"), "missing or invalid bearer token"))
+}
+
+// Authenticate tells if token is the API token or one of the stored API keys
+func (server *Server) Authenticate(token string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+	if server.APIToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(server.APIToken)) == 1 {
+		return true, nil
+	}
+	apiKey, err := server.APIKeys.Authenticate(token)
+	if !errors.IsEmpty(err) {
+		return false, errors.E(errors.Op("Authenticate"), err)
+	}
+	return apiKey != nil, nil
+}
+
+// Authorize checks that a client authenticating with token can call fullMethod, for clients that don't call over gRPC
+func (server *Server) Authorize(token string, fullMethod string) error {
+	ctx := context.Background()
+	if token != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(authorizationHeader, bearerPrefix+token))
+	}
+	return server.authorize(ctx, fullMethod)
+}
+
+func (server *Server) authorizeUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := server.authorize(ctx, info.FullMethod); !errors.IsEmpty(err) {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (server *Server) authorizeStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := server.authorize(stream.Context(), info.FullMethod); !errors.IsEmpty(err) {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+// getGatewayTLSConfig returns the TLS config of the gateway's HTTPS listener. With client certificates required by the gRPC API,
+// REST clients need one too, since the gateway dials the gRPC API with the server's own certificate on their behalf.
+func (server *Server) getGatewayTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if server.ClientCAFile != "" {
+		clientCAs, err := loadCertPool(server.ClientCAFile)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Load client CA"), err)
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+// getGatewayDialOptions returns how the gateway connects to the gRPC server.
+// The bearer tokens of REST clients are passed on by the gateway, so it doesn't need one of its own.
+func (server *Server) getGatewayDialOptions() ([]grpc.DialOption, error) {
+	if !server.useTLS() {
+		return []grpc.DialOption{grpc.WithInsecure()}, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(server.CertFile, server.KeyFile)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Load certificate"), err)
+	}
+	tlsConfig := &tls.Config{
+		// The gateway only connects to this node's own gRPC server, so it trusts exactly the server's certificate,
+		// whatever host names it was issued for
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 || !bytes.Equal(rawCerts[0], certificate.Certificate[0]) {
+				return errors.E(errors.Op("Verify gRPC server certificate"), "the server didn't present its configured certificate")
+			}
+			return nil
+		},
+	}
+	// With client certificates required, the gateway presents the server's own, which the client CA must have issued
+	if server.ClientCAFile != "" {
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}, nil
+}
@@ -1,8 +1,10 @@
 package service
 
 import (
+	"context"
 	fmt "fmt"
 	"net"
+	"net/http"
 
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/interfaces"
@@ -11,16 +13,29 @@ import (
 	"google.golang.org/grpc"
 )
 
-// Server contains services for both Orders and Channels
+// Server contains services for Orders, Channels and the Node itself
 type Server struct {
 	Orders   *OrderService
 	Channels *ChannelService
+	Nodes    *NodeService
+	APIKeys  *APIKeyService
 	Logger   interfaces.Logger
+	// Address is the interface the gRPC API listens on, all of them if empty
+	Address string
+	// CertFile and KeyFile enable TLS when both are set
+	CertFile string
+	KeyFile  string
+	// ClientCAFile requires clients to present a certificate it has issued
+	ClientCAFile string
+	// APIToken lets clients authenticate as admins with it as a bearer token, besides the stored API keys
+	APIToken string
 	grpc     *grpc.Server
+	gateway  *http.Server
+	metrics  interfaces.Metrics
 }
 
 // NewServer returns a server that has connections to p2p and storage
-func NewServer(log interfaces.Logger, storage interfaces.Storage, p2p interfaces.P2p,  websocket interfaces.WebsocketService) *Server {
+func NewServer(log interfaces.Logger, storage interfaces.Storage, p2p interfaces.P2p, websocket interfaces.WebsocketService) *Server {
 	server := &Server{}
 	if log != nil {
 		server.Logger = log
@@ -35,33 +50,68 @@ func NewServer(log interfaces.Logger, storage interfaces.Storage, p2p interfaces
 	server.Orders.RegisterP2p(p2p)
 
 	// Create a ChannelService that defines channel operations
-	server.Channels = &ChannelService{}
+	server.Channels = &ChannelService{Logger: server.Logger}
 	server.Channels.RegisterStorage(storage)
 	server.Channels.RegisterP2p(p2p)
 
+	// Create a NodeService that defines node and peer operations
+	server.Nodes = &NodeService{Logger: server.Logger}
+	server.Nodes.RegisterStorage(storage)
+	server.Nodes.RegisterP2p(p2p)
+
+	// Create an APIKeyService that manages the keys clients authenticate with
+	server.APIKeys = &APIKeyService{Logger: server.Logger}
+	server.APIKeys.RegisterStorage(storage)
+
+	// Pass the direct messages received by the order service on to the node service
+	server.Orders.RegisterDirectMessageReceiver(server.Nodes)
+
+	// Let the channel service report the channel activity the order service sees
+	server.Channels.RegisterChannelActivity(server.Orders)
+
+	// Let websocket clients call the same operations as gRPC clients
+	if websocket != nil {
+		websocket.RegisterHandlers(server.Orders, server.Channels, server.Nodes, server)
+	}
+
 	return server
 }
 
+// RegisterLimiter limits the messages and open orders other peers send, and reports the peers that exceed the limits
+func (server *Server) RegisterLimiter(limiter interfaces.Limiter) {
+	server.Orders.RegisterLimiter(limiter)
+	server.Nodes.RegisterLimiter(limiter)
+}
+
 // Run runs the gRPC server
 func (server *Server) Run(port uint) {
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	lis, err := net.Listen("tcp", net.JoinHostPort(server.Address, fmt.Sprint(port)))
 	if !errors.IsEmpty(err) {
 		server.Logger.Fatal(errors.E(errors.Op("Listen"), err))
 	}
 
-	opts := []grpc.ServerOption{}
+	opts, err := server.getServerOptions()
+	if !errors.IsEmpty(err) {
+		server.Logger.Fatal(errors.E(errors.Op("Configure gRPC server"), err))
+	}
 	server.grpc = grpc.NewServer(opts...)
 
 	// Register the Services with the RPC server
 	pb.RegisterOrderHandlerServer(server.grpc, server.Orders)
 	pb.RegisterChannelHandlerServer(server.grpc, server.Channels)
+	pb.RegisterNodeHandlerServer(server.grpc, server.Nodes)
+	pb.RegisterAPIKeyHandlerServer(server.grpc, server.APIKeys)
 
 	// Run the server
 	server.grpc.Serve(lis)
 }
 
-// Close gracefully shuts down the gRPC server
+// Close gracefully shuts down the gRPC server and the gateway
 func (server *Server) Close() {
+	if server.gateway != nil {
+		server.Logger.Debug("REST/JSON gateway shutting down")
+		server.gateway.Shutdown(context.Background())
+	}
 	server.Logger.Debug("gRPC API shutting down")
 	server.grpc.GracefulStop()
 }
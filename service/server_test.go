@@ -2,19 +2,36 @@ package service
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"net/http"
+	"os"
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/pb"
 	"github.com/sprawl/sprawl/util"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const serverTestKey string = "serverTestKey"
 const serverTestEntry string = "serverTestEntry"
 const apiPort string = "1337"
 const serverAddr string = "localhost:1337"
+const gatewayPort uint = 8080
+const gatewayAddr string = "http://localhost:8080"
+const securePort uint = 1338
+const secureAddr string = "localhost:1338"
+const secureGatewayAddr string = "https://localhost:8080"
+const serverTestToken string = "serverTestToken"
 
 func TestServerCreation(t *testing.T) {
 	p2pInstance.Run()
@@ -28,8 +45,10 @@ func TestServerCreation(t *testing.T) {
 	assert.NotNil(t, server)
 	assert.Equal(t, server.Orders.Storage, storage)
 	assert.Equal(t, server.Channels.Storage, storage)
+	assert.Equal(t, server.Nodes.Storage, storage)
 	assert.Equal(t, server.Orders.P2p, p2pInstance)
 	assert.Equal(t, server.Channels.P2p, p2pInstance)
+	assert.Equal(t, server.Nodes.P2p, p2pInstance)
 
 	var err error
 
@@ -61,4 +80,119 @@ func TestServerRun(t *testing.T) {
 	resp, err := client.GetAllOrders(context.Background(), &pb.Empty{})
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
+
+	nodeClient := pb.NewNodeHandlerClient(conn)
+	nodeInfo, err := nodeClient.GetNodeInfo(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, p2pInstance.GetHostIDString(), nodeInfo.GetId())
+}
+
+func TestGatewayRun(t *testing.T) {
+	p2pInstance.Run()
+	storage.Run()
+	defer storage.Close()
+	defer p2pInstance.Close()
+
+	server := NewServer(log, storage, p2pInstance, nil)
+	port, err := strconv.ParseUint(apiPort, 10, 64)
+	assert.NoError(t, err)
+	go server.Run(uint(port))
+	go server.RunGateway(gatewayPort, uint(port))
+	defer server.Close()
+
+	var resp *http.Response
+	waitFor(t, func() bool {
+		resp, err = http.Get(gatewayAddr + "/v1/node")
+		return errors.IsEmpty(err)
+	}, 5*time.Second)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	nodeInfo := &pb.NodeInfo{}
+	assert.NoError(t, jsonpb.Unmarshal(resp.Body, nodeInfo))
+	assert.Equal(t, p2pInstance.GetHostIDString(), nodeInfo.GetId())
+
+	// Errors keep their gRPC status, mapped to HTTP status codes
+	missing, err := http.Get(gatewayAddr + "/v1/channels/" + base64.URLEncoding.EncodeToString([]byte("missing")))
+	assert.NoError(t, err)
+	defer missing.Body.Close()
+	assert.Equal(t, http.StatusNotFound, missing.StatusCode)
+}
+
+func TestServerSecurity(t *testing.T) {
+	p2pInstance.Run()
+	storage.Run()
+	defer storage.Close()
+	defer p2pInstance.Close()
+
+	certFile, keyFile := writeTestCertificate(t)
+	defer os.Remove(certFile)
+	defer os.Remove(keyFile)
+
+	server := NewServer(log, storage, p2pInstance, nil)
+	server.Address = "localhost"
+	server.CertFile = certFile
+	server.KeyFile = keyFile
+	server.ClientCAFile = certFile
+	server.APIToken = serverTestToken
+	go server.Run(securePort)
+	go server.RunGateway(gatewayPort, securePort)
+	defer server.Close()
+
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	assert.NoError(t, err)
+	rootCAs, err := loadCertPool(certFile)
+	assert.NoError(t, err)
+	dial := func(certificates []tls.Certificate) pb.NodeHandlerClient {
+		creds := credentials.NewTLS(&tls.Config{RootCAs: rootCAs, Certificates: certificates})
+		conn, err := grpc.Dial(secureAddr, grpc.WithTransportCredentials(creds))
+		assert.NoError(t, err)
+		return pb.NewNodeHandlerClient(conn)
+	}
+	authenticated := metadata.AppendToOutgoingContext(context.Background(), authorizationHeader, bearerPrefix+serverTestToken)
+
+	// A client with a certificate and the token gets in
+	client := dial([]tls.Certificate{certificate})
+	waitFor(t, func() bool {
+		_, err = client.GetNodeInfo(authenticated, &pb.Empty{})
+		return errors.IsEmpty(err)
+	}, 5*time.Second)
+
+	_, err = client.GetNodeInfo(context.Background(), &pb.Empty{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	wrongToken := metadata.AppendToOutgoingContext(context.Background(), authorizationHeader, bearerPrefix+"wrong")
+	_, err = client.GetNodeInfo(wrongToken, &pb.Empty{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Streams are authenticated too
+	stream, err := client.ReceiveDirectMessages(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Without a client certificate the TLS handshake fails
+	_, err = dial(nil).GetNodeInfo(authenticated, &pb.Empty{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// The gateway serves HTTPS, requires client certificates like the gRPC API and passes the bearer token on
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs, Certificates: []tls.Certificate{certificate}}}}
+	request, err := http.NewRequest(http.MethodGet, secureGatewayAddr+"/v1/node", nil)
+	assert.NoError(t, err)
+	var resp *http.Response
+	waitFor(t, func() bool {
+		resp, err = httpClient.Do(request)
+		return errors.IsEmpty(err)
+	}, 5*time.Second)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	request.Header.Set("Authorization", bearerPrefix+serverTestToken)
+	withoutCertificate := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs}}}
+	_, err = withoutCertificate.Do(request)
+	assert.Error(t, err)
+
+	resp, err = httpClient.Do(request)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
 }
@@ -0,0 +1,524 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/reconcile"
+)
+
+func getTombstoneStorageKey(channelID []byte, orderID []byte) []byte {
+	return []byte(strings.Join([]string{string(interfaces.TombstonePrefix), string(channelID), string(orderID)}, ""))
+}
+
+func getTombstoneQueryPrefix(channelID []byte) []byte {
+	return []byte(strings.Join([]string{string(interfaces.TombstonePrefix), string(channelID)}, ""))
+}
+
+// getChannelOrders returns the orders of exactly this channel, keyed by order ID,
+// leaving out the orders of private channels whose IDs start with this channel's ID
+func getChannelOrders(storage interfaces.Storage, channelID []byte) (map[string]*pb.Order, error) {
+	data, err := storage.GetAllWithPrefix(string(getOrderQueryPrefix(channelID)))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get channel orders"), err)
+	}
+
+	orders := make(map[string]*pb.Order)
+	for key, value := range data {
+		order := &pb.Order{}
+		err = proto.Unmarshal([]byte(value), order)
+		if !errors.IsEmpty(err) || key != string(getOrderStorageKey(channelID, order.GetId())) {
+			continue
+		}
+		orders[string(order.GetId())] = order
+	}
+	return orders, nil
+}
+
+// getChannelTombstones returns the tombstones of the orders deleted from exactly this channel, keyed by order ID
+func getChannelTombstones(storage interfaces.Storage, channelID []byte) (map[string]*pb.Tombstone, error) {
+	data, err := storage.GetAllWithPrefix(string(getTombstoneQueryPrefix(channelID)))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get channel tombstones"), err)
+	}
+
+	tombstones := make(map[string]*pb.Tombstone)
+	for key, value := range data {
+		tombstone := &pb.Tombstone{}
+		err = proto.Unmarshal([]byte(value), tombstone)
+		if !errors.IsEmpty(err) || key != string(getTombstoneStorageKey(channelID, tombstone.GetOrderID())) {
+			continue
+		}
+		tombstones[string(tombstone.GetOrderID())] = tombstone
+	}
+	return tombstones, nil
+}
+
+// storeTombstone records that an order was deleted, so that syncing with peers who missed the deletion doesn't bring it back
+func (s *OrderService) storeTombstone(channelID []byte, order *pb.Order) error {
+	// A deleted order carries its creator's signature of the deletion, which the tombstone keeps along with the order
+	deleted := *order
+	deleted.StateSignature = nil
+	tombstone := &pb.Tombstone{OrderID: order.GetId(), Nonce: order.GetNonce(), Deleted: ptypes.TimestampNow(), Order: &deleted, Signature: order.GetStateSignature()}
+	tombstoneInBytes, err := proto.Marshal(tombstone)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal tombstone"), err)
+	}
+	return s.Storage.Put(getTombstoneStorageKey(channelID, order.GetId()), tombstoneInBytes)
+}
+
+// syncState is the local state of a channel's order book, summarized for reconciliation
+type syncState struct {
+	set        *reconcile.Set
+	orders     map[string]*pb.Order
+	tombstones map[string]*pb.Tombstone
+}
+
+func (s *OrderService) getSyncState(channelID []byte) (*syncState, error) {
+	orders, err := getChannelOrders(s.Storage, channelID)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	tombstones, err := getChannelTombstones(s.Storage, channelID)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+
+	set := reconcile.NewSet(nil)
+	for _, order := range orders {
+		set.Add(reconcile.Item{ID: order.GetId(), Nonce: order.GetNonce()})
+	}
+	for _, tombstone := range tombstones {
+		set.Add(reconcile.Item{ID: tombstone.GetOrderID(), Nonce: tombstone.GetNonce(), Deleted: true})
+	}
+	return &syncState{set: set, orders: orders, tombstones: tombstones}, nil
+}
+
+// update collects the orders and tombstones with the given IDs into a SyncUpdate
+func (state *syncState) update(ids [][]byte) *pb.SyncUpdate {
+	update := &pb.SyncUpdate{}
+	for _, id := range ids {
+		if tombstone, ok := state.tombstones[string(id)]; ok {
+			update.Tombstones = append(update.Tombstones, tombstone)
+		} else if order, ok := state.orders[string(id)]; ok {
+			update.Orders = append(update.Orders, order)
+		}
+	}
+	return update
+}
+
+// sendSyncMessage seals a sync message for the channel and sends it to a single peer
+func (s *OrderService) sendSyncMessage(channelID []byte, op pb.Operation, message proto.Message, to peer.ID) error {
+	data, err := proto.Marshal(message)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal sync message"), err)
+	}
+	data, err = s.sealForChannel(channelID, data)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Seal sync message for channel"), err)
+	}
+	return s.sendToPeer(to, &pb.WireMessage{ChannelID: channelID, Operation: op, Data: data})
+}
+
+// sendFingerprints answers a sync request with the fingerprints of our order book's buckets
+func (s *OrderService) sendFingerprints(channelID []byte, from peer.ID) error {
+	fingerprints, err := s.getFingerprints(channelID)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	return s.sendSyncMessage(channelID, pb.Operation_SYNC_FINGERPRINTS, fingerprints, from)
+}
+
+// getAllOrders returns all of the channel's orders in a SyncUpdate without tombstones.
+// Nodes from before set reconciliation read it as the OrderList they sync with, since the orders are its first field.
+func (s *OrderService) getAllOrders(channelID []byte) (*pb.SyncUpdate, error) {
+	orders, err := getChannelOrders(s.Storage, channelID)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get channel orders"), err)
+	}
+	update := &pb.SyncUpdate{}
+	for _, order := range orders {
+		update.Orders = append(update.Orders, order)
+	}
+	return update, nil
+}
+
+// sendAllOrders answers the sync request of a node from before set reconciliation with all of the channel's orders
+func (s *OrderService) sendAllOrders(channelID []byte, from peer.ID) error {
+	update, err := s.getAllOrders(channelID)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	s.recordSyncResult(channelID, func(results *pb.SyncStatus) {
+		results.ItemsSent += uint64(len(update.GetOrders()))
+	})
+	s.recordSyncItems(update, nil)
+	return s.sendSyncMessage(channelID, pb.Operation_SYNC_RECEIVE, update, from)
+}
+
+// receiveFingerprints compares the peer's bucket fingerprints with ours, and sends our items in the buckets that differ
+func (s *OrderService) receiveFingerprints(channelID []byte, data []byte, from peer.ID) error {
+	fingerprints := &pb.SyncFingerprints{}
+	err := proto.Unmarshal(data, fingerprints)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal sync fingerprints"), err)
+	}
+	s.startSync(channelID, from)
+
+	digest, err := s.getDigest(channelID, fingerprints)
+	if !errors.IsEmpty(err) {
+		s.finishSync(channelID, from, false)
+		return err
+	}
+	if digest == nil {
+		s.Logger.Debugf("Channel %s is in sync with %s", channelID, from)
+		s.recordSyncResult(channelID, func(results *pb.SyncStatus) {
+			results.LastInSync = ptypes.TimestampNow()
+		})
+		s.finishSync(channelID, from, true)
+		return nil
+	}
+	err = s.sendSyncMessage(channelID, pb.Operation_SYNC_DIGEST, digest, from)
+	if !errors.IsEmpty(err) {
+		s.finishSync(channelID, from, false)
+	}
+	return err
+}
+
+// receiveDigest compares the peer's items with ours, sending the orders and tombstones the peer is missing
+// and asking for the ones we are missing
+func (s *OrderService) receiveDigest(channelID []byte, data []byte, from peer.ID) error {
+	digest := &pb.SyncDigest{}
+	err := proto.Unmarshal(data, digest)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal sync digest"), err)
+	}
+
+	update, err := s.getUpdate(channelID, digest)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if update == nil {
+		return nil
+	}
+	s.recordSyncResult(channelID, func(results *pb.SyncStatus) {
+		results.ItemsSent += uint64(len(update.GetOrders()) + len(update.GetTombstones()))
+	})
+	s.recordSyncItems(update, nil)
+	return s.sendSyncMessage(channelID, pb.Operation_SYNC_RECEIVE, update, from)
+}
+
+// receiveSyncUpdate applies the orders and tombstones a peer sent, and answers with the ones it asked for
+func (s *OrderService) receiveSyncUpdate(channelID []byte, data []byte, from peer.ID) error {
+	update := &pb.SyncUpdate{}
+	err := proto.Unmarshal(data, update)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal sync update"), err)
+	}
+
+	reply, err := s.applyUpdate(channelID, update)
+	if !errors.IsEmpty(err) {
+		s.finishSync(channelID, from, false)
+		return err
+	}
+	s.Logger.Debugf("Synced %d orders and %d tombstones on channel %s from %s", len(update.GetOrders()), len(update.GetTombstones()), channelID, from)
+	s.recordSyncResult(channelID, func(results *pb.SyncStatus) {
+		results.OrdersReceived += uint64(len(update.GetOrders()))
+		results.TombstonesReceived += uint64(len(update.GetTombstones()))
+		results.LastRepair = ptypes.TimestampNow()
+		if reply != nil {
+			results.ItemsSent += uint64(len(reply.GetOrders()) + len(reply.GetTombstones()))
+		}
+	})
+	s.recordSyncItems(reply, update)
+	// The peer that sent its fingerprints is done once its update is applied, what it asked for is on its side
+	s.finishSync(channelID, from, true)
+
+	if reply == nil {
+		return nil
+	}
+	return s.sendSyncMessage(channelID, pb.Operation_SYNC_RECEIVE, reply, from)
+}
+
+// getFingerprints returns the fingerprints of the non-empty buckets of the channel's order book
+func (s *OrderService) getFingerprints(channelID []byte) (*pb.SyncFingerprints, error) {
+	state, err := s.getSyncState(channelID)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get sync state"), err)
+	}
+
+	fingerprints := &pb.SyncFingerprints{}
+	for index, fingerprint := range state.set.Fingerprints() {
+		fingerprints.Buckets = append(fingerprints.Buckets, &pb.SyncBucket{Index: index, Fingerprint: fingerprint})
+	}
+	sort.Slice(fingerprints.Buckets, func(i, j int) bool {
+		return fingerprints.Buckets[i].GetIndex() < fingerprints.Buckets[j].GetIndex()
+	})
+	return fingerprints, nil
+}
+
+// getDigest returns our items in the buckets whose fingerprints differ from the peer's, or nil if there are none
+func (s *OrderService) getDigest(channelID []byte, fingerprints *pb.SyncFingerprints) (*pb.SyncDigest, error) {
+	state, err := s.getSyncState(channelID)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get sync state"), err)
+	}
+
+	remote := make(map[uint32][]byte)
+	for _, bucket := range fingerprints.GetBuckets() {
+		remote[bucket.GetIndex()] = bucket.GetFingerprint()
+	}
+	buckets := state.set.DifferingBuckets(remote)
+	if len(buckets) == 0 {
+		return nil, nil
+	}
+
+	digest := &pb.SyncDigest{Buckets: buckets}
+	for _, item := range state.set.Items(buckets) {
+		digest.Items = append(digest.Items, &pb.SyncItem{Id: item.ID, Nonce: item.Nonce, Deleted: item.Deleted})
+	}
+	return digest, nil
+}
+
+// getUpdate returns the orders and tombstones that are newer than the peer's items in the digest,
+// and the IDs of the peer's items that are newer than ours, or nil if there are no differences
+func (s *OrderService) getUpdate(channelID []byte, digest *pb.SyncDigest) (*pb.SyncUpdate, error) {
+	state, err := s.getSyncState(channelID)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get sync state"), err)
+	}
+
+	remote := make([]reconcile.Item, 0, len(digest.GetItems()))
+	for _, item := range digest.GetItems() {
+		remote = append(remote, reconcile.Item{ID: item.GetId(), Nonce: item.GetNonce(), Deleted: item.GetDeleted()})
+	}
+	wanted, newer := state.set.Compare(digest.GetBuckets(), remote)
+
+	newerIDs := make([][]byte, 0, len(newer))
+	for _, item := range newer {
+		newerIDs = append(newerIDs, item.ID)
+	}
+	update := state.update(newerIDs)
+	update.Wanted = wanted
+	if len(update.GetOrders()) == 0 && len(update.GetTombstones()) == 0 && len(update.GetWanted()) == 0 {
+		return nil, nil
+	}
+	return update, nil
+}
+
+// applyUpdate stores the orders and tombstones of an update that are newer than ours,
+// and returns the ones the peer asked for, or nil if it didn't ask for any
+func (s *OrderService) applyUpdate(channelID []byte, update *pb.SyncUpdate) (*pb.SyncUpdate, error) {
+	state, err := s.getSyncState(channelID)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get sync state"), err)
+	}
+
+	for _, tombstone := range update.GetTombstones() {
+		if _, ok := state.tombstones[string(tombstone.GetOrderID())]; ok {
+			continue
+		}
+		// Only the order's creator can delete it
+		err = s.verifySyncedTombstone(tombstone, state.orders[string(tombstone.GetOrderID())])
+		if !errors.IsEmpty(err) {
+			s.Logger.Debug(errors.E(errors.Op("Skip synced tombstone"), err))
+			continue
+		}
+		err = s.Storage.Delete(getOrderStorageKey(channelID, tombstone.GetOrderID()))
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Delete synced tombstone's order"), err)
+		}
+		tombstoneInBytes, err := proto.Marshal(tombstone)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Marshal synced tombstone"), err)
+		}
+		err = s.Storage.Put(getTombstoneStorageKey(channelID, tombstone.GetOrderID()), tombstoneInBytes)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Put synced tombstone"), err)
+		}
+		s.releaseOrder(channelID, tombstone.GetOrder())
+		state.set.Add(reconcile.Item{ID: tombstone.GetOrderID(), Nonce: tombstone.GetNonce(), Deleted: true})
+		s.publishEvent(pb.EventType_ORDER_DELETED, channelID, &pb.Order{Id: tombstone.GetOrderID(), Nonce: tombstone.GetNonce()}, false)
+	}
+
+	for _, order := range update.GetOrders() {
+		item := reconcile.Item{ID: order.GetId(), Nonce: order.GetNonce()}
+		existing, ok := state.set.Get(order.GetId())
+		if ok && !item.Newer(existing) {
+			continue
+		}
+		// The order and its state must be signed by its creator, who must be the creator of the order it replaces
+		err = s.verifySyncedOrder(order, state.orders[string(order.GetId())])
+		if !errors.IsEmpty(err) {
+			s.Logger.Debug(errors.E(errors.Op("Skip synced order"), err))
+			continue
+		}
+		// Orders we've never seen have to meet the channel's difficulty, same as when they're published
+		if !ok {
+			err = s.checkWork(channelID, order)
+			if !errors.IsEmpty(err) {
+				s.Logger.Debug(errors.E(errors.Op("Skip synced order"), err))
+				continue
+			}
+		}
+		orderInBytes, err := proto.Marshal(order)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Marshal synced order"), err)
+		}
+		// And count towards their creator's open orders, so the limit can't be dodged by serving orders through sync
+		if !ok && !s.reserveOrder(channelID, order) {
+			s.Logger.Debugf("Skipping synced order %s, its creator has too many open orders on channel %s", order.GetId(), channelID)
+			continue
+		}
+		err = s.Storage.Put(getOrderStorageKey(channelID, order.GetId()), orderInBytes)
+		if !errors.IsEmpty(err) {
+			if !ok {
+				s.releaseOrder(channelID, order)
+			}
+			return nil, errors.E(errors.Op("Put synced order"), err)
+		}
+		s.publishEvent(pb.EventType_ORDER_SYNCED, channelID, order, false)
+	}
+
+	if len(update.GetWanted()) == 0 {
+		return nil, nil
+	}
+	return state.update(update.GetWanted()), nil
+}
+
+// getCreator returns the public key of the order's creator
+func getCreator(order *pb.Order) (crypto.PubKey, error) {
+	if len(order.GetCreator()) == 0 {
+		return nil, errors.E(errors.Op("Get order creator"), fmt.Sprintf("order %s has no creator", order.GetId()))
+	}
+	publicKey, err := crypto.UnmarshalPublicKey(order.GetCreator())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal order creator"), err)
+	}
+	return publicKey, nil
+}
+
+// verifyCreator checks that the order is signed by its creator, and by the creator of the stored order it replaces if there is one
+func (s *OrderService) verifyCreator(order *pb.Order, stored *pb.Order) (crypto.PubKey, error) {
+	publicKey, err := getCreator(order)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	isCreator, err := s.VerifyOrder(publicKey, order)
+	if !errors.IsEmpty(err) || !isCreator {
+		return nil, errors.E(errors.Op("Verify order creator"), fmt.Sprintf("order %s isn't signed by its creator", order.GetId()))
+	}
+	if stored != nil && !bytes.Equal(stored.GetCreator(), order.GetCreator()) {
+		return nil, errors.E(errors.Op("Verify order creator"), fmt.Sprintf("order %s has a different creator than the stored one", order.GetId()))
+	}
+	return publicKey, nil
+}
+
+func getCreatorID(order *pb.Order) (peer.ID, error) {
+	publicKey, err := getCreator(order)
+	if !errors.IsEmpty(err) {
+		return "", err
+	}
+	creatorID, err := peer.IDFromPublicKey(publicKey)
+	if !errors.IsEmpty(err) {
+		return "", errors.E(errors.Op("Get order creator ID"), err)
+	}
+	return creatorID, nil
+}
+
+// reserveOrder counts a synced order towards the open orders of its creator, and tells if the creator was still under its limit
+func (s *OrderService) reserveOrder(channelID []byte, order *pb.Order) bool {
+	if s.limiter == nil {
+		return true
+	}
+	creatorID, err := getCreatorID(order)
+	if !errors.IsEmpty(err) {
+		return false
+	}
+	return s.limiter.ReserveOrder(channelID, creatorID, order.GetId())
+}
+
+// releaseOrder stops counting a deleted order towards the open orders of its creator, which it was reserved for when received
+func (s *OrderService) releaseOrder(channelID []byte, order *pb.Order) {
+	if s.limiter == nil {
+		return
+	}
+	creatorID, err := getCreatorID(order)
+	if !errors.IsEmpty(err) {
+		return
+	}
+	s.limiter.ReleaseOrder(channelID, creatorID, order.GetId())
+}
+
+// verifySyncedOrder checks that a synced order, and the state it's in, are signed by its creator
+func (s *OrderService) verifySyncedOrder(order *pb.Order, stored *pb.Order) error {
+	publicKey, err := s.verifyCreator(order, stored)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	data, err := getStateData(order.GetId(), order.GetState(), order.GetNonce(), false)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal order state"), err)
+	}
+	valid, err := identity.Verify(publicKey, data, order.GetStateSignature())
+	if !errors.IsEmpty(err) || !valid {
+		return errors.E(errors.Op("Verify order state"), fmt.Sprintf("state of order %s isn't signed by its creator", order.GetId()))
+	}
+	return nil
+}
+
+// verifySyncedTombstone checks that a synced tombstone's order was deleted by its creator
+func (s *OrderService) verifySyncedTombstone(tombstone *pb.Tombstone, stored *pb.Order) error {
+	order := tombstone.GetOrder()
+	if order == nil || !bytes.Equal(order.GetId(), tombstone.GetOrderID()) {
+		return errors.E(errors.Op("Verify tombstone"), fmt.Sprintf("tombstone of order %s doesn't carry the order", tombstone.GetOrderID()))
+	}
+	publicKey, err := s.verifyCreator(order, stored)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	data, err := getStateData(order.GetId(), order.GetState(), tombstone.GetNonce(), true)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal order state"), err)
+	}
+	valid, err := identity.Verify(publicKey, data, tombstone.GetSignature())
+	if !errors.IsEmpty(err) || !valid {
+		return errors.E(errors.Op("Verify tombstone"), fmt.Sprintf("deletion of order %s isn't signed by its creator", tombstone.GetOrderID()))
+	}
+	return nil
+}
+
+func (s *OrderService) recordSyncResult(channelID []byte, record func(results *pb.SyncStatus)) {
+	s.activityLock.Lock()
+	defer s.activityLock.Unlock()
+	if s.syncResults == nil {
+		s.syncResults = make(map[string]*pb.SyncStatus)
+	}
+	results, ok := s.syncResults[string(channelID)]
+	if !ok {
+		results = &pb.SyncStatus{ChannelID: channelID}
+		s.syncResults[string(channelID)] = results
+	}
+	record(results)
+}
+
+// GetSyncResults returns how many orders and tombstones syncing has exchanged on a channel, and when it was last in sync or repaired
+func (s *OrderService) GetSyncResults(channelID []byte) *pb.SyncStatus {
+	s.activityLock.RLock()
+	defer s.activityLock.RUnlock()
+	results, ok := s.syncResults[string(channelID)]
+	if !ok {
+		return &pb.SyncStatus{ChannelID: channelID}
+	}
+	return proto.Clone(results).(*pb.SyncStatus)
+}
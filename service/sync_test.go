@@ -0,0 +1,260 @@
+package service
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/limits"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSyncTestService() *OrderService {
+	return &OrderService{Logger: new(util.PlaceholderLogger), Storage: &inmemory.Storage{Db: make(map[string]string)}}
+}
+
+func createSyncTestOrder(t *testing.T, orderService *OrderService, channelID []byte) *pb.Order {
+	resp, err := orderService.Create(ctx, &pb.CreateRequest{ChannelID: channelID, Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	return resp.GetCreatedOrder()
+}
+
+func TestChannelOrdersExcludePrivateChannels(t *testing.T) {
+	orderService := newSyncTestService()
+	order := createSyncTestOrder(t, orderService, []byte(assetPair))
+	createSyncTestOrder(t, orderService, []byte(assetPair+privateChannelSeparator+"private"))
+
+	orders, err := getChannelOrders(orderService.Storage, []byte(assetPair))
+	assert.NoError(t, err)
+	assert.Len(t, orders, 1)
+	assert.Contains(t, orders, string(order.GetId()))
+}
+
+func TestSyncReconciliation(t *testing.T) {
+	local := newSyncTestService()
+	remote := newSyncTestService()
+	channelID := []byte(assetPair)
+
+	// Both nodes start from the same order book
+	shared := []*pb.Order{
+		createSyncTestOrder(t, local, channelID),
+		createSyncTestOrder(t, local, channelID),
+		createSyncTestOrder(t, local, channelID),
+	}
+	reply, err := remote.applyUpdate(channelID, &pb.SyncUpdate{Orders: shared})
+	assert.NoError(t, err)
+	assert.Nil(t, reply)
+
+	// Then the local node locks and deletes an order, while the remote creates one
+	_, err = local.Lock(ctx, &pb.OrderSpecificRequest{ChannelID: channelID, OrderID: shared[1].GetId()})
+	assert.NoError(t, err)
+	_, err = local.Delete(ctx, &pb.OrderSpecificRequest{ChannelID: channelID, OrderID: shared[2].GetId()})
+	assert.NoError(t, err)
+	remoteOrder := createSyncTestOrder(t, remote, channelID)
+
+	// The remote answers our sync request with its fingerprints, and we send our items in the differing buckets
+	fingerprints, err := remote.getFingerprints(channelID)
+	assert.NoError(t, err)
+	digest, err := local.getDigest(channelID, fingerprints)
+	assert.NoError(t, err)
+	assert.NotNil(t, digest)
+	assert.True(t, len(digest.GetBuckets()) <= 3)
+
+	// The remote sends what we're missing and asks for what it's missing
+	update, err := remote.getUpdate(channelID, digest)
+	assert.NoError(t, err)
+	assert.Len(t, update.GetOrders(), 1)
+	assert.True(t, proto.Equal(remoteOrder, update.GetOrders()[0]))
+	assert.ElementsMatch(t, [][]byte{shared[1].GetId(), shared[2].GetId()}, update.GetWanted())
+
+	reply, err = local.applyUpdate(channelID, update)
+	assert.NoError(t, err)
+	assert.Len(t, reply.GetOrders(), 1)
+	assert.Len(t, reply.GetTombstones(), 1)
+	assert.Empty(t, reply.GetWanted())
+
+	reply, err = remote.applyUpdate(channelID, reply)
+	assert.NoError(t, err)
+	assert.Nil(t, reply)
+
+	// Both order books are now the same
+	localFingerprints, err := local.getFingerprints(channelID)
+	assert.NoError(t, err)
+	remoteFingerprints, err := remote.getFingerprints(channelID)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(localFingerprints, remoteFingerprints))
+	digest, err = local.getDigest(channelID, remoteFingerprints)
+	assert.NoError(t, err)
+	assert.Nil(t, digest)
+
+	remoteOrders, err := getChannelOrders(remote.Storage, channelID)
+	assert.NoError(t, err)
+	assert.Len(t, remoteOrders, 3)
+	assert.Equal(t, pb.State_LOCKED, remoteOrders[string(shared[1].GetId())].GetState())
+	assert.NotContains(t, remoteOrders, string(shared[2].GetId()))
+
+	// A stale copy of a deleted order doesn't come back
+	reply, err = remote.applyUpdate(channelID, &pb.SyncUpdate{Orders: []*pb.Order{shared[2]}})
+	assert.NoError(t, err)
+	assert.Nil(t, reply)
+	remoteOrders, err = getChannelOrders(remote.Storage, channelID)
+	assert.NoError(t, err)
+	assert.NotContains(t, remoteOrders, string(shared[2].GetId()))
+}
+
+func TestSyncResults(t *testing.T) {
+	local := newSyncTestService()
+	remote := newSyncTestService()
+	channelID := []byte(assetPair)
+	_, publicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	from, err := peer.IDFromPublicKey(publicKey)
+	assert.NoError(t, err)
+
+	order := createSyncTestOrder(t, remote, channelID)
+	update, err := proto.Marshal(&pb.SyncUpdate{Orders: []*pb.Order{order}})
+	assert.NoError(t, err)
+	assert.NoError(t, local.receiveSyncUpdate(channelID, update, from))
+
+	fingerprints, err := remote.getFingerprints(channelID)
+	assert.NoError(t, err)
+	marshaledFingerprints, err := proto.Marshal(fingerprints)
+	assert.NoError(t, err)
+	assert.NoError(t, local.receiveFingerprints(channelID, marshaledFingerprints, from))
+
+	channelService := &ChannelService{Logger: new(util.PlaceholderLogger), Storage: local.Storage}
+	channelService.RegisterChannelActivity(local)
+	status := channelService.getSyncStatus(channelID)
+	assert.Equal(t, channelID, status.GetChannelID())
+	assert.Equal(t, uint64(1), status.GetOrdersReceived())
+	assert.Equal(t, uint64(0), status.GetTombstonesReceived())
+	assert.NotNil(t, status.GetLastRepair())
+	assert.NotNil(t, status.GetLastInSync())
+}
+
+func TestSyncRejectsForgeries(t *testing.T) {
+	local := newSyncTestService()
+	remote := newSyncTestService()
+	attacker := newSyncTestService()
+	channelID := []byte(assetPair)
+
+	order := createSyncTestOrder(t, local, channelID)
+	_, err := remote.applyUpdate(channelID, &pb.SyncUpdate{Orders: []*pb.Order{order}})
+	assert.NoError(t, err)
+
+	// A peer can't change the state or the price of someone else's order
+	locked := proto.Clone(order).(*pb.Order)
+	locked.State = pb.State_LOCKED
+	locked.Nonce++
+	repriced := proto.Clone(order).(*pb.Order)
+	repriced.Price *= 2
+	repriced.Nonce++
+	_, err = remote.applyUpdate(channelID, &pb.SyncUpdate{Orders: []*pb.Order{locked}})
+	assert.NoError(t, err)
+	_, err = remote.applyUpdate(channelID, &pb.SyncUpdate{Orders: []*pb.Order{repriced}})
+	assert.NoError(t, err)
+
+	// Nor replace it with an order of its own under the same ID
+	stolen := createSyncTestOrder(t, attacker, channelID)
+	stolen.Id = order.GetId()
+	stolen.Nonce = order.GetNonce() + 1
+	stolen.Signature, err = attacker.GetSignature(stolen)
+	assert.NoError(t, err)
+	assert.NoError(t, attacker.signState(stolen, false))
+	_, err = remote.applyUpdate(channelID, &pb.SyncUpdate{Orders: []*pb.Order{stolen}})
+	assert.NoError(t, err)
+
+	// Nor delete it
+	forgedTombstone := &pb.Tombstone{OrderID: order.GetId(), Nonce: order.GetNonce(), Order: order}
+	assert.NoError(t, attacker.signState(order, true))
+	forgedTombstone.Signature = order.GetStateSignature()
+	_, err = remote.applyUpdate(channelID, &pb.SyncUpdate{Tombstones: []*pb.Tombstone{forgedTombstone, {OrderID: order.GetId()}}})
+	assert.NoError(t, err)
+
+	remoteOrders, err := getChannelOrders(remote.Storage, channelID)
+	assert.NoError(t, err)
+	assert.Len(t, remoteOrders, 1)
+	assert.Equal(t, pb.State_OPEN, remoteOrders[string(order.GetId())].GetState())
+	assert.Equal(t, float32(testPrice), remoteOrders[string(order.GetId())].GetPrice())
+	assert.Equal(t, local.Storage.(*inmemory.Storage).Db[string(getOrderStorageKey(channelID, order.GetId()))], remote.Storage.(*inmemory.Storage).Db[string(getOrderStorageKey(channelID, order.GetId()))])
+}
+
+func TestSyncedTombstoneReleasesOrder(t *testing.T) {
+	local := newSyncTestService()
+	remote := newSyncTestService()
+	limiter := limits.NewLimiter(0, 0, 1, nil)
+	remote.RegisterLimiter(limiter)
+	channelID := []byte(assetPair)
+
+	// The remote received the order through gossip, which counts it towards the creator's open orders
+	order := createSyncTestOrder(t, local, channelID)
+	creatorKey, err := getCreator(order)
+	assert.NoError(t, err)
+	creator, err := peer.IDFromPublicKey(creatorKey)
+	assert.NoError(t, err)
+	assert.True(t, limiter.ReserveOrder(channelID, creator, order.GetId()))
+	_, err = remote.applyUpdate(channelID, &pb.SyncUpdate{Orders: []*pb.Order{order}})
+	assert.NoError(t, err)
+
+	// It only learns of the deletion through sync, after which the creator can open another order
+	_, err = local.Delete(ctx, &pb.OrderSpecificRequest{ChannelID: channelID, OrderID: order.GetId()})
+	assert.NoError(t, err)
+	tombstones, err := getChannelTombstones(local.Storage, channelID)
+	assert.NoError(t, err)
+	_, err = remote.applyUpdate(channelID, &pb.SyncUpdate{Tombstones: []*pb.Tombstone{tombstones[string(order.GetId())]}})
+	assert.NoError(t, err)
+	assert.True(t, limiter.ReserveOrder(channelID, creator, []byte("another order")))
+}
+
+func TestSyncedOrdersCountTowardsLimit(t *testing.T) {
+	local := newSyncTestService()
+	remote := newSyncTestService()
+	remote.RegisterLimiter(limits.NewLimiter(0, 0, 1, nil))
+	channelID := []byte(assetPair)
+
+	// Orders over their creator's open order limit aren't taken in through sync either
+	first := createSyncTestOrder(t, local, channelID)
+	second := createSyncTestOrder(t, local, channelID)
+	_, err := remote.applyUpdate(channelID, &pb.SyncUpdate{Orders: []*pb.Order{first, second}})
+	assert.NoError(t, err)
+	remoteOrders, err := getChannelOrders(remote.Storage, channelID)
+	assert.NoError(t, err)
+	assert.Len(t, remoteOrders, 1)
+	assert.Contains(t, remoteOrders, string(first.GetId()))
+
+	// The orders already stored can still change
+	_, err = local.Lock(ctx, &pb.OrderSpecificRequest{ChannelID: channelID, OrderID: first.GetId()})
+	assert.NoError(t, err)
+	localOrders, err := getChannelOrders(local.Storage, channelID)
+	assert.NoError(t, err)
+	_, err = remote.applyUpdate(channelID, &pb.SyncUpdate{Orders: []*pb.Order{localOrders[string(first.GetId())]}})
+	assert.NoError(t, err)
+	remoteOrders, err = getChannelOrders(remote.Storage, channelID)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_LOCKED, remoteOrders[string(first.GetId())].GetState())
+}
+
+func TestLegacySyncAnswer(t *testing.T) {
+	orderService := newSyncTestService()
+	channelID := []byte(assetPair)
+	kept := createSyncTestOrder(t, orderService, channelID)
+	deleted := createSyncTestOrder(t, orderService, channelID)
+	_, err := orderService.Delete(ctx, &pb.OrderSpecificRequest{ChannelID: channelID, OrderID: deleted.GetId()})
+	assert.NoError(t, err)
+
+	// Nodes from before set reconciliation get every order, in a message they read as an OrderList
+	update, err := orderService.getAllOrders(channelID)
+	assert.NoError(t, err)
+	assert.Empty(t, update.GetTombstones())
+	data, err := proto.Marshal(update)
+	assert.NoError(t, err)
+	orderList := &pb.OrderList{}
+	assert.NoError(t, proto.Unmarshal(data, orderList))
+	assert.Len(t, orderList.GetOrders(), 1)
+	assert.True(t, proto.Equal(kept, orderList.GetOrders()[0]))
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// gossipedOperations are the operations that are published on channels. Everything else is only sent directly between peers.
+var gossipedOperations = map[pb.Operation]bool{
+	pb.Operation_CREATE:        true,
+	pb.Operation_DELETE:        true,
+	pb.Operation_LOCK:          true,
+	pb.Operation_UNLOCK:        true,
+	pb.Operation_QUOTE_REQUEST: true,
+}
+
+// Validate checks a message published on a channel before it's passed on to other peers.
+// It rejects what Receive would reject, along with replays of orders we already have a newer version of,
+// but doesn't store anything.
+func (s *OrderService) Validate(channelID []byte, buf []byte, from peer.ID) (err error) {
+	reason := rejectedInvalid
+	defer func() {
+		if !errors.IsEmpty(err) {
+			s.recordRejection(reason)
+		}
+	}()
+
+	wireMessage := &pb.WireMessage{}
+	err = proto.Unmarshal(buf, wireMessage)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal wiremessage proto in Validate"), err)
+	}
+	if wireMessage.GetVersion() > interfaces.WireVersion {
+		reason = rejectedVersion
+		return errors.E(errors.Op("Check wiremessage version"), fmt.Sprintf("unsupported wire version %d", wireMessage.GetVersion()))
+	}
+	if string(wireMessage.GetChannelID()) != string(channelID) {
+		return errors.E(errors.Op("Check channel"), fmt.Sprintf("message for channel %s published on channel %s", wireMessage.GetChannelID(), channelID))
+	}
+	op := wireMessage.GetOperation()
+	if !gossipedOperations[op] {
+		return errors.E(errors.Op("Check operation"), fmt.Sprintf("%s is not published on channels", op))
+	}
+	if s.Storage == nil {
+		return nil
+	}
+
+	data, err := s.openFromChannel(channelID, wireMessage.GetData(), from)
+	if !errors.IsEmpty(err) {
+		reason = rejectedMembership
+		return errors.E(errors.Op("Open channel data"), err)
+	}
+
+	if op == pb.Operation_QUOTE_REQUEST {
+		_, err = checkQuoteRequest(channelID, data, from)
+		return err
+	}
+
+	order := &pb.Order{}
+	err = proto.Unmarshal(data, order)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal order proto"), err)
+	}
+	publicKey, err := from.ExtractPublicKey()
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Extract public key"), err)
+	}
+	isCreator, err := s.VerifyOrder(publicKey, order)
+	if !errors.IsEmpty(err) || !isCreator {
+		reason = rejectedSignature
+		return errors.E(errors.Op("Verify order creator"), fmt.Sprintf("order %s isn't signed by %s", order.GetId(), from))
+	}
+	if op == pb.Operation_CREATE {
+		err = s.checkWork(channelID, order)
+		if !errors.IsEmpty(err) {
+			reason = rejectedWork
+			return err
+		}
+	}
+
+	reason = rejectedReplay
+	return s.checkReplay(channelID, op, order)
+}
+
+// checkReplay rejects order operations that are older than, or the same as, what we already have stored
+func (s *OrderService) checkReplay(channelID []byte, op pb.Operation, order *pb.Order) error {
+	deleted, err := s.Storage.Has(getTombstoneStorageKey(channelID, order.GetId()))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Check tombstone"), err)
+	}
+	if deleted {
+		return errors.E(errors.Op("Check replay"), fmt.Sprintf("order %s has been deleted", order.GetId()))
+	}
+
+	exists, err := s.Storage.Has(getOrderStorageKey(channelID, order.GetId()))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Check order"), err)
+	}
+	if !exists {
+		// We may have missed the order, so pass it on for the peers that haven't
+		return nil
+	}
+
+	switch op {
+	case pb.Operation_CREATE:
+		return errors.E(errors.Op("Check replay"), fmt.Sprintf("order %s already exists", order.GetId()))
+	case pb.Operation_LOCK, pb.Operation_UNLOCK:
+		storedData, err := s.Storage.Get(getOrderStorageKey(channelID, order.GetId()))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Get stored order"), err)
+		}
+		stored := &pb.Order{}
+		err = proto.Unmarshal(storedData, stored)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Unmarshal stored order"), err)
+		}
+		if stored.GetNonce() >= order.GetNonce() {
+			return errors.E(errors.Op("Check replay"), fmt.Sprintf("order %s is already at nonce %d", order.GetId(), stored.GetNonce()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"crypto/rand"
+	"testing"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	orderService := newSyncTestService()
+	channelID := []byte(assetPair)
+	order := createSyncTestOrder(t, orderService, channelID)
+
+	_, signerPublicKey, err := identity.GetIdentity(orderService.Storage)
+	assert.NoError(t, err)
+	signerID, err := peer.IDFromPublicKey(signerPublicKey)
+	assert.NoError(t, err)
+	_, outsiderPublicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	outsiderID, err := peer.IDFromPublicKey(outsiderPublicKey)
+	assert.NoError(t, err)
+
+	// Replays of orders we already have are rejected
+	create := marshalWireMessage(t, channelID, pb.Operation_CREATE, order)
+	assert.Error(t, orderService.Validate(channelID, create, signerID))
+	lockedOrder := *order
+	lock := marshalWireMessage(t, channelID, pb.Operation_LOCK, &lockedOrder)
+	assert.Error(t, orderService.Validate(channelID, lock, signerID))
+	lockedOrder.Nonce++
+	lock = marshalWireMessage(t, channelID, pb.Operation_LOCK, &lockedOrder)
+	assert.NoError(t, orderService.Validate(channelID, lock, signerID))
+
+	// New orders pass, but only from their creator and on their own channel
+	orderService.Storage.DeleteAllWithPrefix(string(interfaces.OrderPrefix))
+	assert.NoError(t, orderService.Validate(channelID, create, signerID))
+	assert.Error(t, orderService.Validate(channelID, create, outsiderID))
+	assert.Error(t, orderService.Validate([]byte("otherChannel"), create, signerID))
+	assert.Error(t, orderService.Validate(channelID, []byte("not a wire message"), signerID))
+
+	// Operations that are only sent directly are never gossiped
+	sync := marshalWireMessage(t, channelID, pb.Operation_SYNC_REQUEST, &pb.Empty{})
+	assert.Error(t, orderService.Validate(channelID, sync, signerID))
+
+	// Deleted orders don't come back
+	assert.NoError(t, orderService.storeTombstone(channelID, order))
+	assert.Error(t, orderService.Validate(channelID, create, signerID))
+}
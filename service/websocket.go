@@ -1,9 +1,18 @@
 package service
 
 import (
+	"bytes"
+	"context"
+	"crypto/subtle"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
 	"github.com/gorilla/websocket"
 	"github.com/sprawl/sprawl/errors"
@@ -11,11 +20,73 @@ import (
 	"github.com/sprawl/sprawl/pb"
 )
 
+// Encodings clients can choose from with the encoding query parameter when they connect
+const (
+	protobufEncoding = "protobuf"
+	jsonEncoding     = "json"
+)
+
+const (
+	// writeTimeout is how long writing a single message to a client can take
+	writeTimeout = 10 * time.Second
+	// pongTimeout is how long a client can go without answering our pings before it's disconnected
+	pongTimeout = 60 * time.Second
+	// pingInterval is shorter than pongTimeout so that pongs have time to arrive
+	pingInterval = pongTimeout * 9 / 10
+	// sendBufferSize is how many messages can wait to be written to a client before it's disconnected as too slow
+	sendBufferSize = 64
+	// maxRequestSize limits the size of the requests clients send
+	maxRequestSize = 64 * 1024
+)
+
+// apiKeyHeader and apiKeyParameter carry the API key for clients that can't send a bearer token,
+// browsers can't set headers on websocket connections at all
+const apiKeyHeader = "X-Api-Key"
+const apiKeyParameter = "apiKey"
+
+// feedMethod is the gRPC method that streams the same events as the feed, clients need its role to connect
+const feedMethod = "/pb.OrderHandler/StreamEvents"
+
 type WebsocketService struct {
-	Connections []*websocket.Conn
-	Logger      interfaces.Logger
-	Port        uint
+	Logger         interfaces.Logger
+	Port           uint
+	MaxConnections uint
+	// AllowedOrigins are the origins of the web pages that can connect besides our own host, "*" allows any
+	AllowedOrigins []string
+	// APIKey lets clients connect to the feed besides the API keys. It doesn't let them call any methods.
+	APIKey string
+	// CertFile and KeyFile enable TLS when both are set
+	CertFile    string
+	KeyFile     string
+	connections map[*websocketConnection]bool
+	lock        sync.RWMutex
 	httpServer  http.Server
+	rpcMethods  map[string]rpcMethod
+	authorizer  interfaces.Authorizer
+}
+
+// websocketConnection is a connected client and the messages it has subscribed to.
+// Clients that never subscribe receive everything, like before subscriptions existed.
+// Messages to the client are queued in send and written by a single goroutine, so a slow client can't hold up the others.
+type websocketConnection struct {
+	conn     *websocket.Conn
+	encoding string
+	// token is what the client authenticated with, its JSON-RPC calls are authorized by it
+	token      string
+	filters    map[string]*pb.WebsocketFilter
+	subscribed bool
+	lock       sync.RWMutex
+	send       chan websocketFrame
+	closed     chan struct{}
+	closeOnce  sync.Once
+	// ctx is cancelled when the connection closes, stopping the JSON-RPC calls the client made
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type websocketFrame struct {
+	messageType int
+	data        []byte
 }
 
 func (ws *WebsocketService) Start() {
@@ -25,7 +96,12 @@ func (ws *WebsocketService) Start() {
 		ws.connect(w, r)
 	})
 	ws.httpServer = http.Server{Addr: "localhost:" + fmt.Sprint(ws.Port), Handler: mux}
-	err := ws.httpServer.ListenAndServe()
+	var err error
+	if ws.CertFile != "" && ws.KeyFile != "" {
+		err = ws.httpServer.ListenAndServeTLS(ws.CertFile, ws.KeyFile)
+	} else {
+		err = ws.httpServer.ListenAndServe()
+	}
 	if !errors.IsEmpty(err) {
 		if ws.Logger != nil {
 			ws.Logger.Error(errors.E(errors.Op("Listen and serve port :"+fmt.Sprint(ws.Port))), err)
@@ -40,15 +116,130 @@ func (ws *WebsocketService) Close() {
 			ws.Logger.Error(errors.E(errors.Op("Close http server")), err)
 		}
 	}
+	// Closing the server doesn't close the connections that were upgraded to websockets
+	for _, connection := range ws.getConnections() {
+		ws.removeConnection(connection)
+	}
+}
+
+// checkOrigin allows clients that aren't browsers, pages from our own host and pages from the allowed origins
+func (ws *WebsocketService) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range ws.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	originURL, err := url.Parse(origin)
+	return errors.IsEmpty(err) && strings.EqualFold(originURL.Host, r.Host)
+}
+
+// getRequestToken returns the key the client sent as a bearer token, in the API key header or as a query parameter
+func getRequestToken(r *http.Request) string {
+	key := r.Header.Get(apiKeyHeader)
+	if authorization := r.Header.Get("Authorization"); strings.HasPrefix(authorization, "Bearer ") {
+		key = strings.TrimPrefix(authorization, "Bearer ")
+	}
+	if key == "" {
+		key = r.URL.Query().Get(apiKeyParameter)
+	}
+	return key
+}
+
+// authenticate lets in clients with the websocket's API key, and clients the authorizer would let stream events over gRPC.
+// When the websocket's API key is set, only clients with it or with a real API key or token are let in.
+func (ws *WebsocketService) authenticate(token string) bool {
+	if ws.APIKey != "" && subtle.ConstantTimeCompare([]byte(token), []byte(ws.APIKey)) == 1 {
+		return true
+	}
+	if ws.authorizer == nil {
+		return ws.APIKey == ""
+	}
+	if ws.APIKey != "" {
+		authenticated, err := ws.authorizer.Authenticate(token)
+		return errors.IsEmpty(err) && authenticated
+	}
+	return errors.IsEmpty(ws.authorizer.Authorize(token, feedMethod))
+}
+
+func (ws *WebsocketService) isFull() bool {
+	return ws.MaxConnections > 0 && uint(len(ws.connections)) >= ws.MaxConnections
+}
+
+func (ws *WebsocketService) getConnections() []*websocketConnection {
+	ws.lock.RLock()
+	defer ws.lock.RUnlock()
+	connections := make([]*websocketConnection, 0, len(ws.connections))
+	for connection := range ws.connections {
+		connections = append(connections, connection)
+	}
+	return connections
+}
+
+func (ws *WebsocketService) addConnection(connection *websocketConnection) bool {
+	ws.lock.Lock()
+	defer ws.lock.Unlock()
+	if ws.isFull() {
+		return false
+	}
+	if ws.connections == nil {
+		ws.connections = make(map[*websocketConnection]bool)
+	}
+	ws.connections[connection] = true
+	return true
+}
+
+// removeConnection forgets a client and closes its connection, it's safe to call more than once
+func (ws *WebsocketService) removeConnection(connection *websocketConnection) {
+	ws.lock.Lock()
+	delete(ws.connections, connection)
+	ws.lock.Unlock()
+	connection.closeOnce.Do(func() {
+		connection.cancel()
+		close(connection.closed)
+		connection.conn.Close()
+	})
 }
 
 func (ws *WebsocketService) connect(w http.ResponseWriter, r *http.Request) {
+	if !ws.checkOrigin(r) {
+		if ws.Logger != nil {
+			ws.Logger.Warnf("Refused websocket connection from origin %s", r.Header.Get("Origin"))
+		}
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	token := getRequestToken(r)
+	if !ws.authenticate(token) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "invalid or missing API key", http.StatusUnauthorized)
+		return
+	}
+
+	encoding := r.URL.Query().Get("encoding")
+	if encoding == "" {
+		encoding = protobufEncoding
+	}
+	if encoding != protobufEncoding && encoding != jsonEncoding {
+		http.Error(w, fmt.Sprintf("unknown encoding %q, use %q or %q", encoding, protobufEncoding, jsonEncoding), http.StatusBadRequest)
+		return
+	}
+
+	ws.lock.RLock()
+	full := ws.isFull()
+	ws.lock.RUnlock()
+	if full {
+		http.Error(w, "too many websocket connections", http.StatusServiceUnavailable)
+		return
+	}
+
 	upgrader := websocket.Upgrader{
 		ReadBufferSize:  1024,
 		WriteBufferSize: 1024,
-	}
-	upgrader.CheckOrigin = func(r *http.Request) bool {
-		return true
+		CheckOrigin:     ws.checkOrigin,
 	}
 
 	conn, err := upgrader.Upgrade(w, r, nil)
@@ -58,26 +249,276 @@ func (ws *WebsocketService) connect(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
-	ws.Connections = append(ws.Connections, conn)
-}
-
-func (ws *WebsocketService) PushToWebsockets(message *pb.WireMessage) {
-	if len(ws.Connections) == 0 {
+	ctx, cancel := context.WithCancel(context.Background())
+	connection := &websocketConnection{
+		conn:     conn,
+		encoding: encoding,
+		token:    token,
+		filters:  make(map[string]*pb.WebsocketFilter),
+		send:     make(chan websocketFrame, sendBufferSize),
+		closed:   make(chan struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	// Others may have connected while this one was upgrading
+	if !ws.addConnection(connection) {
+		cancel()
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too many websocket connections"), time.Now().Add(writeTimeout))
+		conn.Close()
 		return
 	}
-	buf, err := proto.Marshal(message)
-	if !errors.IsEmpty(err) {
+	go ws.writeMessages(connection)
+	go ws.readRequests(connection)
+}
+
+// queue passes a message on to the connection's writer, and disconnects the client if it has fallen too far behind
+func (ws *WebsocketService) queue(connection *websocketConnection, frame websocketFrame) {
+	select {
+	case connection.send <- frame:
+	case <-connection.closed:
+	default:
 		if ws.Logger != nil {
-			ws.Logger.Warn(errors.E(errors.Op("Marshal wiremessage"), err))
+			ws.Logger.Warnf("Disconnecting websocket client %s, it has %d messages waiting", connection.conn.RemoteAddr(), sendBufferSize)
 		}
-		return
+		ws.removeConnection(connection)
 	}
-	for _, conn := range ws.Connections {
-		err := conn.WriteMessage(1, buf)
+}
+
+// writeMessages writes the queued messages to the client and pings it, until the connection is closed or a write fails
+func (ws *WebsocketService) writeMessages(connection *websocketConnection) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	defer ws.removeConnection(connection)
+
+	for {
+		var frame websocketFrame
+		select {
+		case <-connection.closed:
+			return
+		case frame = <-connection.send:
+		case <-ticker.C:
+			frame = websocketFrame{messageType: websocket.PingMessage}
+		}
+		connection.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		err := connection.conn.WriteMessage(frame.messageType, frame.data)
 		if !errors.IsEmpty(err) {
 			if ws.Logger != nil {
-				ws.Logger.Warn(errors.E(errors.Op("Send message with ws"), err))
+				ws.Logger.Debug(errors.E(errors.Op("Send message with ws"), err))
 			}
+			return
 		}
 	}
 }
+
+// readRequests handles the requests a client sends, until its connection is closed.
+// JSON-RPC requests are sent and answered in text messages. Subscription requests can also be sent
+// as protobuf in binary messages or as JSON in text messages, and are always answered in JSON.
+func (ws *WebsocketService) readRequests(connection *websocketConnection) {
+	defer ws.removeConnection(connection)
+	connection.conn.SetReadLimit(maxRequestSize)
+	// Every pong, like every request, proves the client is still there
+	connection.conn.SetReadDeadline(time.Now().Add(pongTimeout))
+	connection.conn.SetPongHandler(func(string) error {
+		return connection.conn.SetReadDeadline(time.Now().Add(pongTimeout))
+	})
+
+	for {
+		messageType, data, err := connection.conn.ReadMessage()
+		if !errors.IsEmpty(err) {
+			return
+		}
+		connection.conn.SetReadDeadline(time.Now().Add(pongTimeout))
+
+		if messageType == websocket.TextMessage && isJSONRPC(data) {
+			if reply := ws.handleJSONRPC(connection.ctx, connection, data); reply != nil {
+				ws.queue(connection, websocketFrame{messageType: websocket.TextMessage, data: reply})
+			}
+			continue
+		}
+
+		request := &pb.WebsocketRequest{}
+		if messageType == websocket.TextMessage {
+			err = jsonpb.Unmarshal(bytes.NewReader(data), request)
+		} else {
+			err = proto.Unmarshal(data, request)
+		}
+
+		var response *pb.WebsocketResponse
+		if !errors.IsEmpty(err) {
+			response = &pb.WebsocketResponse{Error: errors.E(errors.Op("Unmarshal websocket request"), err).Error()}
+		} else {
+			response = connection.handleRequest(request)
+		}
+
+		reply, err := (&jsonpb.Marshaler{}).MarshalToString(response)
+		if !errors.IsEmpty(err) {
+			if ws.Logger != nil {
+				ws.Logger.Warn(errors.E(errors.Op("Marshal websocket response"), err))
+			}
+			continue
+		}
+		ws.queue(connection, websocketFrame{messageType: websocket.TextMessage, data: []byte(reply)})
+	}
+}
+
+// handleRequest updates the connection's subscriptions and returns them.
+// Subscribing replaces the filter of the same channel, unsubscribing without filters removes them all.
+func (connection *websocketConnection) handleRequest(request *pb.WebsocketRequest) *pb.WebsocketResponse {
+	connection.lock.Lock()
+	defer connection.lock.Unlock()
+	connection.subscribed = true
+
+	switch request.GetAction() {
+	case pb.WebsocketAction_SUBSCRIBE:
+		for _, filter := range request.GetFilters() {
+			connection.filters[string(filter.GetChannelID())] = filter
+		}
+	case pb.WebsocketAction_UNSUBSCRIBE:
+		if len(request.GetFilters()) == 0 {
+			connection.filters = make(map[string]*pb.WebsocketFilter)
+		}
+		for _, filter := range request.GetFilters() {
+			delete(connection.filters, string(filter.GetChannelID()))
+		}
+	default:
+		return &pb.WebsocketResponse{Error: fmt.Sprintf("unknown websocket action %s", request.GetAction())}
+	}
+
+	subscriptions := make([]*pb.WebsocketFilter, 0, len(connection.filters))
+	for _, filter := range connection.filters {
+		subscriptions = append(subscriptions, filter)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return string(subscriptions[i].GetChannelID()) < string(subscriptions[j].GetChannelID())
+	})
+	return &pb.WebsocketResponse{Subscriptions: subscriptions}
+}
+
+// accepts tells if the message matches any of the connection's subscriptions
+func (connection *websocketConnection) accepts(message *pb.WireMessage, assets []string) bool {
+	connection.lock.RLock()
+	defer connection.lock.RUnlock()
+	if !connection.subscribed {
+		return true
+	}
+	for _, filter := range connection.filters {
+		if matchesFilter(filter, message, assets) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesFilter tells if a message is on the filter's channel, with one of its operations and about one of its assets.
+// Empty filter fields match everything.
+func matchesFilter(filter *pb.WebsocketFilter, message *pb.WireMessage, assets []string) bool {
+	if len(filter.GetChannelID()) > 0 && !bytes.Equal(filter.GetChannelID(), message.GetChannelID()) {
+		return false
+	}
+	if len(filter.GetOperations()) > 0 {
+		found := false
+		for _, op := range filter.GetOperations() {
+			if op == message.GetOperation() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(filter.GetAssets()) > 0 {
+		for _, asset := range assets {
+			if contains(filter.GetAssets(), asset) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// newWebsocketEvent decodes the order or quote a message carries, so JSON clients don't have to parse protobuf.
+// Data is only kept for messages that carry neither.
+func newWebsocketEvent(message *pb.WireMessage) *pb.WebsocketEvent {
+	event := &pb.WebsocketEvent{ChannelID: message.GetChannelID(), Operation: message.GetOperation()}
+	switch message.GetOperation() {
+	case pb.Operation_CREATE, pb.Operation_DELETE, pb.Operation_LOCK, pb.Operation_UNLOCK:
+		order := &pb.Order{}
+		if proto.Unmarshal(message.GetData(), order) == nil {
+			event.Order = order
+			return event
+		}
+	case pb.Operation_QUOTE_REQUEST:
+		request := &pb.QuoteRequest{}
+		if proto.Unmarshal(message.GetData(), request) == nil {
+			event.QuoteRequest = request
+			return event
+		}
+	case pb.Operation_QUOTE:
+		quote := &pb.Quote{}
+		if proto.Unmarshal(message.GetData(), quote) == nil {
+			event.Quote = quote
+			return event
+		}
+	}
+	event.Data = message.GetData()
+	return event
+}
+
+// getEventAssets returns the assets the order or quote in an event trades, or nil if it carries neither
+func getEventAssets(event *pb.WebsocketEvent) []string {
+	switch {
+	case event.GetOrder() != nil:
+		return []string{event.GetOrder().GetAsset(), event.GetOrder().GetCounterAsset()}
+	case event.GetQuoteRequest() != nil:
+		return []string{event.GetQuoteRequest().GetAsset(), event.GetQuoteRequest().GetCounterAsset()}
+	case event.GetQuote() != nil:
+		return []string{event.GetQuote().GetOrder().GetAsset(), event.GetQuote().GetOrder().GetCounterAsset()}
+	}
+	return nil
+}
+
+// PushToWebsockets sends a message to every client subscribed to it, as a binary WireMessage
+// or as a WebsocketEvent in a JSON text message, depending on the encoding the client chose
+func (ws *WebsocketService) PushToWebsockets(message *pb.WireMessage) {
+	connections := ws.getConnections()
+	if len(connections) == 0 {
+		return
+	}
+	event := newWebsocketEvent(message)
+	assets := getEventAssets(event)
+
+	// Each encoding is marshaled once, when the first client using it needs it
+	encoded := make(map[string][]byte)
+	for _, connection := range connections {
+		if !connection.accepts(message, assets) {
+			continue
+		}
+		buf, ok := encoded[connection.encoding]
+		if !ok {
+			var err error
+			buf, err = encodeMessage(connection.encoding, message, event)
+			if !errors.IsEmpty(err) {
+				if ws.Logger != nil {
+					ws.Logger.Warn(errors.E(errors.Op("Marshal websocket message"), err))
+				}
+				return
+			}
+			encoded[connection.encoding] = buf
+		}
+		messageType := websocket.BinaryMessage
+		if connection.encoding == jsonEncoding {
+			messageType = websocket.TextMessage
+		}
+		ws.queue(connection, websocketFrame{messageType: messageType, data: buf})
+	}
+}
+
+func encodeMessage(encoding string, message *pb.WireMessage, event *pb.WebsocketEvent) ([]byte, error) {
+	if encoding == jsonEncoding {
+		json, err := (&jsonpb.Marshaler{}).MarshalToString(event)
+		return []byte(json), err
+	}
+	return proto.Marshal(message)
+}
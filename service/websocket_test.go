@@ -1,10 +1,23 @@
 package service
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
 	"net/url"
+	"os"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/pb"
@@ -25,10 +38,15 @@ const port uint = 3000
 func StartServer(websocketService *WebsocketService) (ws *websocket.Conn, err error) {
 	go websocketService.Start()
 	u := url.URL{Scheme: "ws", Host: "localhost:" + fmt.Sprint(port), Path: "/"}
-	ws, _, err = websocket.DefaultDialer.Dial(u.String(), nil)
-	if err != nil {
-		err = errors.E(errors.Op("Dial to websocket"), err)
+	// The server starts listening in the background, so retry until it's up
+	for attempt := 0; attempt < 50; attempt++ {
+		ws, _, err = websocket.DefaultDialer.Dial(u.String(), nil)
+		if err == nil {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
 	}
+	err = errors.E(errors.Op("Dial to websocket"), err)
 	return
 }
 
@@ -51,3 +69,224 @@ func TestConnectAndRelay(t *testing.T) {
 	assert.Equal(t, testOrder.GetId(), testOrder2.GetId())
 
 }
+
+func TestWebsocketSubscriptions(t *testing.T) {
+	wss := WebsocketService{Logger: log, Port: port}
+	ws, err := StartServer(&wss)
+	defer wss.Close()
+	assert.NoError(t, err)
+
+	// Subscribe to new ETH orders on the test channel
+	err = ws.WriteMessage(websocket.TextMessage, []byte(`{"action": "SUBSCRIBE", "filters": [{"channelID": "dGVzdENoYW5uZWw=", "operations": ["CREATE"], "assets": ["ETH"]}]}`))
+	assert.NoError(t, err)
+	messageType, p, err := ws.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, websocket.TextMessage, messageType)
+	response := &pb.WebsocketResponse{}
+	assert.NoError(t, jsonpb.UnmarshalString(string(p), response))
+	assert.Empty(t, response.GetError())
+	assert.Len(t, response.GetSubscriptions(), 1)
+	assert.Equal(t, testChannel.GetId(), response.GetSubscriptions()[0].GetChannelID())
+
+	testOrderInBytes, err := proto.Marshal(testOrder)
+	assert.NoError(t, err)
+	otherOrderInBytes, err := proto.Marshal(&pb.Order{Asset: "LTC", CounterAsset: "BTC", Id: []byte("other")})
+	assert.NoError(t, err)
+
+	// Only the message matching every part of the filter is relayed
+	wss.PushToWebsockets(&pb.WireMessage{ChannelID: []byte("otherChannel"), Operation: pb.Operation_CREATE, Data: testOrderInBytes})
+	wss.PushToWebsockets(&pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_DELETE, Data: testOrderInBytes})
+	wss.PushToWebsockets(&pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE, Data: otherOrderInBytes})
+	wss.PushToWebsockets(&pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE, Data: testOrderInBytes})
+	messageType, p, err = ws.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, websocket.BinaryMessage, messageType)
+	received := &pb.WireMessage{}
+	assert.NoError(t, proto.Unmarshal(p, received))
+	assert.Equal(t, pb.Operation_CREATE, received.GetOperation())
+	assert.Equal(t, testOrderInBytes, received.GetData())
+
+	// After unsubscribing nothing is relayed anymore
+	request, err := proto.Marshal(&pb.WebsocketRequest{Action: pb.WebsocketAction_UNSUBSCRIBE})
+	assert.NoError(t, err)
+	assert.NoError(t, ws.WriteMessage(websocket.BinaryMessage, request))
+	_, p, err = ws.ReadMessage()
+	assert.NoError(t, err)
+	response = &pb.WebsocketResponse{}
+	assert.NoError(t, jsonpb.UnmarshalString(string(p), response))
+	assert.Empty(t, response.GetSubscriptions())
+
+	assert.NoError(t, ws.WriteMessage(websocket.TextMessage, []byte("not a request")))
+	_, p, err = ws.ReadMessage()
+	assert.NoError(t, err)
+	response = &pb.WebsocketResponse{}
+	assert.NoError(t, jsonpb.UnmarshalString(string(p), response))
+	assert.NotEmpty(t, response.GetError())
+}
+
+func TestWebsocketJSONEncoding(t *testing.T) {
+	wss := WebsocketService{Logger: log, Port: port}
+	go wss.Start()
+	defer wss.Close()
+
+	// Unknown encodings are refused before upgrading
+	u := url.URL{Scheme: "ws", Host: "localhost:" + fmt.Sprint(port), Path: "/", RawQuery: "encoding=xml"}
+	var ws *websocket.Conn
+	var err error
+	waitFor(t, func() bool {
+		_, resp, err := websocket.DefaultDialer.Dial(u.String(), nil)
+		return err != nil && resp != nil && resp.StatusCode == http.StatusBadRequest
+	}, time.Second)
+
+	u.RawQuery = "encoding=json"
+	ws, _, err = websocket.DefaultDialer.Dial(u.String(), nil)
+	assert.NoError(t, err)
+
+	testOrderInBytes, err := proto.Marshal(testOrder)
+	assert.NoError(t, err)
+	wss.PushToWebsockets(&pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_LOCK, Data: testOrderInBytes})
+
+	// JSON clients get the order decoded
+	messageType, p, err := ws.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, websocket.TextMessage, messageType)
+	event := &pb.WebsocketEvent{}
+	assert.NoError(t, jsonpb.UnmarshalString(string(p), event))
+	assert.Equal(t, pb.Operation_LOCK, event.GetOperation())
+	assert.Equal(t, testChannel.GetId(), event.GetChannelID())
+	assert.Equal(t, testOrder.GetAsset(), event.GetOrder().GetAsset())
+	assert.Empty(t, event.GetData())
+}
+
+func TestWebsocketConnectionManagement(t *testing.T) {
+	wss := WebsocketService{Logger: log, Port: port, MaxConnections: 2}
+	first, err := StartServer(&wss)
+	defer wss.Close()
+	assert.NoError(t, err)
+
+	u := url.URL{Scheme: "ws", Host: "localhost:" + fmt.Sprint(port), Path: "/"}
+	second, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	assert.NoError(t, err)
+	assert.Len(t, wss.getConnections(), 2)
+
+	// Clients over the limit are turned away
+	_, resp, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	// Closed clients are removed, which makes room for new ones
+	first.Close()
+	waitFor(t, func() bool { return len(wss.getConnections()) == 1 }, 2*time.Second)
+	third, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	assert.NoError(t, err)
+	defer third.Close()
+
+	// A client that stops reading is disconnected once its buffer fills up, while the others keep receiving
+	received := make(chan bool, 1)
+	go func() {
+		for {
+			_, _, err := third.ReadMessage()
+			if err != nil {
+				return
+			}
+			select {
+			case received <- true:
+			default:
+			}
+		}
+	}()
+	large := &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_DIRECT_MESSAGE, Data: make([]byte, 64*1024)}
+	waitFor(t, func() bool {
+		wss.PushToWebsockets(large)
+		return len(wss.getConnections()) == 1
+	}, 5*time.Second)
+	waitFor(t, func() bool { return len(received) == 1 }, 2*time.Second)
+	second.Close()
+}
+
+func TestWebsocketAuthentication(t *testing.T) {
+	wss := WebsocketService{Logger: log, Port: port, APIKey: "secret", AllowedOrigins: []string{"https://dashboard.example"}}
+	go wss.Start()
+	defer wss.Close()
+
+	u := url.URL{Scheme: "ws", Host: "localhost:" + fmt.Sprint(port), Path: "/"}
+	dial := func(header http.Header, query string) int {
+		u.RawQuery = query
+		ws, resp, err := websocket.DefaultDialer.Dial(u.String(), header)
+		if err == nil {
+			ws.Close()
+			return http.StatusSwitchingProtocols
+		}
+		if resp == nil {
+			return 0
+		}
+		return resp.StatusCode
+	}
+
+	waitFor(t, func() bool { return dial(nil, "") == http.StatusUnauthorized }, time.Second)
+	assert.Equal(t, http.StatusUnauthorized, dial(http.Header{"Authorization": {"Bearer wrong"}}, ""))
+	assert.Equal(t, http.StatusSwitchingProtocols, dial(http.Header{"Authorization": {"Bearer secret"}}, ""))
+	assert.Equal(t, http.StatusSwitchingProtocols, dial(http.Header{apiKeyHeader: {"secret"}}, ""))
+	assert.Equal(t, http.StatusSwitchingProtocols, dial(nil, apiKeyParameter+"=secret"))
+
+	// Browser pages need an allowed origin as well as the key
+	assert.Equal(t, http.StatusSwitchingProtocols, dial(http.Header{"Origin": {"https://dashboard.example"}}, apiKeyParameter+"=secret"))
+	assert.Equal(t, http.StatusSwitchingProtocols, dial(http.Header{"Origin": {"http://localhost:" + fmt.Sprint(port)}}, apiKeyParameter+"=secret"))
+	assert.Equal(t, http.StatusForbidden, dial(http.Header{"Origin": {"https://evil.example"}}, apiKeyParameter+"=secret"))
+}
+
+func TestWebsocketTLS(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+	defer os.Remove(certFile)
+	defer os.Remove(keyFile)
+
+	wss := WebsocketService{Logger: log, Port: port, CertFile: certFile, KeyFile: keyFile}
+	go wss.Start()
+	defer wss.Close()
+
+	dialer := websocket.Dialer{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	u := url.URL{Scheme: "wss", Host: "localhost:" + fmt.Sprint(port), Path: "/"}
+	var ws *websocket.Conn
+	waitFor(t, func() bool {
+		var err error
+		ws, _, err = dialer.Dial(u.String(), nil)
+		return err == nil
+	}, time.Second)
+	defer ws.Close()
+
+	wss.PushToWebsockets(&pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_DIRECT_MESSAGE})
+	_, p, err := ws.ReadMessage()
+	assert.NoError(t, err)
+	received := &pb.WireMessage{}
+	assert.NoError(t, proto.Unmarshal(p, received))
+	assert.Equal(t, testChannel.GetId(), received.GetChannelID())
+}
+
+// writeTestCertificate writes a self-signed certificate for localhost and its key to temporary files
+func writeTestCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certFile, err := ioutil.TempFile("", "sprawl-cert")
+	assert.NoError(t, err)
+	defer certFile.Close()
+	assert.NoError(t, pem.Encode(certFile, &pem.Block{Type: "CERTIFICATE", Bytes: certificate}))
+	keyFile, err := ioutil.TempFile("", "sprawl-key")
+	assert.NoError(t, err)
+	defer keyFile.Close()
+	assert.NoError(t, pem.Encode(keyFile, &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}))
+	return certFile.Name(), keyFile.Name()
+}
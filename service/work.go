@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/pow"
+)
+
+// getStampData returns what an order's proof-of-work stamp is computed over.
+// The signature already covers everything but the mutable fields, so stamps stay valid when orders are locked and unlocked.
+func getStampData(order *pb.Order) []byte {
+	return append(append([]byte{}, order.GetId()...), order.GetSignature()...)
+}
+
+// getDifficulty returns the proof-of-work difficulty orders on a channel must meet, 0 if the channel hasn't been joined
+func (s *OrderService) getDifficulty(channelID []byte) (uint32, error) {
+	channel, err := getChannel(s.Storage, channelID)
+	if !errors.IsEmpty(err) {
+		return 0, errors.E(errors.Op("Get channel"), err)
+	}
+	if channel == nil {
+		return 0, nil
+	}
+	return channel.GetOptions().GetDifficulty(), nil
+}
+
+// stampOrder stamps a signed order with enough work for its channel, giving up when ctx is done
+func (s *OrderService) stampOrder(ctx context.Context, channelID []byte, order *pb.Order) error {
+	difficulty, err := s.getDifficulty(channelID)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get channel difficulty"), err)
+	}
+	if difficulty > 0 {
+		order.Stamp, err = pow.Solve(ctx, getStampData(order), difficulty)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Solve proof-of-work"), err)
+		}
+	}
+	return nil
+}
+
+// checkWork rejects orders whose stamp doesn't meet the channel's difficulty
+func (s *OrderService) checkWork(channelID []byte, order *pb.Order) error {
+	difficulty, err := s.getDifficulty(channelID)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get channel difficulty"), err)
+	}
+	if !pow.Verify(getStampData(order), order.GetStamp(), difficulty) {
+		return errors.E(errors.Op("Check proof-of-work"), fmt.Sprintf("order %s doesn't meet difficulty %d on channel %s", order.GetId(), difficulty, channelID))
+	}
+	return nil
+}
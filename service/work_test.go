@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/pow"
+	"github.com/stretchr/testify/assert"
+)
+
+const testDifficulty = 16
+
+func storeTestChannel(t *testing.T, orderService *OrderService, channelID []byte, difficulty uint32) {
+	marshaledChannel, err := proto.Marshal(&pb.Channel{Id: channelID, Options: &pb.ChannelOptions{AssetPair: assetPair, Difficulty: difficulty}})
+	assert.NoError(t, err)
+	assert.NoError(t, orderService.Storage.Put(getChannelStorageKey(channelID), marshaledChannel))
+}
+
+func TestProofOfWork(t *testing.T) {
+	local := newSyncTestService()
+	remote := newSyncTestService()
+	channelID := []byte(assetPair)
+	storeTestChannel(t, local, channelID, testDifficulty)
+	storeTestChannel(t, remote, channelID, testDifficulty)
+
+	// Created orders are stamped with enough work, and the stamp doesn't break the signature
+	order := createSyncTestOrder(t, local, channelID)
+	_, signerPublicKey, err := identity.GetIdentity(local.Storage)
+	assert.NoError(t, err)
+	signerID, err := peer.IDFromPublicKey(signerPublicKey)
+	assert.NoError(t, err)
+	assert.True(t, pow.Verify(getStampData(order), order.GetStamp(), testDifficulty))
+	isCreator, err := local.VerifyOrder(signerPublicKey, order)
+	assert.NoError(t, err)
+	assert.True(t, isCreator)
+
+	stamped := marshalWireMessage(t, channelID, pb.Operation_CREATE, order)
+	assert.NoError(t, remote.Validate(channelID, stamped, signerID))
+
+	// Orders without the work are rejected when published and when synced
+	unstampedOrder := *order
+	unstampedOrder.Stamp = 0
+	unstamped := marshalWireMessage(t, channelID, pb.Operation_CREATE, &unstampedOrder)
+	assert.Error(t, remote.Validate(channelID, unstamped, signerID))
+	assert.Error(t, remote.Receive(unstamped, signerID))
+	_, err = remote.applyUpdate(channelID, &pb.SyncUpdate{Orders: []*pb.Order{&unstampedOrder}})
+	assert.NoError(t, err)
+	hasOrder, err := remote.Storage.Has(getOrderStorageKey(channelID, order.GetId()))
+	assert.NoError(t, err)
+	assert.False(t, hasOrder)
+
+	assert.NoError(t, remote.Receive(stamped, signerID))
+	hasOrder, err = remote.Storage.Has(getOrderStorageKey(channelID, order.GetId()))
+	assert.NoError(t, err)
+	assert.True(t, hasOrder)
+}
+
+func TestNoDifficulty(t *testing.T) {
+	orderService := newSyncTestService()
+	storeTestChannel(t, orderService, []byte(assetPair), 0)
+	order := createSyncTestOrder(t, orderService, []byte(assetPair))
+	assert.Equal(t, uint64(0), order.GetStamp())
+}
+
+func TestStampOrderCancelled(t *testing.T) {
+	orderService := newSyncTestService()
+	channelID := []byte(assetPair)
+	storeTestChannel(t, orderService, channelID, pow.MaxDifficulty)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := orderService.Create(cancelled, &pb.CreateRequest{ChannelID: channelID, Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.Error(t, err)
+	orders, err := getChannelOrders(orderService.Storage, channelID)
+	assert.NoError(t, err)
+	assert.Empty(t, orders)
+}